@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/combatlog"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var analyzePlayerName string
+var analyzeEncounter int
+var analyzeRequest string
+var analyzeAliases []string
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze [WoWCombatLog.txt]",
+	Short: "compare a pull from a combat log against the sim",
+	Long: `summarize what a player did during an encounter of a combat log: casts per minute, hit, crit and miss rates,
+aura and DoT uptimes and idle time. With --request, the same setup is simmed for the logged duration and shown side by side.
+Without --encounter, the encounters in the log are listed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if analyzeEncounter < 0 {
+			return listEncounters(args[0])
+		}
+		if analyzePlayerName == "" {
+			return fmt.Errorf("--player is required")
+		}
+
+		aliases := make(map[int32]combatlog.ActionKey)
+		for _, alias := range analyzeAliases {
+			spellID, action, ok := strings.Cut(alias, "=")
+			if !ok {
+				return fmt.Errorf("invalid alias %q, expected SPELLID=spell:ID, SPELLID=item:ID or SPELLID=other:NAME", alias)
+			}
+			id, err := strconv.ParseInt(spellID, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid alias %q: %w", alias, err)
+			}
+			key, err := combatlog.ParseActionKey(action)
+			if err != nil {
+				return err
+			}
+			aliases[int32(id)] = key
+		}
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		summary, err := combatlog.AnalyzePlayer(file, combatlog.AnalyzeOptions{
+			PlayerName:     analyzePlayerName,
+			EncounterIndex: analyzeEncounter,
+			SpellAliases:   aliases,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		var result *proto.RaidSimResult
+		if analyzeRequest != "" {
+			if result, err = simLoggedEncounter(analyzeRequest, summary); err != nil {
+				return err
+			}
+		}
+		comparison, err := combatlog.Compare(summary, result)
+		if err != nil {
+			return err
+		}
+		printComparison(summary, comparison)
+		return nil
+	},
+}
+
+func init() {
+	analyzeCmd.Flags().StringVar(&analyzePlayerName, "player", "", "name of the player to analyze")
+	analyzeCmd.Flags().IntVar(&analyzeEncounter, "encounter", -1, "index of the encounter to analyze, as listed without this flag")
+	analyzeCmd.Flags().StringVar(&analyzeRequest, "request", "", "RaidSimRequest in protojson format with the player's setup as its first player, to sim for the logged duration")
+	analyzeCmd.Flags().StringArrayVar(&analyzeAliases, "alias", nil, "spell of the log which the sim models as another action, e.g. 23723=item:19344")
+}
+
+func listEncounters(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	encounters, err := combatlog.FindEncounters(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if len(encounters) == 0 {
+		return fmt.Errorf("%s: no encounters found", path)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Index\tEncounter\tStart\tDuration\tResult")
+	for i, encounter := range encounters {
+		outcome := "wipe"
+		if encounter.Success {
+			outcome = "kill"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%.1fs\t%s\n", i, encounter.Name, encounter.Start.Format("01/02 15:04:05"), encounter.Duration().Seconds(), outcome)
+	}
+	return w.Flush()
+}
+
+// Sims the request with the encounter's duration fixed to the logged one.
+func simLoggedEncounter(path string, summary *combatlog.PlayerSummary) (*proto.RaidSimResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	request := &proto.RaidSimRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, request); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if request.Encounter == nil {
+		request.Encounter = &proto.Encounter{}
+	}
+	request.Encounter.Duration = summary.Duration().Seconds()
+	request.Encounter.DurationVariation = 0
+	if err := validateRequest(request); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	core.SetDefaultNumThreads(request, numThreads)
+
+	result := core.RunRaidSim(request)
+	if result.ErrorResult != "" {
+		return nil, fmt.Errorf("sim failed: %s", result.ErrorResult)
+	}
+	return result, nil
+}
+
+func printComparison(summary *combatlog.PlayerSummary, comparison *combatlog.Comparison) {
+	fmt.Printf("%s, %s (%.1fs)\n", summary.Player.Name, summary.Encounter.Name, comparison.LogDuration.Seconds())
+	fmt.Printf("DPS: %.1f log, %.1f sim\n", comparison.LogDPS, comparison.SimDPS)
+	fmt.Printf("Idle: %.1f%% log, %.1f%% sim\n\n", comparison.LogIdle*100, comparison.SimIdle*100)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Action\tName\tCPM log\tsim\tDPS log\tsim\tHit% log\tsim\tCrit% log\tsim\tMiss% log\tsim\t")
+	for _, row := range comparison.Actions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", row.Key, row.Name,
+			formatValue(row.InLog, row.Log.CastsPerMinute, 1), formatValue(row.InSim, row.Sim.CastsPerMinute, 1),
+			formatValue(row.InLog, row.Log.DPS, 1), formatValue(row.InSim, row.Sim.DPS, 1),
+			formatValue(row.InLog, row.Log.HitRate*100, 1), formatValue(row.InSim, row.Sim.HitRate*100, 1),
+			formatValue(row.InLog, row.Log.CritRate*100, 1), formatValue(row.InSim, row.Sim.CritRate*100, 1),
+			formatValue(row.InLog, row.Log.MissRate*100, 1), formatValue(row.InSim, row.Sim.MissRate*100, 1))
+	}
+	w.Flush()
+
+	printUptimes("Aura", comparison.Auras)
+	printUptimes(fmt.Sprintf("DoT on %s", summary.PrimaryTarget.Name), comparison.DoTs)
+}
+
+func printUptimes(title string, rows []combatlog.UptimeComparison) {
+	if len(rows) == 0 {
+		return
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "%s\tName\tUptime%% log\tsim\t\n", title)
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", row.Key, row.Name, formatValue(row.InLog, row.Log*100, 1), formatValue(row.InSim, row.Sim*100, 1))
+	}
+	w.Flush()
+}
+
+func formatValue(present bool, value float64, precision int) string {
+	if !present {
+		return "-"
+	}
+	return strconv.FormatFloat(value, 'f', precision, 64)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core/apltext"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var aplWrite bool
+
+var aplCmd = &cobra.Command{
+	Use:   "apl",
+	Short: "format and convert APL rotations",
+	Long:  "format and convert APL rotations between the text syntax and protojson",
+}
+
+var aplFmtCmd = &cobra.Command{
+	Use:   "fmt [file]",
+	Short: "format an APL text file",
+	Long:  "format an APL text file, printing the result to stdout unless --write is set",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		rot, err := apltext.Parse(string(data))
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		if aplWrite {
+			return os.WriteFile(args[0], []byte(apltext.Format(rot)), 0644)
+		}
+		fmt.Print(apltext.Format(rot))
+		return nil
+	},
+}
+
+var aplConvertCmd = &cobra.Command{
+	Use:   "convert [file]",
+	Short: "convert an APL between JSON and text",
+	Long:  "convert an APL between JSON and text. Files ending in .json are converted to text, all others to JSON",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		var output []byte
+		if filepath.Ext(args[0]) == ".json" {
+			rot := &proto.APLRotation{}
+			if err := protojson.Unmarshal(data, rot); err != nil {
+				return fmt.Errorf("%s: %w", args[0], err)
+			}
+			output = []byte(apltext.Format(rot))
+		} else {
+			rot, err := apltext.Parse(string(data))
+			if err != nil {
+				return fmt.Errorf("%s: %w", args[0], err)
+			}
+			output, err = protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(rot)
+			if err != nil {
+				return err
+			}
+			output = append(output, '\n')
+		}
+
+		if outfile == "" {
+			fmt.Print(string(output))
+			return nil
+		}
+		return os.WriteFile(outfile, output, 0644)
+	},
+}
+
+func init() {
+	aplFmtCmd.Flags().BoolVarP(&aplWrite, "write", "w", false, "write the result back to the source file")
+	aplConvertCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+
+	aplCmd.AddCommand(aplFmtCmd)
+	aplCmd.AddCommand(aplConvertCmd)
+}
@@ -21,6 +21,7 @@ func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	simCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	addCacheFlags(simCmd)
 	simCmd.MarkFlagRequired("infile")
 }
 
@@ -35,19 +36,29 @@ func simMain(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
+	if err := validateRequest(input); err != nil {
+		log.Fatal(err)
+	}
+	core.SetDefaultNumThreads(input, numThreads)
 
 	var output []byte
-	reporter := make(chan *proto.ProgressMetrics, 10)
-	core.RunRaidSimAsync(input, reporter)
+	cache := newResultCache()
+	finalResult := &proto.RaidSimResult{}
+	if !loadCachedResult(cache, input, finalResult) {
+		reporter := make(chan *proto.ProgressMetrics, 10)
+		core.RunRaidSimAsync(input, reporter)
 
-	var finalResult *proto.RaidSimResult
-	for v := range reporter {
-		if v.FinalRaidResult != nil {
-			finalResult = v.FinalRaidResult
-			break
+		for v := range reporter {
+			if v.FinalRaidResult != nil {
+				finalResult = v.FinalRaidResult
+				break
+			}
+			if verbose {
+				fmt.Printf("Sim Progress: %d / %d\n", v.CompletedIterations, v.TotalIterations)
+			}
 		}
-		if verbose {
-			fmt.Printf("Sim Progress: %d / %d\n", v.CompletedIterations, v.TotalIterations)
+		if finalResult.ErrorResult == "" {
+			storeCachedResult(cache, input, finalResult)
 		}
 	}
 
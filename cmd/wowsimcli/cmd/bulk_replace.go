@@ -34,6 +34,7 @@ func init() {
 	bulkCmd.Flags().StringVar(&replacefile, "replacefile", "", "location of replacement items file. Writes a CSV result of the items replaced instead of JSON")
 	bulkCmd.Flags().StringVar(&outfile, "output", "", "location of output file, defaults to stdout")
 	bulkCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	addCacheFlags(bulkCmd)
 	bulkCmd.MarkFlagRequired("infile")
 	bulkCmd.MarkFlagRequired("replacefile")
 }
@@ -97,6 +98,13 @@ func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) stri
 			FastMode:           replaceInput.FastMode,
 		},
 	}
+	core.SetDefaultNumThreads(bsr, numThreads)
+	cache := newResultCache()
+	cachedResult := &proto.BulkSimResult{}
+	if loadCachedResult(cache, bsr, cachedResult) {
+		return printCombos(cachedResult)
+	}
+
 	progress := make(chan *proto.ProgressMetrics, 100)
 	core.RunBulkSimAsync(context.Background(), bsr, progress)
 
@@ -114,6 +122,7 @@ func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) stri
 				if status.FinalBulkResult.ErrorResult != "" {
 					fmt.Printf("Failed: %s\n", status.FinalBulkResult.ErrorResult)
 				} else {
+					storeCachedResult(cache, bsr, status.FinalBulkResult)
 					return printCombos(status.FinalBulkResult)
 				}
 			}
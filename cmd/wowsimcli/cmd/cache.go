@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core/simcache"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+var (
+	cliVersion string
+	noCache    bool
+	cacheDir   string
+)
+
+func addCacheFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "always run the sim, instead of reusing a cached result for an identical request with a fixed seed")
+	cmd.Flags().StringVar(&cacheDir, "cache-dir", "", "directory for cached results, defaults to the user cache directory for release builds")
+}
+
+// Returns the result cache, or nil if caching is disabled. Development builds
+// only cache results if --cache-dir is set, since their results change without
+// the version changing.
+func newResultCache() *simcache.Cache {
+	if noCache {
+		return nil
+	}
+	dir := cacheDir
+	if dir == "" && cliVersion != "" {
+		if userCacheDir, err := os.UserCacheDir(); err == nil {
+			dir = filepath.Join(userCacheDir, "wowsimcli")
+		}
+	}
+	if dir == "" {
+		return nil
+	}
+	return simcache.New(cliVersion, 0, dir)
+}
+
+// Loads the cached result for request into result, and returns whether there was one.
+func loadCachedResult(cache *simcache.Cache, request googleProto.Message, result googleProto.Message) bool {
+	data, ok := cache.Get(simcache.RequestHash(request))
+	if !ok {
+		return false
+	}
+	if err := googleProto.Unmarshal(data, result); err != nil {
+		return false
+	}
+	log.Printf("Using cached result")
+	return true
+}
+
+func storeCachedResult(cache *simcache.Cache, request googleProto.Message, result googleProto.Message) {
+	key := simcache.RequestHash(request)
+	if cache == nil || key == "" {
+		return
+	}
+	data, err := googleProto.Marshal(result)
+	if err == nil {
+		err = cache.Put(key, data)
+	}
+	if err != nil {
+		log.Printf("failed to cache result: %s", err)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var compareTargetDifference float64
+
+var compareCmd = &cobra.Command{
+	Use:   "compare [a.json] [b.json]",
+	Short: "compare two sims",
+	Long:  "run two RaidSimRequests with paired iterations, and report whether the difference in raid DPS between them is significant",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requests := make([]*proto.RaidSimRequest, len(args))
+		for i, file := range args {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			requests[i] = &proto.RaidSimRequest{}
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, requests[i]); err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			if err := validateRequest(requests[i]); err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+		}
+
+		request := &proto.CompareSimsRequest{
+			RequestA:         requests[0],
+			RequestB:         requests[1],
+			TargetDifference: compareTargetDifference,
+		}
+		core.SetDefaultNumThreads(request, numThreads)
+		result := core.CompareSims(request)
+		if result.ErrorResult != "" {
+			return fmt.Errorf("sim failed: %s", result.ErrorResult)
+		}
+
+		if outfile != "" {
+			output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(outfile, output, 0666); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("A: %.2f DPS (%s)\n", result.ResultA.RaidMetrics.Dps.Avg, args[0])
+		fmt.Printf("B: %.2f DPS (%s)\n", result.ResultB.RaidMetrics.Dps.Avg, args[1])
+		fmt.Printf("B - A: %+.2f ± %.2f DPS (95%% CI %.2f to %.2f)\n", result.MeanDifference, result.StderrDifference, result.Ci95Low, result.Ci95High)
+		fmt.Printf("p-value: %.4g\n", result.PValue)
+		if compareTargetDifference > 0 {
+			fmt.Printf("Iterations needed to resolve %.2f DPS: %d\n", compareTargetDifference, result.IterationsNeeded)
+		}
+		return nil
+	},
+}
+
+func init() {
+	compareCmd.Flags().Float64Var(&compareTargetDifference, "target-difference", 0, "DPS difference to resolve, used to report the number of iterations needed")
+	compareCmd.Flags().StringVar(&outfile, "outfile", "", "location of an output file for the full CompareSimsResult in protojson format")
+}
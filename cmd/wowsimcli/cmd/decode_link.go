@@ -1,67 +1,58 @@
 package cmd
 
 import (
-	"bytes"
-	"compress/zlib"
-	"encoding/base64"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/assets/database"
+	"github.com/wowsims/sod/sim/importer"
 	"google.golang.org/protobuf/encoding/protojson"
 	goproto "google.golang.org/protobuf/proto"
 )
 
+var decodeLinkPlayer bool
+
 var decodeLinkCmd = &cobra.Command{
 	Use:   "decodelink [link]",
 	Short: "decode wowsims link/url",
-	Long:  "decode wowsims link/url",
-	Args:  cobra.ExactArgs(1),
+	Long: `decode a wowsims link/url and print its settings in protojson format,
+or the Player of a Wowhead gear planner link with its gear, enchants, runes and talents`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return decodeLink(args[0])
 	},
 }
 
-var errInvalidLink = errors.New("invalid wowsims export link")
+func init() {
+	decodeLinkCmd.Flags().BoolVar(&decodeLinkPlayer, "player", false, "print only the Player of an individual sim link, checked against the database")
+}
 
 func decodeLink(link string) error {
-	parts := strings.Split(link, "#")
+	var message goproto.Message
 	switch {
-	case len(parts) != 2:
-		return errInvalidLink
-	case parts[1] == "":
-		return errInvalidLink
-	}
-
-	raw, err := base64.StdEncoding.DecodeString(parts[1])
-	if err != nil {
-		return fmt.Errorf("cannot decode proto from link: %w", err)
-	}
-
-	r, err := zlib.NewReader(bytes.NewReader(raw))
-	if err != nil {
-		return fmt.Errorf("cannot create zlib reader: %w", err)
-	}
-	defer r.Close()
-
-	var buf bytes.Buffer
-	if _, err := buf.ReadFrom(r); err != nil {
-		return fmt.Errorf("reading zlib data failed: %w", err)
-	}
-
-	var settings goproto.Message
-	if strings.Contains(link, "/raid/") {
-		settings = &proto.RaidSimSettings{}
-	} else {
-		settings = &proto.IndividualSimSettings{}
-	}
-
-	if err := goproto.Unmarshal(buf.Bytes(), settings); err != nil {
-		return fmt.Errorf("cannot unmarshal raw proto: %w", err)
+	case strings.Contains(link, "wowhead.com/"):
+		result, err := importer.FromWowheadLink(link, importer.NewEnchantSpells(database.Load().Enchants))
+		if err != nil {
+			return err
+		}
+		printImportWarnings(result)
+		message = result.Player
+	case decodeLinkPlayer:
+		result, err := importer.FromSimLink(link)
+		if err != nil {
+			return err
+		}
+		printImportWarnings(result)
+		message = result.Player
+	default:
+		settings, err := importer.DecodeSimLink(link)
+		if err != nil {
+			return err
+		}
+		message = settings
 	}
 
-	fmt.Println(protojson.Format(goproto.Message(settings)))
+	fmt.Println(protojson.Format(message))
 	return nil
 }
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/assets/database"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/importer"
+	"google.golang.org/protobuf/encoding/protojson"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+var encodeLinkWowhead bool
+
+var encodeLinkCmd = &cobra.Command{
+	Use:   "encodelink [settings file]",
+	Short: "encode settings as a wowsims link/url",
+	Long: `encode IndividualSimSettings or RaidSimSettings, like decodelink prints, or the first player of a RaidSimRequest
+in protojson format as a wowsims link/url, or as a Wowhead gear planner link`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings, err := readLinkSettings(args[0])
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		var link string
+		if encodeLinkWowhead {
+			individual, ok := settings.(*proto.IndividualSimSettings)
+			if !ok {
+				return fmt.Errorf("%s: Wowhead links are only for a single player", args[0])
+			}
+			link, err = importer.ToWowheadLink(individual.GetPlayer(), importer.NewEnchantSpells(database.Load().Enchants))
+		} else {
+			link, err = importer.EncodeSimLink(settings)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Println(link)
+		return nil
+	},
+}
+
+func init() {
+	encodeLinkCmd.Flags().BoolVar(&encodeLinkWowhead, "wowhead", false, "encode the player's gear, enchants, runes and talents as a Wowhead gear planner link instead")
+}
+
+// Reads settings of an individual or raid sim, telling them apart by their
+// fields. Requests give the settings of their first player.
+func readLinkSettings(path string) (goproto.Message, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	unmarshal := protojson.UnmarshalOptions{DiscardUnknown: true}
+	_, hasPlayer := fields["player"]
+	_, hasSimOptions := fields["simOptions"]
+	switch {
+	case hasPlayer:
+		settings := &proto.IndividualSimSettings{}
+		return settings, unmarshal.Unmarshal(data, settings)
+	case hasSimOptions:
+		request := &proto.RaidSimRequest{}
+		if err := unmarshal.Unmarshal(data, request); err != nil {
+			return nil, err
+		}
+		if len(request.GetRaid().GetParties()) == 0 || len(request.Raid.Parties[0].Players) == 0 {
+			return nil, fmt.Errorf("no player in the request")
+		}
+		return importer.SettingsFromRequest(request), nil
+	default:
+		settings := &proto.RaidSimSettings{}
+		return settings, unmarshal.Unmarshal(data, settings)
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/combatlog"
+	"github.com/wowsims/sod/sim/core"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var encounterName string
+var encounterWipes bool
+var encounterLevel int32
+
+var encounterCmd = &cobra.Command{
+	Use:   "encounter [WoWCombatLog.txt]",
+	Short: "derive encounter settings from the kills of a boss in a combat log",
+	Long: `measure the duration, execute timing, number of hostile targets over time and boss swings of the kills of an encounter
+in a combat log, print a summary of each pull to stderr and an Encounter in protojson format to stdout.
+Execute timing needs advanced combat logging.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		profile, err := combatlog.ProfileEncounter(file, combatlog.EncounterOptions{
+			Encounter:    encounterName,
+			IncludeWipes: encounterWipes,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		printEncounterProfile(profile)
+		output, err := protojson.MarshalOptions{Multiline: true}.Marshal(profile.Encounter(core.NewDefaultTarget(encounterLevel)))
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	},
+}
+
+func init() {
+	encounterCmd.Flags().StringVar(&encounterName, "encounter", "", "name or ID of the encounter, defaults to the first one in the log")
+	encounterCmd.Flags().BoolVar(&encounterWipes, "wipes", false, "also include the pulls which didn't kill the boss")
+	encounterCmd.Flags().Int32Var(&encounterLevel, "level", 60, "level of the players, which picks the default target the boss and adds are based on")
+}
+
+func printEncounterProfile(profile *combatlog.EncounterProfile) {
+	out := os.Stderr
+	fmt.Fprintf(out, "%s (%d), %d pulls\n", profile.Name, profile.ID, len(profile.Pulls))
+	for i, pull := range profile.Pulls {
+		outcome := "wipe"
+		if pull.Encounter.Success {
+			outcome = "kill"
+		}
+		fmt.Fprintf(out, "\n%d: %s, %.1fs %s, boss %s\n", i, pull.Encounter.Start.Format("01/02 15:04:05"), pull.Duration().Seconds(), outcome, pull.Boss.Name)
+		if pull.HasHealth {
+			fmt.Fprintf(out, "  Execute: %.1f%% below 20%%, %.1f%% below 25%%, %.1f%% below 35%%\n",
+				pull.ExecuteProportion20*100, pull.ExecuteProportion25*100, pull.ExecuteProportion35*100)
+		}
+		segments := make([]string, 0, len(pull.TargetCounts))
+		for _, segment := range pull.TargetCounts {
+			segments = append(segments, fmt.Sprintf("%.0f-%.0fs: %d", segment.Start.Seconds(), segment.End.Seconds(), segment.Count))
+		}
+		fmt.Fprintf(out, "  Targets: %s\n", strings.Join(segments, ", "))
+	}
+
+	fmt.Fprintf(out, "\nDuration: %.1fs +/- %.1fs\n", profile.AverageDuration().Seconds(), profile.DurationVariation().Seconds())
+	if proportion20, proportion25, proportion35, ok := profile.ExecuteProportions(); ok {
+		fmt.Fprintf(out, "Execute: %.1f%% below 20%%, %.1f%% below 25%%, %.1f%% below 35%%\n", proportion20*100, proportion25*100, proportion35*100)
+	} else {
+		fmt.Fprintln(out, "Execute: unknown, the log has no health values")
+	}
+	for count, fraction := range profile.TargetCountFractions() {
+		if fraction > 0 {
+			fmt.Fprintf(out, "%d targets: %.1f%% of the time\n", count, fraction*100)
+		}
+	}
+	if speed := profile.BossSwingSpeed(); speed > 0 {
+		low, high := profile.BossSwingDamage()
+		fmt.Fprintf(out, "Boss swings: every %.2fs, normal hits of %.0f to %.0f before mitigation\n", speed.Seconds(), low, high)
+	}
+	fmt.Fprintln(out)
+}
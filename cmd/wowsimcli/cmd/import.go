@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/importer"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var importPlayerName string
+var importClass string
+var importRace string
+var importLevel int32
+var importInto string
+
+var importCmd = &cobra.Command{
+	Use:   "import [export file]",
+	Short: "import a character from the in-game addon or a combat log",
+	Long: `import a character's gear, enchants, runes, talents and professions from the JSON written by the WoWSims Exporter addon,
+or the gear from the COMBATANT_INFO lines of a WoWCombatLog.txt file, and print it as a Player in protojson format`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		var result *importer.Result
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+			result, err = importer.FromAddonExport(data)
+		} else {
+			result, err = importer.FromCombatLog(bytes.NewReader(data), importPlayerName)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		if err := applyImportFlags(result); err != nil {
+			return err
+		}
+
+		printImportWarnings(result)
+
+		var output []byte
+		if importInto != "" {
+			output, err = importIntoRequest(importInto, result.Player)
+		} else {
+			output, err = protojson.MarshalOptions{Multiline: true}.Marshal(result.Player)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importPlayerName, "player", "", "name of the player to import from a combat log, needed if it has more than one player's gear")
+	importCmd.Flags().StringVar(&importClass, "class", "", "class of the player, for combat logs which don't include it")
+	importCmd.Flags().StringVar(&importRace, "race", "", "race of the player, for combat logs which don't include it")
+	importCmd.Flags().Int32Var(&importLevel, "level", 0, "level of the player, for combat logs which don't include it")
+	importCmd.Flags().StringVar(&importInto, "into", "", "RaidSimRequest in protojson format whose first player gets the imported character, printing the updated request instead")
+}
+
+func printImportWarnings(result *importer.Result) {
+	for _, unmapped := range result.Unmapped {
+		fmt.Fprintf(os.Stderr, "warning: %s, so it was left out\n", unmapped)
+	}
+	if len(result.Missing) > 0 {
+		fmt.Fprintf(os.Stderr, "warning: not imported: %s\n", strings.Join(result.Missing, ", "))
+	}
+}
+
+// Fills in the parts of the character given by flags, which take precedence
+// over the export.
+func applyImportFlags(result *importer.Result) error {
+	player := result.Player
+	notMissing := func(part string) {
+		result.Missing = slices.DeleteFunc(result.Missing, func(missing string) bool { return missing == part })
+	}
+
+	if importClass != "" {
+		class, ok := importer.ClassFromName(importClass)
+		if !ok {
+			return fmt.Errorf("unknown class %q", importClass)
+		}
+		player.Class = class
+		notMissing("class")
+	}
+	if importRace != "" {
+		race, ok := importer.RaceFromName(importRace)
+		if !ok {
+			return fmt.Errorf("unknown race %q", importRace)
+		}
+		player.Race = race
+		notMissing("race")
+	}
+	if importLevel != 0 {
+		player.Level = importLevel
+		notMissing("level")
+	}
+	return nil
+}
+
+// Replaces the character of the request's first player with the imported one,
+// keeping the rest of their settings like spec options and rotation.
+func importIntoRequest(path string, imported *proto.Player) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	request := &proto.RaidSimRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, request); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(request.GetRaid().GetParties()) == 0 || len(request.Raid.Parties[0].Players) == 0 {
+		return nil, fmt.Errorf("%s: no player to import into", path)
+	}
+
+	player := request.Raid.Parties[0].Players[0]
+	if imported.Class != proto.Class_ClassUnknown && player.Class != proto.Class_ClassUnknown && imported.Class != player.Class {
+		return nil, fmt.Errorf("can't import a %s into a %s", imported.Class, player.Class)
+	}
+	if imported.Name != "" {
+		player.Name = imported.Name
+	}
+	if imported.Class != proto.Class_ClassUnknown {
+		player.Class = imported.Class
+	}
+	if imported.Race != proto.Race_RaceUnknown {
+		player.Race = imported.Race
+	}
+	if imported.Level != 0 {
+		player.Level = imported.Level
+	}
+	if imported.TalentsString != "" {
+		player.TalentsString = imported.TalentsString
+	}
+	if imported.Profession1 != proto.Profession_ProfessionUnknown {
+		player.Profession1 = imported.Profession1
+		player.Profession2 = imported.Profession2
+	}
+	player.Equipment = imported.Equipment
+
+	return protojson.MarshalOptions{Multiline: true}.Marshal(request)
+}
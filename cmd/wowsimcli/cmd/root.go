@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"runtime"
 
 	"github.com/spf13/cobra"
 )
@@ -13,11 +14,26 @@ var rootCmd = &cobra.Command{
 	Long:  "wowsims command line tool",
 }
 
+// Number of parallel workers for sims whose request doesn't set one.
+var numThreads int32
+
+func init() {
+	rootCmd.PersistentFlags().Int32Var(&numThreads, "threads", int32(runtime.NumCPU()), "number of parallel workers for each sim, unless the request sets its own. 0 runs iterations sequentially")
+}
+
 func Execute(version string) {
+	cliVersion = version
 	rootCmd.AddCommand(newVersionCommand(version))
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(bulkCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
+	rootCmd.AddCommand(encodeLinkCmd)
+	rootCmd.AddCommand(aplCmd)
+	rootCmd.AddCommand(compareCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(encounterCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
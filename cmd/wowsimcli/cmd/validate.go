@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [input.json]",
+	Short: "check a sim request for problems",
+	Long:  "check a RaidSimRequest in protojson format for problems like ineligible items, enchants or runes, without running it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		input := &proto.RaidSimRequest{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		if err := validateRequest(input); err != nil {
+			return err
+		}
+		fmt.Println("No errors found.")
+		return nil
+	},
+}
+
+// Prints any warnings about the request to stderr, and returns an error
+// listing the problems which keep it from being simmed.
+func validateRequest(input *proto.RaidSimRequest) error {
+	validation := core.ValidateRaidSimRequest(input)
+	for _, issue := range validation.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s: %s\n", issue.Path, issue.Message)
+	}
+	if len(validation.Errors) == 0 {
+		return nil
+	}
+
+	lines := []string{"invalid request:"}
+	for _, issue := range validation.Errors {
+		lines = append(lines, fmt.Sprintf("  %s: %s", issue.Path, issue.Message))
+	}
+	return fmt.Errorf("%s", strings.Join(lines, "\n"))
+}
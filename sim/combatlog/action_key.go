@@ -0,0 +1,86 @@
+package combatlog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Identifies an action in both the combat log and the sim's metrics. It's
+// like the sim's ActionID, but without the tags which split a spell's metrics
+// in ways the log doesn't, except for telling main and off hand swings apart.
+type ActionKey struct {
+	SpellID int32
+	ItemID  int32
+	OtherID proto.OtherAction
+	Tag     int32
+}
+
+var (
+	MainHandAttackKey = ActionKey{OtherID: proto.OtherAction_OtherActionAttack, Tag: 1}
+	OffHandAttackKey  = ActionKey{OtherID: proto.OtherAction_OtherActionAttack, Tag: 2}
+	ShootKey          = ActionKey{OtherID: proto.OtherAction_OtherActionShoot}
+)
+
+// Spells which the sim models with a different action.
+var DefaultSpellAliases = map[int32]ActionKey{
+	75:   ShootKey, // Auto Shot
+	5019: ShootKey, // Shoot (wands)
+}
+
+func ActionKeyFromProto(id *proto.ActionID) ActionKey {
+	switch raw := id.GetRawId().(type) {
+	case *proto.ActionID_SpellId:
+		return ActionKey{SpellID: raw.SpellId}
+	case *proto.ActionID_ItemId:
+		return ActionKey{ItemID: raw.ItemId}
+	case *proto.ActionID_OtherId:
+		key := ActionKey{OtherID: raw.OtherId}
+		if raw.OtherId == proto.OtherAction_OtherActionAttack {
+			key.Tag = id.Tag
+		}
+		return key
+	}
+	return ActionKey{}
+}
+
+// Parses keys like spell:400640, item:19344 or other:OtherActionShoot.
+func ParseActionKey(s string) (ActionKey, error) {
+	kind, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return ActionKey{}, fmt.Errorf("invalid action %q, expected spell:ID, item:ID or other:NAME", s)
+	}
+	switch kind {
+	case "spell", "item":
+		id, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return ActionKey{}, fmt.Errorf("invalid action %q: %w", s, err)
+		}
+		if kind == "spell" {
+			return ActionKey{SpellID: int32(id)}, nil
+		}
+		return ActionKey{ItemID: int32(id)}, nil
+	case "other":
+		other, ok := proto.OtherAction_value[value]
+		if !ok {
+			return ActionKey{}, fmt.Errorf("invalid action %q: unknown OtherAction", s)
+		}
+		return ActionKey{OtherID: proto.OtherAction(other)}, nil
+	}
+	return ActionKey{}, fmt.Errorf("invalid action %q, expected spell:ID, item:ID or other:NAME", s)
+}
+
+func (key ActionKey) String() string {
+	switch {
+	case key.SpellID != 0:
+		return fmt.Sprintf("spell:%d", key.SpellID)
+	case key.ItemID != 0:
+		return fmt.Sprintf("item:%d", key.ItemID)
+	case key.Tag != 0:
+		return fmt.Sprintf("other:%s/%d", key.OtherID, key.Tag)
+	default:
+		return fmt.Sprintf("other:%s", key.OtherID)
+	}
+}
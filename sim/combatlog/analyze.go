@@ -0,0 +1,496 @@
+package combatlog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// A boss encounter, from its ENCOUNTER_START line to its ENCOUNTER_END line.
+type Encounter struct {
+	ID      int32
+	Name    string
+	Start   time.Time
+	End     time.Time
+	Success bool
+}
+
+func (encounter Encounter) Duration() time.Duration {
+	return encounter.End.Sub(encounter.Start)
+}
+
+// Lists the encounters in the log, in order. An encounter which the log ends
+// during ends at the last line.
+func FindEncounters(r io.Reader) ([]Encounter, error) {
+	var encounters []Encounter
+	var current *Encounter
+	var lastTimestamp time.Time
+	err := ForEachLine(r, func(line *Line) error {
+		lastTimestamp = line.Timestamp
+		switch line.Event {
+		case "ENCOUNTER_START":
+			if current != nil {
+				current.End = line.Timestamp
+				encounters = append(encounters, *current)
+			}
+			current = &Encounter{
+				ID:    int32(line.IntArg(0)),
+				Name:  line.Arg(1),
+				Start: line.Timestamp,
+			}
+		case "ENCOUNTER_END":
+			if current != nil {
+				current.End = line.Timestamp
+				current.Success = line.Arg(4) == "1"
+				encounters = append(encounters, *current)
+				current = nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		current.End = lastTimestamp
+		encounters = append(encounters, *current)
+	}
+	return encounters, nil
+}
+
+type AnalyzeOptions struct {
+	// Name of the player, with or without their realm.
+	PlayerName string
+
+	// Index of the encounter in the list returned by FindEncounters.
+	EncounterIndex int
+
+	// Spells which the sim models with a different action, in addition to
+	// DefaultSpellAliases.
+	SpellAliases map[int32]ActionKey
+}
+
+// What a player's action did during an encounter. The outcomes are counted
+// the same way as the sim's TargetedActionMetrics.
+type ActionSummary struct {
+	Key  ActionKey
+	Name string
+
+	Casts     int
+	Hits      int
+	Crits     int
+	Glances   int
+	Blocks    int
+	Misses    int
+	Dodges    int
+	Parries   int
+	Ticks     int
+	CritTicks int
+	Damage    float64
+}
+
+// How long an aura was active during an encounter.
+type UptimeSummary struct {
+	Key    ActionKey
+	Name   string
+	Uptime time.Duration
+}
+
+// A cast by the player, relative to the start of the encounter. Start is when
+// the cast began, so it's before End for hard casts.
+type CastInterval struct {
+	Key   ActionKey
+	Start time.Duration
+	End   time.Duration
+}
+
+// What a player did during an encounter.
+type PlayerSummary struct {
+	Player    Unit
+	Encounter Encounter
+
+	// Sorted by number of casts, then damage.
+	Actions []*ActionSummary
+
+	// Buffs on the player, sorted by uptime.
+	Auras []UptimeSummary
+
+	// The hostile unit the player did the most damage to, and the player's
+	// debuffs on it, sorted by uptime.
+	PrimaryTarget Unit
+	Debuffs       []UptimeSummary
+
+	Casts []CastInterval
+}
+
+func (summary *PlayerSummary) Duration() time.Duration {
+	return summary.Encounter.Duration()
+}
+
+// Total damage done by the player, not including pets.
+func (summary *PlayerSummary) Damage() float64 {
+	damage := 0.0
+	for _, action := range summary.Actions {
+		damage += action.Damage
+	}
+	return damage
+}
+
+type auraKey struct {
+	unitGUID string
+	spellID  int32
+}
+
+type auraState struct {
+	name        string
+	active      bool
+	activeSince time.Duration
+	uptime      time.Duration
+}
+
+// Tracks aura uptimes. Auras which are removed without having been applied
+// during the encounter count as active since its start.
+type auraTracker map[auraKey]*auraState
+
+func (tracker auraTracker) get(key auraKey, name string) (*auraState, bool) {
+	state, ok := tracker[key]
+	if !ok {
+		state = &auraState{name: name}
+		tracker[key] = state
+	}
+	if name != "" {
+		state.name = name
+	}
+	return state, ok
+}
+
+func (tracker auraTracker) apply(key auraKey, name string, at time.Duration) {
+	if state, _ := tracker.get(key, name); !state.active {
+		state.active = true
+		state.activeSince = at
+	}
+}
+
+// For refreshes and stack changes, which imply the aura was already active.
+func (tracker auraTracker) keep(key auraKey, name string) {
+	if state, existed := tracker.get(key, name); !existed {
+		state.active = true
+	}
+}
+
+func (tracker auraTracker) remove(key auraKey, name string, at time.Duration) {
+	state, existed := tracker.get(key, name)
+	if state.active {
+		state.uptime += at - state.activeSince
+	} else if !existed {
+		state.uptime += at
+	}
+	state.active = false
+}
+
+func (tracker auraTracker) finish(at time.Duration) {
+	for _, state := range tracker {
+		if state.active {
+			state.uptime += at - state.activeSince
+			state.active = false
+		}
+	}
+}
+
+// Summarizes what a player did during an encounter.
+func AnalyzePlayer(r io.Reader, options AnalyzeOptions) (*PlayerSummary, error) {
+	if options.PlayerName == "" {
+		return nil, errors.New("no player name given")
+	}
+	aliases := make(map[int32]ActionKey, len(DefaultSpellAliases)+len(options.SpellAliases))
+	for spellID, key := range DefaultSpellAliases {
+		aliases[spellID] = key
+	}
+	for spellID, key := range options.SpellAliases {
+		aliases[spellID] = key
+	}
+
+	summary := &PlayerSummary{}
+	actions := make(map[ActionKey]*ActionSummary)
+	getAction := func(key ActionKey, name string) *ActionSummary {
+		action, ok := actions[key]
+		if !ok {
+			action = &ActionSummary{Key: key, Name: name}
+			actions[key] = action
+		}
+		return action
+	}
+
+	buffs := make(auraTracker)
+	debuffs := make(auraTracker)
+	damageByTarget := make(map[string]float64)
+	castStarts := make(map[int32]time.Duration)
+
+	encounterIndex := -1
+	inEncounter := false
+	found := false
+	combatantInfos := make(map[string]*CombatantInfo)
+	unitNames := make(map[string]string)
+	var lastTimestamp time.Time
+
+	err := ForEachLine(r, func(line *Line) error {
+		switch line.Event {
+		case "ENCOUNTER_START":
+			encounterIndex++
+			inEncounter = encounterIndex == options.EncounterIndex
+			if inEncounter {
+				found = true
+				summary.Encounter = Encounter{ID: int32(line.IntArg(0)), Name: line.Arg(1), Start: line.Timestamp}
+			}
+			return nil
+		case "ENCOUNTER_END":
+			if inEncounter {
+				summary.Encounter.End = line.Timestamp
+				summary.Encounter.Success = line.Arg(4) == "1"
+				inEncounter = false
+			}
+			return nil
+		case EventCombatantInfo:
+			if inEncounter {
+				info, err := ParseCombatantInfo(line)
+				if err != nil {
+					return err
+				}
+				combatantInfos[info.GUID] = info
+			}
+			return nil
+		}
+		if !inEncounter {
+			return nil
+		}
+		lastTimestamp = line.Timestamp
+
+		event, ok := ParseSpellEvent(line)
+		if !ok {
+			return nil
+		}
+		unitNames[event.Source.GUID] = event.Source.Name
+		unitNames[event.Dest.GUID] = event.Dest.Name
+		if summary.Player.GUID == "" {
+			for _, unit := range []Unit{event.Source, event.Dest} {
+				if unit.IsPlayer() && nameMatches(unit.Name, options.PlayerName) {
+					summary.Player = unit
+				}
+			}
+			if summary.Player.GUID == "" {
+				return nil
+			}
+		}
+		at := line.Timestamp.Sub(summary.Encounter.Start)
+		player := summary.Player.GUID
+
+		// Auras on the player, from anyone.
+		if event.Dest.GUID == player && strings.HasPrefix(event.Suffix, "AURA_") && event.AuraType() == "BUFF" {
+			trackAura(buffs, event, auraKey{spellID: event.SpellID}, at)
+		}
+		if event.Source.GUID != player {
+			return nil
+		}
+
+		key := ActionKey{SpellID: event.SpellID}
+		if alias, ok := aliases[event.SpellID]; ok {
+			key = alias
+		}
+
+		switch event.Suffix {
+		case "CAST_START":
+			castStarts[event.SpellID] = at
+		case "CAST_FAILED":
+			delete(castStarts, event.SpellID)
+		case "CAST_SUCCESS":
+			start, ok := castStarts[event.SpellID]
+			if !ok {
+				start = at
+			}
+			delete(castStarts, event.SpellID)
+			getAction(key, event.SpellName).Casts++
+			summary.Casts = append(summary.Casts, CastInterval{Key: key, Start: start, End: at})
+		case "DAMAGE":
+			damage := event.Damage()
+			if event.Prefix == "SWING" {
+				key = MainHandAttackKey
+				if damage.OffHand {
+					key = OffHandAttackKey
+				}
+			}
+			action := getAction(key, event.SpellName)
+			if event.Prefix == "SWING" {
+				action.Casts++
+			}
+			action.Damage += damage.Amount
+			damageByTarget[event.Dest.GUID] += damage.Amount
+			switch {
+			case event.Prefix == "SPELL_PERIODIC" && damage.Critical:
+				action.CritTicks++
+			case event.Prefix == "SPELL_PERIODIC":
+				action.Ticks++
+			case damage.Critical:
+				action.Crits++
+			case damage.Glancing:
+				action.Glances++
+			case damage.Blocked:
+				action.Blocks++
+			default:
+				action.Hits++
+			}
+		case "MISSED":
+			missType, offHand := event.Missed()
+			if event.Prefix == "SWING" {
+				key = MainHandAttackKey
+				if offHand {
+					key = OffHandAttackKey
+				}
+			}
+			action := getAction(key, event.SpellName)
+			if event.Prefix == "SWING" {
+				action.Casts++
+			}
+			switch missType {
+			case MissTypeDodge:
+				action.Dodges++
+			case MissTypeParry:
+				action.Parries++
+			case MissTypeBlock:
+				action.Blocks++
+			case MissTypeAbsorb:
+				// Fully absorbed damage still landed.
+				action.Hits++
+			default:
+				action.Misses++
+			}
+		case "AURA_APPLIED", "AURA_REMOVED", "AURA_REFRESH", "AURA_APPLIED_DOSE", "AURA_REMOVED_DOSE":
+			if event.Dest.GUID != player && event.AuraType() == "DEBUFF" {
+				trackAura(debuffs, event, auraKey{unitGUID: event.Dest.GUID, spellID: event.SpellID}, at)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("the log has no encounter with index %d", options.EncounterIndex)
+	}
+	if summary.Player.GUID == "" {
+		return nil, fmt.Errorf("%q did nothing during %s", options.PlayerName, summary.Encounter.Name)
+	}
+	if summary.Encounter.End.IsZero() {
+		summary.Encounter.End = lastTimestamp
+	}
+	duration := summary.Duration()
+
+	// Buffs which were already active when the encounter started, and never
+	// changed during it.
+	if info := combatantInfos[summary.Player.GUID]; info != nil {
+		for _, aura := range info.Auras {
+			if _, ok := buffs[auraKey{spellID: aura.SpellID}]; !ok {
+				buffs.apply(auraKey{spellID: aura.SpellID}, "", 0)
+			}
+		}
+	}
+	buffs.finish(duration)
+	debuffs.finish(duration)
+
+	for _, action := range actions {
+		summary.Actions = append(summary.Actions, action)
+	}
+	slices.SortFunc(summary.Actions, func(a, b *ActionSummary) int {
+		if a.Casts != b.Casts {
+			return b.Casts - a.Casts
+		}
+		if c := compareFloats(b.Damage, a.Damage); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key.String(), b.Key.String())
+	})
+
+	summary.Auras = uptimeSummaries(buffs, "", aliases)
+	targetGUID := primaryTarget(damageByTarget, debuffs)
+	summary.PrimaryTarget = Unit{GUID: targetGUID, Name: unitNames[targetGUID]}
+	summary.Debuffs = uptimeSummaries(debuffs, targetGUID, aliases)
+	return summary, nil
+}
+
+// Matches names with or without their realm.
+func nameMatches(name string, playerName string) bool {
+	shortName, _, _ := strings.Cut(name, "-")
+	return strings.EqualFold(name, playerName) || strings.EqualFold(shortName, playerName)
+}
+
+func trackAura(tracker auraTracker, event *SpellEvent, key auraKey, at time.Duration) {
+	switch event.Suffix {
+	case "AURA_APPLIED":
+		tracker.apply(key, event.SpellName, at)
+	case "AURA_REMOVED":
+		tracker.remove(key, event.SpellName, at)
+	case "AURA_REFRESH", "AURA_APPLIED_DOSE", "AURA_REMOVED_DOSE":
+		tracker.keep(key, event.SpellName)
+	}
+}
+
+// Returns the uptimes of the auras on the unit, sorted by uptime.
+func uptimeSummaries(tracker auraTracker, unitGUID string, aliases map[int32]ActionKey) []UptimeSummary {
+	var summaries []UptimeSummary
+	for key, state := range tracker {
+		if key.unitGUID != unitGUID {
+			continue
+		}
+		actionKey := ActionKey{SpellID: key.spellID}
+		if alias, ok := aliases[key.spellID]; ok {
+			actionKey = alias
+		}
+		summaries = append(summaries, UptimeSummary{Key: actionKey, Name: state.name, Uptime: state.uptime})
+	}
+	slices.SortFunc(summaries, func(a, b UptimeSummary) int {
+		if a.Uptime != b.Uptime {
+			return int(b.Uptime - a.Uptime)
+		}
+		return strings.Compare(a.Key.String(), b.Key.String())
+	})
+	return summaries
+}
+
+// The unit which took the most damage from the player, or if they did no
+// damage, the one with the most debuff uptime.
+func primaryTarget(damageByTarget map[string]float64, debuffs auraTracker) string {
+	best := ""
+	bestDamage := 0.0
+	for guid, damage := range damageByTarget {
+		if damage > bestDamage || damage == bestDamage && guid < best {
+			best, bestDamage = guid, damage
+		}
+	}
+	if best != "" {
+		return best
+	}
+
+	uptimes := make(map[string]time.Duration)
+	for key, state := range debuffs {
+		uptimes[key.unitGUID] += state.uptime
+	}
+	var bestUptime time.Duration
+	for guid, uptime := range uptimes {
+		if uptime > bestUptime || uptime == bestUptime && guid < best {
+			best, bestUptime = guid, uptime
+		}
+	}
+	return best
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,157 @@
+package combatlog
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+const (
+	testPlayer = `Player-5826-0000000A,"Caster-Wild Growth",0x511,0x0`
+	testBoss   = `Creature-0-5208-90-12-7800-00001,"Test Boss",0x10a48,0x0`
+	noUnit     = `0000000000000000,nil,0x80000000,0x80000000`
+	advanced   = `Player-5826-0000000A,0000000000000000,100,100,0,0,0,0,-1,0,0,0,0.0,0.0,0,0.0,60`
+)
+
+var testLog = strings.Join([]string{
+	`3/14 20:00:00.000  ENCOUNTER_START,7800,"Test Boss",1,5,90`,
+	`3/14 20:00:00.010  COMBATANT_INFO,Player-5826-0000000A,0,37,109,95,47,0,0,0,4.48,4.48,2.00,0,0,0,0,0,0,0,0,0,0,3213,0,(),(0,0,0,0),[(0,0,(),(),())],[Player-5826-0000000B,10157]`,
+	`3/14 20:00:00.500  SPELL_CAST_START,` + testPlayer + `,` + noUnit + `,10151,"Fireball",0x4`,
+	`3/14 20:00:02.000  SPELL_AURA_REMOVED,` + testPlayer + `,` + testPlayer + `,10060,"Power Infusion",0x2,BUFF`,
+	`3/14 20:00:03.500  SPELL_CAST_SUCCESS,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,` + advanced,
+	`3/14 20:00:04.000  SPELL_DAMAGE,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,` + advanced + `,1000,500,-1,4,0,0,0,1,nil,nil,nil`,
+	`3/14 20:00:04.000  SPELL_AURA_APPLIED,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,DEBUFF`,
+	`3/14 20:00:04.500  SPELL_CAST_SUCCESS,` + testPlayer + `,` + testBoss + `,10199,"Fire Blast",0x4,` + advanced,
+	`3/14 20:00:04.500  SPELL_MISSED,` + testPlayer + `,` + testBoss + `,10199,"Fire Blast",0x4,RESIST,nil,300`,
+	`3/14 20:00:05.000  SWING_DAMAGE,` + testPlayer + `,` + testBoss + `,` + advanced + `,50,50,-1,1,0,0,0,nil,1,nil,nil`,
+	`3/14 20:00:06.000  SPELL_PERIODIC_DAMAGE,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,` + advanced + `,100,100,-1,4,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:00:08.000  SPELL_AURA_REMOVED,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,DEBUFF`,
+	`3/14 20:00:10.000  ENCOUNTER_END,7800,"Test Boss",1,5,1,10000`,
+	`3/14 20:01:00.000  ENCOUNTER_START,7800,"Test Boss",1,5,90`,
+	`3/14 20:01:30.000  ENCOUNTER_END,7800,"Test Boss",1,5,0,30000`,
+}, "\n")
+
+func TestFindEncounters(t *testing.T) {
+	encounters, err := FindEncounters(strings.NewReader(testLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encounters) != 2 {
+		t.Fatalf("Expected 2 encounters, got %d", len(encounters))
+	}
+	if encounter := encounters[0]; encounter.Name != "Test Boss" || encounter.Duration() != 10*time.Second || !encounter.Success {
+		t.Errorf("Unexpected first encounter %+v", encounter)
+	}
+	if encounter := encounters[1]; encounter.Duration() != 30*time.Second || encounter.Success {
+		t.Errorf("Unexpected second encounter %+v", encounter)
+	}
+}
+
+func TestAnalyzePlayer(t *testing.T) {
+	summary, err := AnalyzePlayer(strings.NewReader(testLog), AnalyzeOptions{PlayerName: "caster"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actions := make(map[ActionKey]ActionSummary)
+	for _, action := range summary.Actions {
+		actions[action.Key] = *action
+	}
+	if fireball := actions[ActionKey{SpellID: 10151}]; fireball.Casts != 1 || fireball.Crits != 1 || fireball.Ticks != 1 || fireball.Damage != 1100 {
+		t.Errorf("Unexpected Fireball summary %+v", fireball)
+	}
+	if fireBlast := actions[ActionKey{SpellID: 10199}]; fireBlast.Casts != 1 || fireBlast.Misses != 1 {
+		t.Errorf("Unexpected Fire Blast summary %+v", fireBlast)
+	}
+	if swing := actions[MainHandAttackKey]; swing.Casts != 1 || swing.Glances != 1 {
+		t.Errorf("Unexpected melee summary %+v", swing)
+	}
+
+	uptimes := func(summaries []UptimeSummary) map[int32]time.Duration {
+		result := make(map[int32]time.Duration)
+		for _, summary := range summaries {
+			result[summary.Key.SpellID] = summary.Uptime
+		}
+		return result
+	}
+	// Arcane Intellect was active the whole time, and Power Infusion until it was removed.
+	if auras := uptimes(summary.Auras); auras[10157] != 10*time.Second || auras[10060] != 2*time.Second {
+		t.Errorf("Unexpected aura uptimes %v", auras)
+	}
+	if summary.PrimaryTarget.Name != "Test Boss" {
+		t.Errorf("Expected Test Boss as the primary target, got %+v", summary.PrimaryTarget)
+	}
+	if debuffs := uptimes(summary.Debuffs); debuffs[10151] != 4*time.Second {
+		t.Errorf("Unexpected debuff uptimes %v", debuffs)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	summary, err := AnalyzePlayer(strings.NewReader(testLog), AnalyzeOptions{PlayerName: "Caster-Wild Growth"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fireball := func(tag int32) *proto.ActionID {
+		return &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 10151}, Tag: tag}
+	}
+	result := &proto.RaidSimResult{
+		Iterations:           2,
+		AvgIterationDuration: 10,
+		RaidMetrics: &proto.RaidMetrics{Parties: []*proto.PartyMetrics{{Players: []*proto.UnitMetrics{{
+			Dps: &proto.DistributionMetrics{Avg: 150},
+			Actions: []*proto.ActionMetrics{
+				{Id: fireball(1), Targets: []*proto.TargetedActionMetrics{{Casts: 4, Hits: 2, Crits: 1, Misses: 1, Damage: 3000, CastTimeMs: 12000}}},
+				{Id: fireball(2), Targets: []*proto.TargetedActionMetrics{{Ticks: 4, Damage: 400}}},
+			},
+			Auras: []*proto.AuraMetrics{{Id: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 10157}}, UptimeSecondsAvg: 10}},
+		}}}}},
+		EncounterMetrics: &proto.EncounterMetrics{Targets: []*proto.UnitMetrics{{
+			Auras: []*proto.AuraMetrics{
+				{Id: fireball(0), UptimeSecondsAvg: 5},
+				// Debuffs from other raid members aren't compared.
+				{Id: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 11597}}, UptimeSecondsAvg: 10},
+			},
+		}}},
+	}
+
+	comparison, err := Compare(summary, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	if !near(comparison.LogDPS, 115) || !near(comparison.SimDPS, 150) {
+		t.Errorf("Unexpected DPS %f log, %f sim", comparison.LogDPS, comparison.SimDPS)
+	}
+	// Fireball takes 3s in both, Fire Blast the default global cooldown in the log.
+	if !near(comparison.LogIdle, 0.55) || !near(comparison.SimIdle, 0.4) {
+		t.Errorf("Unexpected idle %f log, %f sim", comparison.LogIdle, comparison.SimIdle)
+	}
+
+	var fireballRow *ActionComparison
+	for i := range comparison.Actions {
+		if comparison.Actions[i].Key.SpellID == 10151 {
+			fireballRow = &comparison.Actions[i]
+		}
+	}
+	if fireballRow == nil || !fireballRow.InLog || !fireballRow.InSim {
+		t.Fatalf("Expected Fireball in both the log and the sim, got %+v", fireballRow)
+	}
+	if !near(fireballRow.Log.CastsPerMinute, 6) || !near(fireballRow.Sim.CastsPerMinute, 12) {
+		t.Errorf("Unexpected Fireball casts per minute %+v", fireballRow)
+	}
+	if !near(fireballRow.Log.CritRate, 1) || !near(fireballRow.Sim.CritRate, 0.25) || !near(fireballRow.Sim.MissRate, 0.25) {
+		t.Errorf("Unexpected Fireball rates %+v", fireballRow)
+	}
+
+	if len(comparison.DoTs) != 1 || !near(comparison.DoTs[0].Log, 0.4) || !near(comparison.DoTs[0].Sim, 0.5) {
+		t.Errorf("Unexpected DoT uptimes %+v", comparison.DoTs)
+	}
+	if aura := comparison.Auras[0]; aura.Key.SpellID != 10157 || !near(aura.Log, 1) || !near(aura.Sim, 1) {
+		t.Errorf("Unexpected aura uptimes %+v", comparison.Auras)
+	}
+}
@@ -0,0 +1,139 @@
+package combatlog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const EventCombatantInfo = "COMBATANT_INFO"
+
+// Number of equipment slots in a COMBATANT_INFO line, which follow the game's
+// inventory slots 1 to 19 (head to tabard).
+const NumInventorySlots = 19
+
+// An item listed in a COMBATANT_INFO line.
+type CombatantItem struct {
+	ID        int32
+	ItemLevel int32
+
+	// Enchantment IDs on the item: the permanent enchant first, then the
+	// temporary one, then any others, such as Season of Discovery engravings.
+	// Unused ones are 0.
+	Enchants []int32
+}
+
+// An aura which was active when a COMBATANT_INFO line was logged.
+type CombatantAura struct {
+	CasterGUID string
+	SpellID    int32
+}
+
+// The gear and auras of a player, logged when an encounter starts.
+type CombatantInfo struct {
+	GUID  string
+	Items [NumInventorySlots]CombatantItem
+	Auras []CombatantAura
+}
+
+// Parses a line like
+//
+//	COMBATANT_INFO,Player-5826-020A1B2C,0,<stats...>,(talents),(...),[(19019,71,(1900,0,0),(),()),...],[auras]
+//
+// Only the equipment and auras are parsed, since the talents of Classic clients
+// are always logged as empty.
+func ParseCombatantInfo(line *Line) (*CombatantInfo, error) {
+	if line.Event != EventCombatantInfo {
+		return nil, fmt.Errorf("expected a %s line, got %s", EventCombatantInfo, line.Event)
+	}
+	info := &CombatantInfo{GUID: line.Arg(0)}
+	if info.GUID == "" {
+		return nil, errors.New("missing GUID")
+	}
+
+	// The number of stat fields differs between clients, but the equipment is
+	// always the first [...] list.
+	equipmentIndex := -1
+	for i, arg := range line.Args {
+		if strings.HasPrefix(arg, "[") {
+			equipmentIndex = i
+			break
+		}
+	}
+	if equipmentIndex == -1 {
+		return nil, errors.New("missing equipment")
+	}
+
+	items, err := SplitList(line.Args[equipmentIndex])
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > NumInventorySlots {
+		return nil, fmt.Errorf("expected at most %d items, got %d", NumInventorySlots, len(items))
+	}
+	for i, itemText := range items {
+		item, err := parseCombatantItem(itemText)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		info.Items[i] = item
+	}
+
+	// The auras are the list after the equipment, as caster GUID and spell ID pairs.
+	if auraIndex := equipmentIndex + 1; auraIndex < len(line.Args) && strings.HasPrefix(line.Args[auraIndex], "[") {
+		fields, err := SplitList(line.Args[auraIndex])
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i+1 < len(fields); i += 2 {
+			spellID, err := parseInt32(fields[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("auras: %w", err)
+			}
+			info.Auras = append(info.Auras, CombatantAura{CasterGUID: fields[i], SpellID: spellID})
+		}
+	}
+	return info, nil
+}
+
+// Parses an item like (19019,71,(1900,0,0),(),()).
+func parseCombatantItem(text string) (CombatantItem, error) {
+	fields, err := SplitList(text)
+	if err != nil {
+		return CombatantItem{}, err
+	}
+	if len(fields) < 2 {
+		return CombatantItem{}, fmt.Errorf("invalid item %q", text)
+	}
+
+	var item CombatantItem
+	if item.ID, err = parseInt32(fields[0]); err != nil {
+		return CombatantItem{}, err
+	}
+	if item.ItemLevel, err = parseInt32(fields[1]); err != nil {
+		return CombatantItem{}, err
+	}
+	if len(fields) > 2 {
+		enchants, err := SplitList(fields[2])
+		if err != nil {
+			return CombatantItem{}, err
+		}
+		for _, enchant := range enchants {
+			id, err := parseInt32(enchant)
+			if err != nil {
+				return CombatantItem{}, err
+			}
+			item.Enchants = append(item.Enchants, id)
+		}
+	}
+	return item, nil
+}
+
+func parseInt32(s string) (int32, error) {
+	value, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return int32(value), nil
+}
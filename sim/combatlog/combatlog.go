@@ -0,0 +1,223 @@
+// Package combatlog parses the WoWCombatLog.txt files written by the game
+// client while /combatlog is enabled.
+package combatlog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A single line of a combat log.
+type Line struct {
+	Number    int // 1-based line number in the file.
+	Timestamp time.Time
+	Event     string
+
+	// Top-level arguments following the event name. Quoted strings are
+	// unquoted, and nested lists are kept as-is including their brackets, so
+	// they can be split further with SplitList.
+	Args []string
+}
+
+// Returns the argument at the index, or "" if there are fewer arguments.
+func (line *Line) Arg(index int) string {
+	if index < len(line.Args) {
+		return line.Args[index]
+	}
+	return ""
+}
+
+// Returns the argument at the index as an int, or 0 if it isn't one.
+func (line *Line) IntArg(index int) int64 {
+	value, _ := strconv.ParseInt(line.Arg(index), 0, 64)
+	return value
+}
+
+// Returns the argument at the index as a float, or 0 if it isn't one.
+func (line *Line) FloatArg(index int) float64 {
+	value, _ := strconv.ParseFloat(line.Arg(index), 64)
+	return value
+}
+
+// Reads lines one at a time from a combat log.
+type Reader struct {
+	scanner *bufio.Scanner
+	lineNum int
+}
+
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	// COMBATANT_INFO lines list every aura on the player, so they can get long.
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	return &Reader{scanner: scanner}
+}
+
+// Returns the next line, or io.EOF once the log has been read. Blank lines are
+// skipped.
+func (r *Reader) Next() (*Line, error) {
+	for r.scanner.Scan() {
+		r.lineNum++
+		text := strings.TrimRight(r.scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		line, err := ParseLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.lineNum, err)
+		}
+		line.Number = r.lineNum
+		return line, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Calls fn for each line of the log, stopping at the first error.
+func ForEachLine(r io.Reader, fn func(line *Line) error) error {
+	reader := NewReader(r)
+	for {
+		line, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := fn(line); err != nil {
+			return fmt.Errorf("line %d: %w", line.Number, err)
+		}
+	}
+}
+
+// Parses a line like
+//
+//	3/14 20:15:31.123  SPELL_CAST_SUCCESS,Player-5826-020A1B2C,"Name-Realm",...
+//
+// Newer clients also write the year and a timezone offset, e.g. 3/14/2024 20:15:31.123-4.
+func ParseLine(text string) (*Line, error) {
+	stamp, rest, ok := strings.Cut(text, "  ")
+	if !ok {
+		return nil, errors.New("missing timestamp")
+	}
+	timestamp, err := parseTimestamp(stamp)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := SplitList(rest)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 || args[0] == "" {
+		return nil, errors.New("missing event name")
+	}
+	return &Line{
+		Timestamp: timestamp,
+		Event:     args[0],
+		Args:      args[1:],
+	}, nil
+}
+
+// Logs don't always include the year, in which case year 0 is used. Only the
+// differences between timestamps matter for our purposes anyway.
+func parseTimestamp(stamp string) (time.Time, error) {
+	datePart, timePart, ok := strings.Cut(strings.TrimSpace(stamp), " ")
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", stamp)
+	}
+
+	dateFields := strings.Split(datePart, "/")
+	if len(dateFields) < 2 || len(dateFields) > 3 {
+		return time.Time{}, fmt.Errorf("invalid date %q", datePart)
+	}
+	var date [3]int
+	for i, field := range dateFields {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", datePart)
+		}
+		date[i] = value
+	}
+
+	// Drop the timezone offset, if any.
+	if i := strings.LastIndexAny(timePart, "+-"); i > 0 {
+		timePart = timePart[:i]
+	}
+	clock, err := time.Parse("15:04:05.999999999", timePart)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q", timePart)
+	}
+
+	return time.Date(date[2], time.Month(date[0]), date[1], clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), time.UTC), nil
+}
+
+// Splits comma-separated values, keeping nested (...) and [...] lists intact
+// and unquoting quoted strings. If the whole value is wrapped in a list, the
+// outer brackets are removed first.
+func SplitList(s string) ([]string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '(' && s[len(s)-1] == ')' || s[0] == '[' && s[len(s)-1] == ']') {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" {
+		return nil, nil
+	}
+
+	var values []string
+	var current strings.Builder
+	depth := 0
+	inQuotes := false
+	wasQuoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case inQuotes:
+			if c == '\\' && i+1 < len(s) {
+				i++
+				current.WriteByte(s[i])
+			} else if c == '"' {
+				inQuotes = false
+			} else {
+				current.WriteByte(c)
+			}
+		case c == '"' && depth == 0:
+			inQuotes = true
+			wasQuoted = true
+		case c == '(' || c == '[':
+			depth++
+			current.WriteByte(c)
+		case c == ')' || c == ']':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced %q in %q", c, s)
+			}
+			current.WriteByte(c)
+		case c == ',' && depth == 0:
+			values = append(values, finishValue(current.String(), wasQuoted))
+			current.Reset()
+			wasQuoted = false
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced brackets in %q", s)
+	}
+	return append(values, finishValue(current.String(), wasQuoted)), nil
+}
+
+func finishValue(value string, wasQuoted bool) string {
+	if wasQuoted {
+		return value
+	}
+	return strings.TrimSpace(value)
+}
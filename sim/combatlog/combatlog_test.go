@@ -0,0 +1,121 @@
+package combatlog
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLine(t *testing.T) {
+	line, err := ParseLine(`3/14 20:15:31.123  SPELL_CAST_SUCCESS,Player-5826-020A1B2C,"Caster-Wild Growth",0x511,0x0,Creature-0-5208-2789-12-218537-00001,"Mekgineer, the Boss",0x10a48,0x0,400640,"Living Bomb",0x4`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := time.Date(0, time.March, 14, 20, 15, 31, 123000000, time.UTC); !line.Timestamp.Equal(want) {
+		t.Errorf("Expected timestamp %v, got %v", want, line.Timestamp)
+	}
+	if line.Event != "SPELL_CAST_SUCCESS" {
+		t.Errorf("Expected SPELL_CAST_SUCCESS, got %s", line.Event)
+	}
+	if !line.HasUnits() {
+		t.Fatal("Expected the line to have units")
+	}
+	if source := line.Source(); source.Name != "Caster-Wild Growth" || !source.IsPlayer() || source.Flags != 0x511 {
+		t.Errorf("Unexpected source %+v", source)
+	}
+	if dest := line.Dest(); dest.Name != "Mekgineer, the Boss" || dest.IsPlayer() {
+		t.Errorf("Unexpected dest %+v", dest)
+	}
+	if spellID := line.IntArg(8); spellID != 400640 {
+		t.Errorf("Expected spell 400640, got %d", spellID)
+	}
+}
+
+func TestParseLineWithYearAndTimezone(t *testing.T) {
+	line, err := ParseLine(`3/14/2024 20:15:31.1234-4  ENCOUNTER_START,2935,"Mekgineer Thermaplugg",1,5,90`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, time.March, 14, 20, 15, 31, 123400000, time.UTC); !line.Timestamp.Equal(want) {
+		t.Errorf("Expected timestamp %v, got %v", want, line.Timestamp)
+	}
+	if line.HasUnits() {
+		t.Error("Expected ENCOUNTER_START not to have units")
+	}
+	if got := line.Arg(1); got != "Mekgineer Thermaplugg" {
+		t.Errorf("Expected the encounter name, got %q", got)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  []string
+	}{
+		{input: "()", want: nil},
+		{input: "1,2,3", want: []string{"1", "2", "3"}},
+		{input: "[(1,(2,3)),(4,[5])]", want: []string{"(1,(2,3))", "(4,[5])"}},
+		{input: `"a,b",c`, want: []string{"a,b", "c"}},
+	} {
+		got, err := SplitList(tc.input)
+		if err != nil {
+			t.Errorf("SplitList(%q) failed: %v", tc.input, err)
+		} else if !slices.Equal(got, tc.want) {
+			t.Errorf("SplitList(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+
+	if _, err := SplitList("(1,(2)"); err == nil {
+		t.Error("Expected an error for unbalanced brackets")
+	}
+}
+
+func TestParseCombatantInfo(t *testing.T) {
+	line, err := ParseLine(`3/14 20:15:31.123  COMBATANT_INFO,Player-5826-020A1B2C,0,37,109,95,47,0,0,0,4.48,4.48,2.00,0,0,0,0,0,0,0,0,0,0,3213,0,(),(0,0,0,0),[(215161,45,(),(),()),(20219,60,(1900,0,7024),(),()),(0,0,(),(),())],[Player-5826-020A1B2C,2457,Player-5826-0000000B,10157]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := ParseCombatantInfo(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.GUID != "Player-5826-020A1B2C" {
+		t.Errorf("Unexpected GUID %s", info.GUID)
+	}
+	if item := info.Items[0]; item.ID != 215161 || item.ItemLevel != 45 || len(item.Enchants) != 0 {
+		t.Errorf("Unexpected first item %+v", item)
+	}
+	if item := info.Items[1]; item.ID != 20219 || !slices.Equal(item.Enchants, []int32{1900, 0, 7024}) {
+		t.Errorf("Unexpected second item %+v", item)
+	}
+	if item := info.Items[2]; item.ID != 0 {
+		t.Errorf("Expected an empty slot, got %+v", item)
+	}
+	if want := []CombatantAura{{"Player-5826-020A1B2C", 2457}, {"Player-5826-0000000B", 10157}}; !slices.Equal(info.Auras, want) {
+		t.Errorf("Expected auras %v, got %v", want, info.Auras)
+	}
+}
+
+func TestForEachLine(t *testing.T) {
+	log := "3/14 20:15:31.123  COMBAT_LOG_VERSION,9,ADVANCED_LOG_ENABLED,1\r\n\r\n3/14 20:15:32.000  ZONE_CHANGE,90,\"Gnomeregan\",0\r\n"
+
+	var events []string
+	err := ForEachLine(strings.NewReader(log), func(line *Line) error {
+		events = append(events, line.Event)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"COMBAT_LOG_VERSION", "ZONE_CHANGE"}; !slices.Equal(events, want) {
+		t.Errorf("Expected events %v, got %v", want, events)
+	}
+
+	err = ForEachLine(strings.NewReader("not a combat log"), func(line *Line) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected an error for line 1, got %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+package combatlog
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// The global cooldown assumed for casts the sim doesn't know about.
+const defaultGCD = 1500 * time.Millisecond
+
+// Rates of an action, per minute of the encounter or per attempt.
+type ActionRates struct {
+	CastsPerMinute float64
+	DPS            float64
+
+	// Fractions of the attempts which landed, were crits, or were missed,
+	// dodged or parried. Periodic ticks aren't counted.
+	HitRate  float64
+	CritRate float64
+	MissRate float64
+}
+
+type ActionComparison struct {
+	Key  ActionKey
+	Name string
+
+	InLog bool
+	InSim bool
+	Log   ActionRates
+	Sim   ActionRates
+}
+
+// Uptimes are fractions of the encounter's duration.
+type UptimeComparison struct {
+	Key  ActionKey
+	Name string
+
+	InLog bool
+	InSim bool
+	Log   float64
+	Sim   float64
+}
+
+// A side-by-side comparison of a player's encounter and a sim of it.
+type Comparison struct {
+	LogDuration time.Duration
+	SimDuration time.Duration
+
+	LogDPS float64
+	SimDPS float64
+
+	// Fractions of the encounter spent neither casting nor on the global cooldown.
+	LogIdle float64
+	SimIdle float64
+
+	Actions []ActionComparison
+	Auras   []UptimeComparison
+	DoTs    []UptimeComparison
+}
+
+// Compares what the player did against the sim of the first player of the
+// first party, whose DoTs are taken from the first target. Without a result,
+// only the log's side is filled in.
+func Compare(summary *PlayerSummary, result *proto.RaidSimResult) (*Comparison, error) {
+	player := &proto.UnitMetrics{}
+	if result == nil {
+		result = &proto.RaidSimResult{}
+	} else if result.ErrorResult != "" {
+		return nil, errors.New(result.ErrorResult)
+	} else if parties := result.GetRaidMetrics().GetParties(); len(parties) > 0 && len(parties[0].Players) > 0 {
+		player = parties[0].Players[0]
+	} else {
+		return nil, errors.New("the sim result has no players")
+	}
+	var target *proto.UnitMetrics
+	if targets := result.GetEncounterMetrics().GetTargets(); len(targets) > 0 {
+		target = targets[0]
+	}
+
+	iterations := float64(max(result.Iterations, 1))
+	logSeconds := summary.Duration().Seconds()
+	simSeconds := result.AvgIterationDuration
+
+	comparison := &Comparison{
+		LogDuration: summary.Duration(),
+		SimDuration: time.Duration(simSeconds * float64(time.Second)),
+		LogDPS:      perSecond(summary.Damage(), logSeconds),
+		SimDPS:      player.GetDps().GetAvg(),
+	}
+
+	// Actions.
+	simActions := make(map[ActionKey]*simActionTotals)
+	for _, action := range player.Actions {
+		key := ActionKeyFromProto(action.Id)
+		totals, ok := simActions[key]
+		if !ok {
+			totals = &simActionTotals{}
+			simActions[key] = totals
+		}
+		for _, targeted := range action.Targets {
+			totals.add(targeted)
+		}
+	}
+
+	logKeys := make(map[ActionKey]bool)
+	for _, action := range summary.Actions {
+		logKeys[action.Key] = true
+		row := ActionComparison{
+			Key:   action.Key,
+			Name:  action.Name,
+			InLog: true,
+			Log:   logActionRates(action, logSeconds),
+		}
+		if totals, ok := simActions[action.Key]; ok {
+			row.InSim = true
+			row.Sim = totals.rates(iterations, simSeconds)
+		}
+		comparison.Actions = append(comparison.Actions, row)
+	}
+	for key, totals := range simActions {
+		if !logKeys[key] && totals.casts > 0 {
+			comparison.Actions = append(comparison.Actions, ActionComparison{
+				Key:   key,
+				InSim: true,
+				Sim:   totals.rates(iterations, simSeconds),
+			})
+		}
+	}
+	slices.SortFunc(comparison.Actions, func(a, b ActionComparison) int {
+		if c := compareFloats(max(b.Log.CastsPerMinute, b.Sim.CastsPerMinute), max(a.Log.CastsPerMinute, a.Sim.CastsPerMinute)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key.String(), b.Key.String())
+	})
+
+	// Idle time.
+	simBusySeconds := 0.0
+	simCastTimes := make(map[ActionKey]time.Duration)
+	for key, totals := range simActions {
+		simBusySeconds += totals.castTimeMs / 1000 / iterations
+		if totals.casts > 0 {
+			simCastTimes[key] = time.Duration(totals.castTimeMs / float64(totals.casts) * float64(time.Millisecond))
+		}
+	}
+	comparison.SimIdle = idleFraction(simSeconds-simBusySeconds, simSeconds)
+	comparison.LogIdle = idleFraction((summary.Duration() - logBusyTime(summary, simCastTimes)).Seconds(), logSeconds)
+
+	// Auras.
+	comparison.Auras = compareUptimes(summary.Auras, player.Auras, logSeconds, simSeconds, nil)
+	// Only the target's auras which come from the player's own actions.
+	comparison.DoTs = compareUptimes(summary.Debuffs, target.GetAuras(), logSeconds, simSeconds, simActions)
+	return comparison, nil
+}
+
+type simActionTotals struct {
+	casts      int32
+	hits       int32
+	crits      int32
+	glances    int32
+	blocks     int32
+	misses     int32
+	damage     float64
+	castTimeMs float64
+}
+
+func (totals *simActionTotals) add(metrics *proto.TargetedActionMetrics) {
+	totals.casts += metrics.Casts
+	totals.hits += metrics.Hits
+	totals.crits += metrics.Crits + metrics.BlockedCrits
+	totals.glances += metrics.Glances
+	totals.blocks += metrics.Blocks
+	totals.misses += metrics.Misses + metrics.Dodges + metrics.Parries
+	totals.damage += metrics.Damage
+	totals.castTimeMs += metrics.CastTimeMs
+}
+
+func (totals *simActionTotals) rates(iterations float64, seconds float64) ActionRates {
+	rates := ActionRates{
+		CastsPerMinute: perSecond(float64(totals.casts)/iterations, seconds) * 60,
+		DPS:            perSecond(totals.damage/iterations, seconds),
+	}
+	attempts := totals.hits + totals.crits + totals.glances + totals.blocks + totals.misses
+	if attempts > 0 {
+		rates.HitRate = float64(attempts-totals.misses) / float64(attempts)
+		rates.CritRate = float64(totals.crits) / float64(attempts)
+		rates.MissRate = float64(totals.misses) / float64(attempts)
+	}
+	return rates
+}
+
+func logActionRates(action *ActionSummary, seconds float64) ActionRates {
+	rates := ActionRates{
+		CastsPerMinute: perSecond(float64(action.Casts), seconds) * 60,
+		DPS:            perSecond(action.Damage, seconds),
+	}
+	misses := action.Misses + action.Dodges + action.Parries
+	attempts := action.Hits + action.Crits + action.Glances + action.Blocks + misses
+	if attempts > 0 {
+		rates.HitRate = float64(attempts-misses) / float64(attempts)
+		rates.CritRate = float64(action.Crits) / float64(attempts)
+		rates.MissRate = float64(misses) / float64(attempts)
+	}
+	return rates
+}
+
+// The time the player spent casting or on the global cooldown. The log doesn't
+// say how long the global cooldown or a channel was, so each cast takes at
+// least as long as it does on average in the sim. Casts the sim doesn't have
+// take at least the default global cooldown.
+func logBusyTime(summary *PlayerSummary, simCastTimes map[ActionKey]time.Duration) time.Duration {
+	intervals := make([]CastInterval, 0, len(summary.Casts))
+	for _, cast := range summary.Casts {
+		minDuration, ok := simCastTimes[cast.Key]
+		if !ok {
+			minDuration = defaultGCD
+		}
+		if cast.End-cast.Start < minDuration {
+			cast.End = cast.Start + minDuration
+		}
+		intervals = append(intervals, cast)
+	}
+	slices.SortFunc(intervals, func(a, b CastInterval) int {
+		return int(a.Start - b.Start)
+	})
+
+	var busy time.Duration
+	var coveredUntil time.Duration
+	for _, interval := range intervals {
+		start := max(interval.Start, coveredUntil, 0)
+		end := min(interval.End, summary.Duration())
+		if end > start {
+			busy += end - start
+			coveredUntil = end
+		}
+	}
+	return busy
+}
+
+func compareUptimes(logUptimes []UptimeSummary, simAuras []*proto.AuraMetrics, logSeconds float64, simSeconds float64, onlyActions map[ActionKey]*simActionTotals) []UptimeComparison {
+	var rows []UptimeComparison
+	indices := make(map[ActionKey]int)
+	for _, uptime := range logUptimes {
+		indices[uptime.Key] = len(rows)
+		rows = append(rows, UptimeComparison{
+			Key:   uptime.Key,
+			Name:  uptime.Name,
+			InLog: true,
+			Log:   perSecond(uptime.Uptime.Seconds(), logSeconds),
+		})
+	}
+	for _, aura := range simAuras {
+		key := ActionKeyFromProto(aura.Id)
+		i, ok := indices[key]
+		if !ok {
+			if _, isOwnAction := onlyActions[key]; onlyActions != nil && !isOwnAction {
+				continue
+			}
+			i = len(rows)
+			indices[key] = i
+			rows = append(rows, UptimeComparison{Key: key})
+		}
+		// Tagged versions of an aura share a key.
+		rows[i].InSim = true
+		rows[i].Sim = max(rows[i].Sim, perSecond(aura.UptimeSecondsAvg, simSeconds))
+	}
+	slices.SortFunc(rows, func(a, b UptimeComparison) int {
+		if c := compareFloats(max(b.Log, b.Sim), max(a.Log, a.Sim)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key.String(), b.Key.String())
+	})
+	return rows
+}
+
+func perSecond(value float64, seconds float64) float64 {
+	if seconds <= 0 {
+		return 0
+	}
+	return value / seconds
+}
+
+func idleFraction(idleSeconds float64, seconds float64) float64 {
+	return max(perSecond(idleSeconds, seconds), 0)
+}
@@ -0,0 +1,512 @@
+package combatlog
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+type EncounterOptions struct {
+	// Name or ID of the encounter, as logged by ENCOUNTER_START. If empty,
+	// the first encounter of the log is used.
+	Encounter string
+
+	// Also profile the pulls which didn't kill the boss.
+	IncludeWipes bool
+}
+
+// A hostile unit which took part in a pull, and when it was in combat,
+// relative to the start of the pull.
+type HostileUnit struct {
+	Unit
+	Start time.Duration
+	End   time.Duration
+}
+
+// A span of a pull during which the number of hostile units in combat didn't
+// change.
+type TargetCountSegment struct {
+	Start time.Duration
+	End   time.Duration
+	Count int
+}
+
+// An auto attack by the boss.
+type BossSwing struct {
+	At time.Duration
+
+	// Whether it landed without being a crit, crushing blow, glancing blow or
+	// partial block. Damage is the unmitigated amount.
+	Normal bool
+	Damage float64
+}
+
+// What the hostile units did during one pull of an encounter.
+type PullProfile struct {
+	Encounter Encounter
+
+	// The hostile unit with the most health.
+	Boss      Unit
+	BossLevel int32
+
+	// Whether the log has the boss's health, which needs advanced combat
+	// logging, and the fractions of the pull for which it was at or below 20%,
+	// 25% and 35% health.
+	HasHealth           bool
+	ExecuteProportion20 float64
+	ExecuteProportion25 float64
+	ExecuteProportion35 float64
+
+	// Sorted by when they entered combat.
+	Units        []HostileUnit
+	TargetCounts []TargetCountSegment
+	BossSwings   []BossSwing
+}
+
+func (pull *PullProfile) Duration() time.Duration {
+	return pull.Encounter.Duration()
+}
+
+// Several pulls of the same encounter.
+type EncounterProfile struct {
+	ID    int32
+	Name  string
+	Pulls []*PullProfile
+}
+
+type healthSample struct {
+	at       time.Duration
+	fraction float64
+}
+
+type pullState struct {
+	profile *PullProfile
+
+	units       map[string]*HostileUnit
+	dead        map[string]bool
+	maxHealth   map[string]float64
+	levels      map[string]int32
+	damageTaken map[string]float64
+	health      map[string][]healthSample
+	swings      map[string][]BossSwing
+}
+
+func newPullState(encounter Encounter) *pullState {
+	return &pullState{
+		profile:     &PullProfile{Encounter: encounter},
+		units:       make(map[string]*HostileUnit),
+		dead:        make(map[string]bool),
+		maxHealth:   make(map[string]float64),
+		levels:      make(map[string]int32),
+		damageTaken: make(map[string]float64),
+		health:      make(map[string][]healthSample),
+		swings:      make(map[string][]BossSwing),
+	}
+}
+
+func (state *pullState) touch(unit Unit, at time.Duration) {
+	if !unit.IsHostileNPC() || state.dead[unit.GUID] {
+		return
+	}
+	hostile, ok := state.units[unit.GUID]
+	if !ok {
+		hostile = &HostileUnit{Unit: unit, Start: at}
+		state.units[unit.GUID] = hostile
+	}
+	hostile.End = at
+}
+
+func (state *pullState) handle(line *Line, at time.Duration) {
+	if !line.HasUnits() {
+		return
+	}
+	source, dest := line.Source(), line.Dest()
+	state.touch(source, at)
+	state.touch(dest, at)
+	if line.Event == "UNIT_DIED" || line.Event == "UNIT_DESTROYED" {
+		state.dead[dest.GUID] = true
+		return
+	}
+
+	event, ok := ParseSpellEvent(line)
+	if !ok {
+		return
+	}
+	if unit, ok := event.AdvancedUnit(); ok && unit.MaxHealth > 0 {
+		state.maxHealth[unit.GUID] = max(state.maxHealth[unit.GUID], unit.MaxHealth)
+		state.levels[unit.GUID] = unit.Level
+		state.health[unit.GUID] = append(state.health[unit.GUID], healthSample{at: at, fraction: unit.Health / unit.MaxHealth})
+	}
+
+	switch event.Suffix {
+	case "DAMAGE":
+		damage := event.Damage()
+		if dest.IsHostileNPC() {
+			state.damageTaken[dest.GUID] += damage.Amount
+		}
+		if event.Prefix == "SWING" && source.IsHostileNPC() && !dest.IsHostileNPC() {
+			state.swings[source.GUID] = append(state.swings[source.GUID], BossSwing{
+				At:     at,
+				Normal: !damage.Critical && !damage.Crushing && !damage.Glancing && !damage.Blocked,
+				Damage: damage.Unmitigated(),
+			})
+		}
+	case "MISSED":
+		if event.Prefix == "SWING" && source.IsHostileNPC() && !dest.IsHostileNPC() {
+			state.swings[source.GUID] = append(state.swings[source.GUID], BossSwing{At: at})
+		}
+	}
+}
+
+func (state *pullState) finish(end time.Time) *PullProfile {
+	pull := state.profile
+	pull.Encounter.End = end
+	duration := pull.Duration()
+
+	for _, unit := range state.units {
+		pull.Units = append(pull.Units, *unit)
+	}
+	slices.SortFunc(pull.Units, func(a, b HostileUnit) int {
+		if a.Start != b.Start {
+			return int(a.Start - b.Start)
+		}
+		return strings.Compare(a.GUID, b.GUID)
+	})
+
+	var boss *HostileUnit
+	for i := range pull.Units {
+		unit := &pull.Units[i]
+		if boss == nil {
+			boss = unit
+			continue
+		}
+		if c := compareFloats(state.maxHealth[unit.GUID], state.maxHealth[boss.GUID]); c > 0 || c == 0 && state.damageTaken[unit.GUID] > state.damageTaken[boss.GUID] {
+			boss = unit
+		}
+	}
+	if boss != nil {
+		pull.Boss = boss.Unit
+		pull.BossLevel = state.levels[boss.GUID]
+		pull.BossSwings = state.swings[boss.GUID]
+		if samples := state.health[boss.GUID]; len(samples) > 0 {
+			pull.HasHealth = true
+			pull.ExecuteProportion20 = fractionAtOrBelow(samples, 0.2, duration)
+			pull.ExecuteProportion25 = fractionAtOrBelow(samples, 0.25, duration)
+			pull.ExecuteProportion35 = fractionAtOrBelow(samples, 0.35, duration)
+		}
+	}
+	pull.TargetCounts = targetCountSegments(pull.Units, duration)
+	return pull
+}
+
+// The fraction of the pull for which the health was at or below the
+// threshold. Health counts as full until the first sample.
+func fractionAtOrBelow(samples []healthSample, threshold float64, duration time.Duration) float64 {
+	var below time.Duration
+	var since time.Duration
+	fraction := 1.0
+	for _, sample := range samples {
+		at := min(sample.at, duration)
+		if fraction <= threshold {
+			below += at - since
+		}
+		since, fraction = at, sample.fraction
+	}
+	if fraction <= threshold {
+		below += duration - since
+	}
+	return perSecond(below.Seconds(), duration.Seconds())
+}
+
+func targetCountSegments(units []HostileUnit, duration time.Duration) []TargetCountSegment {
+	type change struct {
+		at    time.Duration
+		delta int
+	}
+	changes := make([]change, 0, len(units)*2)
+	for _, unit := range units {
+		changes = append(changes, change{unit.Start, 1}, change{unit.End, -1})
+	}
+	slices.SortStableFunc(changes, func(a, b change) int {
+		if a.at != b.at {
+			return int(a.at - b.at)
+		}
+		return a.delta - b.delta
+	})
+
+	var segments []TargetCountSegment
+	addSegment := func(start time.Duration, end time.Duration, count int) {
+		if end <= start {
+			return
+		}
+		if n := len(segments); n > 0 && segments[n-1].Count == count {
+			segments[n-1].End = end
+			return
+		}
+		segments = append(segments, TargetCountSegment{Start: start, End: end, Count: count})
+	}
+
+	count := 0
+	var since time.Duration
+	for _, change := range changes {
+		addSegment(since, change.at, count)
+		since = max(since, change.at)
+		count += change.delta
+	}
+	addSegment(since, duration, count)
+	return segments
+}
+
+// Profiles the pulls of an encounter: how long they took, when the boss was
+// in execute range, how many hostile units were in combat and how the boss
+// swung.
+func ProfileEncounter(r io.Reader, options EncounterOptions) (*EncounterProfile, error) {
+	profile := &EncounterProfile{}
+	var current *pullState
+	var lastTimestamp time.Time
+
+	matches := func(line *Line) bool {
+		if options.Encounter == "" {
+			return profile.Name == "" || line.IntArg(0) == int64(profile.ID)
+		}
+		return strings.EqualFold(line.Arg(1), options.Encounter) || line.Arg(0) == options.Encounter
+	}
+	endPull := func(end time.Time, success bool) {
+		current.profile.Encounter.Success = success
+		if pull := current.finish(end); success || options.IncludeWipes {
+			profile.Pulls = append(profile.Pulls, pull)
+		}
+		current = nil
+	}
+
+	err := ForEachLine(r, func(line *Line) error {
+		switch line.Event {
+		case "ENCOUNTER_START":
+			if current != nil {
+				endPull(lastTimestamp, false)
+			}
+			if matches(line) {
+				profile.ID = int32(line.IntArg(0))
+				profile.Name = line.Arg(1)
+				current = newPullState(Encounter{ID: profile.ID, Name: profile.Name, Start: line.Timestamp})
+			}
+		case "ENCOUNTER_END":
+			if current != nil {
+				endPull(line.Timestamp, line.Arg(4) == "1")
+			}
+		default:
+			if current != nil {
+				current.handle(line, line.Timestamp.Sub(current.profile.Encounter.Start))
+			}
+		}
+		lastTimestamp = line.Timestamp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		endPull(lastTimestamp, false)
+	}
+
+	if profile.Name == "" {
+		if options.Encounter == "" {
+			return nil, fmt.Errorf("the log has no encounters")
+		}
+		return nil, fmt.Errorf("the log has no encounter %q", options.Encounter)
+	}
+	if len(profile.Pulls) == 0 {
+		return nil, fmt.Errorf("the log has no kills of %s", profile.Name)
+	}
+	return profile, nil
+}
+
+func (profile *EncounterProfile) AverageDuration() time.Duration {
+	var total time.Duration
+	for _, pull := range profile.Pulls {
+		total += pull.Duration()
+	}
+	return total / time.Duration(max(len(profile.Pulls), 1))
+}
+
+// Half of the difference between the longest and shortest pull, which is how
+// the sim varies the duration.
+func (profile *EncounterProfile) DurationVariation() time.Duration {
+	if len(profile.Pulls) == 0 {
+		return 0
+	}
+	shortest, longest := profile.Pulls[0].Duration(), profile.Pulls[0].Duration()
+	for _, pull := range profile.Pulls {
+		shortest = min(shortest, pull.Duration())
+		longest = max(longest, pull.Duration())
+	}
+	return (longest - shortest) / 2
+}
+
+// The average execute proportions of the pulls which have the boss's health.
+func (profile *EncounterProfile) ExecuteProportions() (proportion20 float64, proportion25 float64, proportion35 float64, ok bool) {
+	count := 0
+	for _, pull := range profile.Pulls {
+		if pull.HasHealth {
+			count++
+			proportion20 += pull.ExecuteProportion20
+			proportion25 += pull.ExecuteProportion25
+			proportion35 += pull.ExecuteProportion35
+		}
+	}
+	if count == 0 {
+		return 0, 0, 0, false
+	}
+	n := float64(count)
+	return proportion20 / n, proportion25 / n, proportion35 / n, true
+}
+
+// The fraction of the time, over all pulls, during which the number of hostile
+// units in combat was the index.
+func (profile *EncounterProfile) TargetCountFractions() []float64 {
+	var durations []time.Duration
+	var total time.Duration
+	for _, pull := range profile.Pulls {
+		for _, segment := range pull.TargetCounts {
+			for len(durations) <= segment.Count {
+				durations = append(durations, 0)
+			}
+			durations[segment.Count] += segment.End - segment.Start
+		}
+		total += pull.Duration()
+	}
+	fractions := make([]float64, len(durations))
+	for count, duration := range durations {
+		fractions[count] = perSecond(duration.Seconds(), total.Seconds())
+	}
+	return fractions
+}
+
+// The median time between consecutive boss swings, which leaves out the gaps
+// from stuns, movement and target switches.
+func (profile *EncounterProfile) BossSwingSpeed() time.Duration {
+	var intervals []time.Duration
+	for _, pull := range profile.Pulls {
+		for i := 1; i < len(pull.BossSwings); i++ {
+			intervals = append(intervals, pull.BossSwings[i].At-pull.BossSwings[i-1].At)
+		}
+	}
+	if len(intervals) == 0 {
+		return 0
+	}
+	slices.Sort(intervals)
+	return intervals[len(intervals)/2]
+}
+
+// The range of the boss's normal hits, from the 5th to the 95th percentile to
+// leave out outliers like enrages and debuffs.
+func (profile *EncounterProfile) BossSwingDamage() (low float64, high float64) {
+	var damages []float64
+	for _, pull := range profile.Pulls {
+		for _, swing := range pull.BossSwings {
+			if swing.Normal && swing.Damage > 0 {
+				damages = append(damages, swing.Damage)
+			}
+		}
+	}
+	if len(damages) == 0 {
+		return 0, 0
+	}
+	slices.Sort(damages)
+	percentile := func(p float64) float64 {
+		return damages[int(math.Round(p*float64(len(damages)-1)))]
+	}
+	return percentile(0.05), percentile(0.95)
+}
+
+// Builds an encounter for the sim from the profile. The boss and the adds are
+// copies of the template, with the boss's ID, name, level and auto attacks
+// taken from the log. The sim doesn't support targets joining partway, so
+// each kind of add is included as many times as it was in combat on average.
+func (profile *EncounterProfile) Encounter(template *proto.Target) *proto.Encounter {
+	encounter := &proto.Encounter{
+		Duration:             math.Round(profile.AverageDuration().Seconds()),
+		DurationVariation:    math.Round(profile.DurationVariation().Seconds()),
+		ExecuteProportion_20: 0.2,
+		ExecuteProportion_25: 0.25,
+		ExecuteProportion_35: 0.35,
+	}
+	if proportion20, proportion25, proportion35, ok := profile.ExecuteProportions(); ok {
+		encounter.ExecuteProportion_20 = roundTo(proportion20, 3)
+		encounter.ExecuteProportion_25 = roundTo(proportion25, 3)
+		encounter.ExecuteProportion_35 = roundTo(proportion35, 3)
+	}
+	if len(profile.Pulls) == 0 {
+		return encounter
+	}
+
+	bossUnit := profile.Pulls[0].Boss
+	boss := googleProto.Clone(template).(*proto.Target)
+	boss.Id = bossUnit.NPCID()
+	boss.Name = bossUnit.Name
+	if level := profile.Pulls[0].BossLevel; level > 0 {
+		boss.Level = level
+	}
+	if swingSpeed := profile.BossSwingSpeed(); swingSpeed > 0 {
+		boss.SwingSpeed = roundTo(swingSpeed.Seconds(), 2)
+	}
+	if low, high := profile.BossSwingDamage(); low > 0 {
+		// The sim rolls MinBaseDamage * (1 + DamageSpread * rand + AP * EnemyAutoAttackAPCoefficient).
+		attackPower := 0.0
+		if len(boss.Stats) > int(stats.AttackPower) {
+			attackPower = boss.Stats[stats.AttackPower]
+		}
+		boss.MinBaseDamage = roundTo(low/(1+attackPower*core.EnemyAutoAttackAPCoefficient), 2)
+		boss.DamageSpread = roundTo((high-low)/boss.MinBaseDamage, 4)
+	}
+	encounter.Targets = append(encounter.Targets, boss)
+
+	type addKind struct {
+		npcID      int32
+		name       string
+		activeTime time.Duration
+	}
+	var adds []*addKind
+	addsByID := make(map[int32]*addKind)
+	var total time.Duration
+	for _, pull := range profile.Pulls {
+		total += pull.Duration()
+		for _, unit := range pull.Units {
+			npcID := unit.NPCID()
+			if npcID == boss.Id || unit.GUID == pull.Boss.GUID {
+				continue
+			}
+			add, ok := addsByID[npcID]
+			if !ok {
+				add = &addKind{npcID: npcID, name: unit.Name}
+				addsByID[npcID] = add
+				adds = append(adds, add)
+			}
+			add.activeTime += unit.End - unit.Start
+		}
+	}
+	for _, add := range adds {
+		count := int(math.Round(perSecond(add.activeTime.Seconds(), total.Seconds())))
+		for i := 0; i < count; i++ {
+			target := googleProto.Clone(template).(*proto.Target)
+			target.Id = add.npcID
+			target.Name = add.name
+			encounter.Targets = append(encounter.Targets, target)
+		}
+	}
+	return encounter
+}
+
+func roundTo(value float64, decimals int) float64 {
+	value, _ = strconv.ParseFloat(strconv.FormatFloat(value, 'f', decimals, 64), 64)
+	return value
+}
@@ -0,0 +1,148 @@
+package combatlog
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+const (
+	testTank = `Player-5826-0000000C,"Tank-Wild Growth",0x512,0x0`
+	testAdd  = `Creature-0-5208-90-12-7801-00002,"Test Add",0xa48,0x0`
+	bossGUID = `Creature-0-5208-90-12-7800-00001`
+)
+
+func bossAdvanced(health int) string {
+	return fmt.Sprintf("%s,0000000000000000,%d,1000,0,0,0,0,1,0,0,0,0.0,0.0,0,0.0,63", bossGUID, health)
+}
+
+var encounterLog = strings.Join([]string{
+	// A kill with an add.
+	`3/14 20:00:00.000  ENCOUNTER_START,7800,"Test Boss",1,5,90`,
+	`3/14 20:00:00.500  SPELL_DAMAGE,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,` + bossAdvanced(1000) + `,100,100,-1,4,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:00:02.000  SWING_DAMAGE,` + testBoss + `,` + testTank + `,` + bossAdvanced(1000) + `,1000,1200,-1,1,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:00:04.000  SWING_DAMAGE,` + testBoss + `,` + testTank + `,` + bossAdvanced(1000) + `,1100,1300,-1,1,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:00:06.000  SWING_MISSED,` + testBoss + `,` + testTank + `,DODGE,nil`,
+	`3/14 20:00:08.000  SWING_DAMAGE,` + testBoss + `,` + testTank + `,` + bossAdvanced(1000) + `,2000,2400,-1,1,0,0,0,1,nil,nil,nil`,
+	`3/14 20:00:10.000  SPELL_DAMAGE,` + testPlayer + `,` + testAdd + `,10151,"Fireball",0x4,` + advanced + `,100,100,-1,4,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:00:30.000  UNIT_DIED,` + noUnit + `,` + testAdd + `,0`,
+	`3/14 20:01:05.000  SPELL_DAMAGE,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,` + bossAdvanced(350) + `,100,100,-1,4,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:01:20.000  SPELL_DAMAGE,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,` + bossAdvanced(200) + `,100,100,-1,4,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:01:40.000  UNIT_DIED,` + noUnit + `,` + testBoss + `,0`,
+	`3/14 20:01:40.000  ENCOUNTER_END,7800,"Test Boss",1,5,1,100000`,
+	// Another encounter, which is left out.
+	`3/14 20:05:00.000  ENCOUNTER_START,7900,"Other Boss",1,5,90`,
+	`3/14 20:06:00.000  ENCOUNTER_END,7900,"Other Boss",1,5,1,60000`,
+	// A wipe, which is left out by default.
+	`3/14 20:10:00.000  ENCOUNTER_START,7800,"Test Boss",1,5,90`,
+	`3/14 20:10:00.500  SPELL_DAMAGE,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,` + bossAdvanced(1000) + `,100,100,-1,4,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:10:20.000  ENCOUNTER_END,7800,"Test Boss",1,5,0,20000`,
+	// A shorter kill.
+	`3/14 20:20:00.000  ENCOUNTER_START,7800,"Test Boss",1,5,90`,
+	`3/14 20:20:00.500  SPELL_DAMAGE,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,` + bossAdvanced(1000) + `,100,100,-1,4,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:20:02.000  SWING_DAMAGE,` + testBoss + `,` + testTank + `,` + bossAdvanced(1000) + `,900,1100,-1,1,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:20:04.100  SWING_DAMAGE,` + testBoss + `,` + testTank + `,` + bossAdvanced(1000) + `,1050,1250,-1,1,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:21:12.000  SPELL_DAMAGE,` + testPlayer + `,` + testBoss + `,10151,"Fireball",0x4,` + bossAdvanced(150) + `,100,100,-1,4,0,0,0,nil,nil,nil,nil`,
+	`3/14 20:21:20.000  UNIT_DIED,` + noUnit + `,` + testBoss + `,0`,
+	`3/14 20:21:20.000  ENCOUNTER_END,7800,"Test Boss",1,5,1,80000`,
+}, "\n")
+
+func TestProfileEncounter(t *testing.T) {
+	profile, err := ProfileEncounter(strings.NewReader(encounterLog), EncounterOptions{Encounter: "test boss"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.ID != 7800 || len(profile.Pulls) != 2 {
+		t.Fatalf("Expected 2 kills of encounter 7800, got %d of %d", len(profile.Pulls), profile.ID)
+	}
+
+	pull := profile.Pulls[0]
+	if pull.Boss.GUID != bossGUID || pull.BossLevel != 63 || !pull.HasHealth {
+		t.Errorf("Unexpected boss %+v at level %d", pull.Boss, pull.BossLevel)
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	if !near(pull.ExecuteProportion20, 0.2) || !near(pull.ExecuteProportion25, 0.2) || !near(pull.ExecuteProportion35, 0.35) {
+		t.Errorf("Unexpected execute proportions %f, %f, %f", pull.ExecuteProportion20, pull.ExecuteProportion25, pull.ExecuteProportion35)
+	}
+	wantSegments := []TargetCountSegment{
+		{Start: 0, End: 500 * time.Millisecond, Count: 0},
+		{Start: 500 * time.Millisecond, End: 10 * time.Second, Count: 1},
+		{Start: 10 * time.Second, End: 30 * time.Second, Count: 2},
+		{Start: 30 * time.Second, End: 100 * time.Second, Count: 1},
+	}
+	if !slices.Equal(pull.TargetCounts, wantSegments) {
+		t.Errorf("Expected target counts %v, got %v", wantSegments, pull.TargetCounts)
+	}
+	if len(pull.BossSwings) != 4 || pull.BossSwings[2].Normal || pull.BossSwings[3].Normal || pull.BossSwings[0].Damage != 1200 {
+		t.Errorf("Unexpected boss swings %+v", pull.BossSwings)
+	}
+
+	if profile.AverageDuration() != 90*time.Second || profile.DurationVariation() != 10*time.Second {
+		t.Errorf("Unexpected duration %v +/- %v", profile.AverageDuration(), profile.DurationVariation())
+	}
+	if fractions := profile.TargetCountFractions(); len(fractions) != 3 || !near(fractions[0], 1.0/180) || !near(fractions[2], 20.0/180) {
+		t.Errorf("Unexpected target count fractions %v", fractions)
+	}
+	if speed := profile.BossSwingSpeed(); speed != 2*time.Second {
+		t.Errorf("Expected a 2s swing speed, got %v", speed)
+	}
+	if low, high := profile.BossSwingDamage(); low != 1100 || high != 1300 {
+		t.Errorf("Expected swings between 1100 and 1300, got %f and %f", low, high)
+	}
+}
+
+func TestProfileEncounterWithWipes(t *testing.T) {
+	profile, err := ProfileEncounter(strings.NewReader(encounterLog), EncounterOptions{Encounter: "7800", IncludeWipes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profile.Pulls) != 3 || profile.Pulls[1].Encounter.Success {
+		t.Errorf("Expected the wipe to be the second of 3 pulls, got %d pulls", len(profile.Pulls))
+	}
+
+	if _, err := ProfileEncounter(strings.NewReader(encounterLog), EncounterOptions{Encounter: "Missing Boss"}); err == nil {
+		t.Error("Expected an error for an encounter which isn't in the log")
+	}
+}
+
+func TestEncounterProfileToProto(t *testing.T) {
+	profile, err := ProfileEncounter(strings.NewReader(encounterLog), EncounterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &proto.Target{
+		Level:         60,
+		Stats:         stats.Stats{stats.Armor: 3731}.ToFloatArray(),
+		SwingSpeed:    1.5,
+		MinBaseDamage: 5000,
+		TankIndex:     0,
+	}
+
+	encounter := profile.Encounter(template)
+	if encounter.Duration != 90 || encounter.DurationVariation != 10 {
+		t.Errorf("Unexpected duration %f +/- %f", encounter.Duration, encounter.DurationVariation)
+	}
+	if encounter.ExecuteProportion_20 != 0.15 || encounter.ExecuteProportion_25 != 0.15 || encounter.ExecuteProportion_35 != 0.225 {
+		t.Errorf("Unexpected execute proportions %f, %f, %f", encounter.ExecuteProportion_20, encounter.ExecuteProportion_25, encounter.ExecuteProportion_35)
+	}
+	// The add was in combat for 20 of 180 seconds, which rounds to none.
+	if len(encounter.Targets) != 1 {
+		t.Fatalf("Expected only the boss, got %d targets", len(encounter.Targets))
+	}
+	boss := encounter.Targets[0]
+	if boss.Id != 7800 || boss.Name != "Test Boss" || boss.Level != 63 || boss.Stats[stats.Armor] != 3731 {
+		t.Errorf("Unexpected boss %v", boss)
+	}
+	if boss.SwingSpeed != 2 || boss.MinBaseDamage != 1100 || boss.DamageSpread != 0.1818 {
+		t.Errorf("Unexpected boss auto attacks %v", boss)
+	}
+	if template.Level != 60 {
+		t.Error("Expected the template not to change")
+	}
+}
@@ -0,0 +1,209 @@
+package combatlog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Prefixes of the events which start with source and destination units,
+// followed by the number of fields the prefix adds. Longer prefixes come first
+// so they take precedence.
+var eventPrefixes = []struct {
+	name      string
+	numFields int
+}{
+	{"SPELL_PERIODIC_", 3},
+	{"SPELL_BUILDING_", 3},
+	{"SPELL_", 3},
+	{"RANGE_", 3},
+	{"SWING_", 0},
+	{"ENVIRONMENTAL_", 1},
+}
+
+// Suffixes which are preceded by the advanced parameters (unit GUID, health,
+// power, position and so on) when advanced combat logging is enabled.
+var suffixesWithAdvancedParams = map[string]bool{
+	"DAMAGE":        true,
+	"DAMAGE_LANDED": true,
+	"HEAL":          true,
+	"CAST_SUCCESS":  true,
+	"ENERGIZE":      true,
+	"DRAIN":         true,
+	"LEECH":         true,
+}
+
+const numAdvancedParams = 17
+
+// An event caused by a unit, such as a swing, a spell cast or an aura being applied.
+type SpellEvent struct {
+	*Line
+
+	Prefix string // E.g. SWING, SPELL or SPELL_PERIODIC.
+	Suffix string // E.g. DAMAGE, MISSED or AURA_APPLIED.
+
+	Source Unit
+	Dest   Unit
+
+	// Not set for SWING events.
+	SpellID   int32
+	SpellName string
+
+	// The advanced parameters, if the suffix has them and advanced combat
+	// logging is enabled. The first one is the GUID of the unit they
+	// describe, the second one its owner's for pets.
+	Advanced []string
+
+	// The fields specific to the suffix.
+	SuffixArgs []string
+}
+
+// Decodes the line if it's an event with a known prefix.
+func ParseSpellEvent(line *Line) (*SpellEvent, bool) {
+	if !line.HasUnits() {
+		return nil, false
+	}
+
+	for _, prefix := range eventPrefixes {
+		suffix, ok := strings.CutPrefix(line.Event, prefix.name)
+		if !ok {
+			continue
+		}
+		numPrefixArgs := 8 + prefix.numFields
+		if len(line.Args) < numPrefixArgs {
+			return nil, false
+		}
+
+		event := &SpellEvent{
+			Line:       line,
+			Prefix:     strings.TrimSuffix(prefix.name, "_"),
+			Suffix:     suffix,
+			Source:     line.Source(),
+			Dest:       line.Dest(),
+			SuffixArgs: line.Args[numPrefixArgs:],
+		}
+		if prefix.numFields == 3 {
+			event.SpellID = int32(line.IntArg(8))
+			event.SpellName = line.Arg(9)
+		}
+		// Without advanced logging, none of the suffixes have enough fields to
+		// be mistaken for the advanced parameters.
+		if suffixesWithAdvancedParams[suffix] && len(event.SuffixArgs) > numAdvancedParams {
+			event.Advanced = event.SuffixArgs[:numAdvancedParams]
+			event.SuffixArgs = event.SuffixArgs[numAdvancedParams:]
+		}
+		return event, true
+	}
+	return nil, false
+}
+
+func (event *SpellEvent) suffixArg(index int) string {
+	if index < len(event.SuffixArgs) {
+		return event.SuffixArgs[index]
+	}
+	return ""
+}
+
+func (event *SpellEvent) floatSuffixArg(index int) float64 {
+	value, _ := strconv.ParseFloat(event.suffixArg(index), 64)
+	return value
+}
+
+// The unit described by the advanced parameters, and its health and level.
+// Only valid if the event has advanced parameters.
+type AdvancedUnit struct {
+	GUID      string
+	Health    float64
+	MaxHealth float64
+	Level     int32
+}
+
+// Decodes the advanced parameters, if the event has them.
+func (event *SpellEvent) AdvancedUnit() (AdvancedUnit, bool) {
+	if len(event.Advanced) != numAdvancedParams {
+		return AdvancedUnit{}, false
+	}
+	health, _ := strconv.ParseFloat(event.Advanced[2], 64)
+	maxHealth, _ := strconv.ParseFloat(event.Advanced[3], 64)
+	level, _ := strconv.ParseInt(event.Advanced[numAdvancedParams-1], 10, 32)
+	return AdvancedUnit{
+		GUID:      event.Advanced[0],
+		Health:    health,
+		MaxHealth: maxHealth,
+		Level:     int32(level),
+	}, true
+}
+
+// The outcome of a DAMAGE event.
+type Damage struct {
+	Amount float64
+	// The amount before armor and other mitigation, if logged.
+	BaseAmount float64
+
+	Resisted      float64
+	BlockedAmount float64
+	Absorbed      float64
+
+	Critical bool
+	Glancing bool
+	Crushing bool
+	Blocked  bool
+	OffHand  bool
+}
+
+// Decodes the suffix fields of a DAMAGE event: amount, base amount, overkill,
+// school, resisted, blocked, absorbed, critical, glancing, crushing and
+// is-off-hand. Older clients don't log the base amount.
+func (event *SpellEvent) Damage() Damage {
+	offset := 0
+	if len(event.SuffixArgs) >= 11 {
+		offset = 1
+	}
+	damage := Damage{
+		Amount:        event.floatSuffixArg(0),
+		Resisted:      event.floatSuffixArg(3 + offset),
+		BlockedAmount: event.floatSuffixArg(4 + offset),
+		Absorbed:      event.floatSuffixArg(5 + offset),
+		Critical:      event.suffixArg(6+offset) == "1",
+		Glancing:      event.suffixArg(7+offset) == "1",
+		Crushing:      event.suffixArg(8+offset) == "1",
+		OffHand:       event.suffixArg(9+offset) == "1",
+	}
+	damage.Blocked = damage.BlockedAmount > 0
+	if offset == 1 {
+		damage.BaseAmount = event.floatSuffixArg(1)
+	}
+	return damage
+}
+
+// The damage before mitigation if it was logged, or else the damage before
+// resists, blocks and absorbs.
+func (damage Damage) Unmitigated() float64 {
+	if damage.BaseAmount > 0 {
+		return damage.BaseAmount
+	}
+	return damage.Amount + damage.Resisted + damage.BlockedAmount + damage.Absorbed
+}
+
+// Miss types of MISSED events.
+const (
+	MissTypeMiss    = "MISS"
+	MissTypeDodge   = "DODGE"
+	MissTypeParry   = "PARRY"
+	MissTypeBlock   = "BLOCK"
+	MissTypeResist  = "RESIST"
+	MissTypeAbsorb  = "ABSORB"
+	MissTypeImmune  = "IMMUNE"
+	MissTypeEvade   = "EVADE"
+	MissTypeDeflect = "DEFLECT"
+	MissTypeReflect = "REFLECT"
+)
+
+// Decodes the suffix fields of a MISSED event: miss type and is-off-hand.
+func (event *SpellEvent) Missed() (missType string, offHand bool) {
+	return event.suffixArg(0), event.suffixArg(1) == "1"
+}
+
+// Decodes the aura type, BUFF or DEBUFF, of an AURA_* event.
+func (event *SpellEvent) AuraType() string {
+	return event.suffixArg(0)
+}
@@ -0,0 +1,75 @@
+package combatlog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A unit taking part in an event.
+type Unit struct {
+	GUID      string
+	Name      string
+	Flags     int64
+	RaidFlags int64
+}
+
+// Reaction bit of the unit flags.
+const unitFlagHostile = 0x40
+
+func (unit Unit) IsPlayer() bool {
+	return strings.HasPrefix(unit.GUID, "Player-")
+}
+
+// Whether the unit is a creature hostile to the player who wrote the log.
+func (unit Unit) IsHostileNPC() bool {
+	return unit.Flags&unitFlagHostile != 0 && (strings.HasPrefix(unit.GUID, "Creature-") || strings.HasPrefix(unit.GUID, "Vehicle-"))
+}
+
+// The NPC ID of a creature, which is the sixth part of its GUID.
+func (unit Unit) NPCID() int32 {
+	parts := strings.Split(unit.GUID, "-")
+	if len(parts) < 7 || unit.IsPlayer() {
+		return 0
+	}
+	id, _ := strconv.ParseInt(parts[5], 10, 32)
+	return int32(id)
+}
+
+// Events which don't start with the source and destination units.
+var eventsWithoutUnits = map[string]bool{
+	"COMBAT_LOG_VERSION":   true,
+	EventCombatantInfo:     true,
+	"ENCOUNTER_START":      true,
+	"ENCOUNTER_END":        true,
+	"ZONE_CHANGE":          true,
+	"MAP_CHANGE":           true,
+	"EMOTE":                true,
+	"WORLD_MARKER_PLACED":  true,
+	"WORLD_MARKER_REMOVED": true,
+	"CHALLENGE_MODE_START": true,
+	"CHALLENGE_MODE_END":   true,
+}
+
+// Whether the event starts with the usual source and destination unit fields.
+func (line *Line) HasUnits() bool {
+	return !eventsWithoutUnits[line.Event] && len(line.Args) >= 8
+}
+
+// The unit which caused the event. Only valid if HasUnits.
+func (line *Line) Source() Unit {
+	return line.unitAt(0)
+}
+
+// The unit the event happened to. Only valid if HasUnits.
+func (line *Line) Dest() Unit {
+	return line.unitAt(4)
+}
+
+func (line *Line) unitAt(index int) Unit {
+	return Unit{
+		GUID:      line.Arg(index),
+		Name:      line.Arg(index + 1),
+		Flags:     line.IntArg(index + 2),
+		RaidFlags: line.IntArg(index + 3),
+	}
+}
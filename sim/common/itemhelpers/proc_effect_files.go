@@ -0,0 +1,106 @@
+package itemhelpers
+
+import (
+	"fmt"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/hujsonproto"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// The files registered with RegisterProcEffectFile, by name.
+var procEffectFiles = make(map[string]*proto.ProcEffectFile)
+
+// Registers the item and enchant effects of a file, like core.NewItemEffect
+// and core.NewEnchantEffect would, and panics if the file is invalid. Meant
+// to be called from init() with a file embedded in the package.
+func RegisterProcEffectFile(name string, data []byte) {
+	file, err := ParseProcEffectFile(data)
+	if err != nil {
+		panic(fmt.Sprintf("%s: %s", name, err))
+	}
+
+	for _, item := range file.Items {
+		core.NewItemEffect(item.Id, combineEffects(core.MapSlice(item.Procs, func(proc *proto.ProcEffect) core.ApplyEffect {
+			return MakeItemProcEffect(item.Id, item.Name, proc)
+		})))
+	}
+	for _, enchant := range file.Enchants {
+		core.NewEnchantEffect(enchant.Id, combineEffects(core.MapSlice(enchant.Procs, func(proc *proto.ProcEffect) core.ApplyEffect {
+			return MakeEnchantProcEffect(enchant.Id, enchant.Name, proc)
+		})))
+	}
+	procEffectFiles[name] = file
+}
+
+// Returns the files registered with RegisterProcEffectFile, by name.
+func ProcEffectFiles() map[string]*proto.ProcEffectFile {
+	return procEffectFiles
+}
+
+func combineEffects(effects []core.ApplyEffect) core.ApplyEffect {
+	if len(effects) == 1 {
+		return effects[0]
+	}
+	return func(agent core.Agent) {
+		for _, effect := range effects {
+			effect(agent)
+		}
+	}
+}
+
+// Parses a ProcEffectFile in the format of hujsonproto.Unmarshal.
+func ParseProcEffectFile(data []byte) (*proto.ProcEffectFile, error) {
+	file := &proto.ProcEffectFile{}
+	if err := hujsonproto.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+
+	for i, item := range file.Items {
+		if err := validateProcEffects(item, false); err != nil {
+			return nil, fmt.Errorf("items[%d]: %w", i, err)
+		}
+	}
+	for i, enchant := range file.Enchants {
+		if err := validateProcEffects(enchant, true); err != nil {
+			return nil, fmt.Errorf("enchants[%d]: %w", i, err)
+		}
+	}
+	return file, nil
+}
+
+func validateProcEffects(effects *proto.ProcEffects, enchant bool) error {
+	if effects.Id == 0 || effects.Name == "" {
+		return fmt.Errorf("missing id or name")
+	}
+	if len(effects.Procs) == 0 {
+		return fmt.Errorf("no procs")
+	}
+
+	// Each proc makes auras named after it.
+	names := make(map[string]bool)
+	for i, proc := range effects.Procs {
+		name := proc.Name
+		if name == "" {
+			name = effects.Name
+		}
+		if names[name] {
+			return fmt.Errorf("procs[%d]: %q is already the name of another proc", i, name)
+		}
+		names[name] = true
+
+		if err := core.ValidateProcEffect(proc); err != nil {
+			return fmt.Errorf("procs[%d]: %w", i, err)
+		}
+		if enchant {
+			// Enchant effect IDs aren't spell or item IDs, so they can't be used for the action.
+			if proc.SpellId == 0 {
+				return fmt.Errorf("procs[%d]: procs of enchants need a spellId", i)
+			}
+			if proc.Trigger == proto.ProcTrigger_ProcTriggerUse || proc.Trigger == proto.ProcTrigger_ProcTriggerDefensiveUse {
+				return fmt.Errorf("procs[%d]: enchants can't be used", i)
+			}
+		}
+	}
+	return nil
+}
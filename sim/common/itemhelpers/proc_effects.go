@@ -0,0 +1,196 @@
+package itemhelpers
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func init() {
+	core.RegisterProcEffectFactory(MakeItemProcEffect)
+}
+
+// The item or enchant a proc belongs to.
+type procSource struct {
+	id      int32
+	name    string
+	enchant bool
+}
+
+// The action of a proc's trigger aura. Enchant effect IDs aren't spell or
+// item IDs, so enchants use the proc's spell instead.
+func (source procSource) triggerActionID(proc *proto.ProcEffect) core.ActionID {
+	if source.enchant {
+		return core.ActionID{SpellID: proc.SpellId}
+	}
+	return core.ActionID{ItemID: source.id}
+}
+
+// Makes the effect of a proc of an item, like the ones sent with a request's
+// items so that items which aren't in the sim yet can be simmed.
+func MakeItemProcEffect(itemID int32, itemName string, proc *proto.ProcEffect) core.ApplyEffect {
+	return makeProcEffect(procSource{id: itemID, name: itemName}, proc)
+}
+
+// Makes the effect of a proc of an enchant.
+func MakeEnchantProcEffect(effectID int32, enchantName string, proc *proto.ProcEffect) core.ApplyEffect {
+	return makeProcEffect(procSource{id: effectID, name: enchantName, enchant: true}, proc)
+}
+
+func makeProcEffect(source procSource, proc *proto.ProcEffect) core.ApplyEffect {
+	switch {
+	case proc.Trigger == proto.ProcTrigger_ProcTriggerUse || proc.Trigger == proto.ProcTrigger_ProcTriggerDefensiveUse:
+		return makeUseEffect(source, proc)
+	case proc.DamageMax > 0:
+		return makeProcDamageEffect(source, proc)
+	default:
+		return makeProcStatBonusEffect(procStatBonusEffectFromProto(source, proc))
+	}
+}
+
+// Same as core.NewSimpleStatOffensiveTrinketEffect and core.NewSimpleStatDefensiveTrinketEffect.
+func makeUseEffect(source procSource, proc *proto.ProcEffect) core.ApplyEffect {
+	duration := core.DurationFromSeconds(proc.DurationSeconds)
+	flags := core.SpellFlagOffensiveEquipment
+	sharedCDFunc := func(character *core.Character) core.Cooldown {
+		return core.Cooldown{
+			Timer:    character.GetOffensiveTrinketCD(),
+			Duration: duration,
+		}
+	}
+	if proc.Trigger == proto.ProcTrigger_ProcTriggerDefensiveUse {
+		flags = core.SpellFlagDefensiveEquipment
+		sharedCDFunc = func(character *core.Character) core.Cooldown {
+			return core.Cooldown{
+				Timer:    character.GetDefensiveTrinketCD(),
+				Duration: duration,
+			}
+		}
+	}
+	return core.MakeSimpleStatItemActiveEffect(source.id, stats.FromFloatArray(proc.Stats), duration, core.DurationFromSeconds(proc.CooldownSeconds), flags, sharedCDFunc)
+}
+
+func makeProcDamageEffect(source procSource, proc *proto.ProcEffect) core.ApplyEffect {
+	name := procName(source, proc)
+	defType := core.DefenseTypeMagic
+	if proc.MeleeDamage {
+		defType = core.DefenseTypeMelee
+	}
+	sc := procDamageSpellConfig(name, proc.SpellId, core.SpellSchoolFromProto(proc.DamageSchool), proc.DamageMin, proc.DamageMax-proc.DamageMin, proc.BonusCoefficient, defType)
+	if proc.SpellId == 0 {
+		sc.ActionID = source.triggerActionID(proc)
+	}
+
+	trigger, procMaskFunc := procTriggerFromProto(source, proc)
+	if procMaskFunc != nil && proc.Ppm > 0 && proc.IcdSeconds == 0 {
+		// Same as CreateWeaponCoHProcDamage and CreateWeaponEquipProcDamage.
+		return makeWeaponProcDamage(name, proc.Ppm, sc, trigger.SpellFlagsExclude, procMaskFunc)
+	}
+
+	return func(agent core.Agent) {
+		character := agent.GetCharacter()
+		procSpell := character.RegisterSpell(sc)
+
+		config := trigger
+		if procMaskFunc != nil {
+			config.ProcMask = procMaskFunc(character)
+		}
+		config.ActionID = source.triggerActionID(proc)
+		config.Name = name
+		config.ProcChance = proc.ProcChance
+		config.PPM = proc.Ppm
+		config.ICD = core.DurationFromSeconds(proc.IcdSeconds)
+		config.Handler = func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			switch {
+			case config.Callback == core.CallbackOnSpellHitTaken:
+				// Hits back whoever attacked the character.
+				procSpell.Cast(sim, spell.Unit)
+			case result == nil:
+				procSpell.Cast(sim, character.CurrentTarget)
+			default:
+				procSpell.Cast(sim, result.Target)
+			}
+		}
+		core.MakeProcTriggerAura(&character.Unit, config)
+	}
+}
+
+func procStatBonusEffectFromProto(source procSource, proc *proto.ProcEffect) ProcStatBonusEffect {
+	trigger, procMaskFunc := procTriggerFromProto(source, proc)
+	return ProcStatBonusEffect{
+		Name:       procName(source, proc),
+		ID:         source.id,
+		AuraID:     proc.SpellId,
+		Bonus:      stats.FromFloatArray(proc.Stats),
+		Duration:   core.DurationFromSeconds(proc.DurationSeconds),
+		MaxStacks:  proc.MaxStacks,
+		Callback:   trigger.Callback,
+		ProcMask:   trigger.ProcMask,
+		Outcome:    trigger.Outcome,
+		Harmful:    trigger.Harmful,
+		ProcChance: proc.ProcChance,
+		PPM:        proc.Ppm,
+		ICD:        core.DurationFromSeconds(proc.IcdSeconds),
+
+		ProcMaskFunc:      procMaskFunc,
+		SpellFlagsExclude: trigger.SpellFlagsExclude,
+		TriggerActionID:   source.triggerActionID(proc),
+	}
+}
+
+// Procs without a name use the name of their item or enchant.
+func procName(source procSource, proc *proto.ProcEffect) string {
+	if proc.Name != "" {
+		return proc.Name
+	}
+	return source.name
+}
+
+// The parts of a core.ProcTrigger which depend on the proc's trigger. Weapon
+// triggers return a function for the proc mask instead, since it depends on
+// which weapons have the item or enchant.
+func procTriggerFromProto(source procSource, proc *proto.ProcEffect) (core.ProcTrigger, func(*core.Character) core.ProcMask) {
+	var procMaskFunc func(*core.Character) core.ProcMask
+	trigger := core.ProcTrigger{
+		Callback: core.CallbackOnSpellHitDealt,
+	}
+
+	switch proc.Trigger {
+	case proto.ProcTrigger_ProcTriggerMeleeHit:
+		trigger.ProcMask = core.ProcMaskMelee
+		trigger.Outcome = core.OutcomeLanded
+	case proto.ProcTrigger_ProcTriggerMeleeAutoHit:
+		trigger.ProcMask = core.ProcMaskMeleeWhiteHit
+		trigger.Outcome = core.OutcomeLanded
+	case proto.ProcTrigger_ProcTriggerRangedHit:
+		trigger.ProcMask = core.ProcMaskRanged
+		trigger.Outcome = core.OutcomeLanded
+	case proto.ProcTrigger_ProcTriggerSpellHit:
+		trigger.ProcMask = core.ProcMaskSpellDamage
+		trigger.Outcome = core.OutcomeLanded
+		trigger.Harmful = true
+	case proto.ProcTrigger_ProcTriggerSpellCast:
+		trigger.Callback = core.CallbackOnCastComplete
+		trigger.ProcMask = core.ProcMaskSpellDamage | core.ProcMaskSpellHealing
+	case proto.ProcTrigger_ProcTriggerHeal:
+		trigger.Callback = core.CallbackOnHealDealt
+		trigger.ProcMask = core.ProcMaskSpellHealing
+	case proto.ProcTrigger_ProcTriggerMeleeHitTaken:
+		trigger.Callback = core.CallbackOnSpellHitTaken
+		trigger.ProcMask = core.ProcMaskMelee
+		trigger.Outcome = core.OutcomeLanded
+	case proto.ProcTrigger_ProcTriggerWeaponHit, proto.ProcTrigger_ProcTriggerWeaponEquipHit:
+		trigger.Outcome = core.OutcomeLanded
+		procMaskFunc = func(character *core.Character) core.ProcMask {
+			if source.enchant {
+				return character.GetProcMaskForEnchant(source.id)
+			}
+			return character.GetProcMaskForItem(source.id)
+		}
+		trigger.SpellFlagsExclude = core.SpellFlagSuppressWeaponProcs
+		if proc.Trigger == proto.ProcTrigger_ProcTriggerWeaponEquipHit {
+			trigger.SpellFlagsExclude = core.SpellFlagSuppressEquipProcs
+		}
+	}
+	return trigger, procMaskFunc
+}
@@ -0,0 +1,301 @@
+package itemhelpers_test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/common/itemhelpers"
+	_ "github.com/wowsims/sod/sim/common/vanilla"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func init() {
+	core.RegisterAgentFactory(
+		proto.Player_Warrior{},
+		proto.Spec_SpecWarrior,
+		newProcTestAgent,
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_Warrior)
+			if !ok {
+				panic("Invalid spec value for Warrior!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+const procTestBoltID = 42
+
+// Auto attacks with its main hand, and otherwise casts a bolt or uses its
+// cooldowns, so that most triggers happen regularly.
+type procTestAgent struct {
+	core.Character
+}
+
+func newProcTestAgent(character *core.Character, _ *proto.Player) core.Agent {
+	agent := &procTestAgent{
+		Character: *character,
+	}
+	agent.EnableAutoAttacks(agent, core.AutoAttackOptions{
+		MainHand:       agent.WeaponFromMainHand(),
+		AutoSwingMelee: true,
+	})
+	return agent
+}
+
+func (agent *procTestAgent) GetCharacter() *core.Character {
+	return &agent.Character
+}
+
+func (agent *procTestAgent) Initialize() {
+	agent.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: procTestBoltID},
+		SpellSchool: core.SpellSchoolFire,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealDamage(sim, target, 100, spell.OutcomeMagicHitAndCrit)
+		},
+	})
+}
+
+func (agent *procTestAgent) ApplyTalents()            {}
+func (agent *procTestAgent) ApplyRunes()              {}
+func (agent *procTestAgent) Reset(_ *core.Simulation) {}
+
+var procTestRotation = &proto.APLRotation{
+	Type: proto.APLRotation_TypeAPL,
+	PriorityList: []*proto.APLListItem{
+		{Action: &proto.APLAction{Action: &proto.APLAction_AutocastOtherCooldowns{AutocastOtherCooldowns: &proto.APLActionAutocastOtherCooldowns{}}}},
+		{Action: &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+			SpellId: core.ActionID{SpellID: procTestBoltID}.ToProto(),
+		}}}},
+	},
+}
+
+// Sims a procTestAgent with the item, and returns whether each proc of the
+// item dealt damage or activated its aura.
+func simProcs(t *testing.T, item *proto.SimItem, itemSpec *proto.ItemSpec, database *proto.SimDatabase, procs []*proto.ProcEffect) []bool {
+	slot := proto.ItemSlot_ItemSlotTrinket1
+	if item.Type == proto.ItemType_ItemTypeWeapon {
+		slot = proto.ItemSlot_ItemSlotMainHand
+	}
+	equipment := &proto.EquipmentSpec{Items: make([]*proto.ItemSpec, len(core.EquipmentSpec{}))}
+	for i := range equipment.Items {
+		equipment.Items[i] = &proto.ItemSpec{}
+	}
+	equipment.Items[slot] = itemSpec
+	if slot != proto.ItemSlot_ItemSlotMainHand {
+		equipment.Items[proto.ItemSlot_ItemSlotMainHand] = &proto.ItemSpec{Id: procTestSword.Id}
+		database.Items = append(database.Items, procTestSword)
+	}
+	database.Items = append(database.Items, item)
+
+	// The player tanks the target, so procs on hits taken can happen too.
+	raid := core.SinglePlayerRaidProto(&proto.Player{
+		Name:      "Warrior",
+		Race:      proto.Race_RaceHuman,
+		Class:     proto.Class_ClassWarrior,
+		Level:     60,
+		Consumes:  &proto.Consumes{},
+		Buffs:     &proto.IndividualBuffs{},
+		Spec:      &proto.Player_Warrior{Warrior: &proto.Warrior{}},
+		Equipment: equipment,
+		Rotation:  procTestRotation,
+		Database:  database,
+	}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+	raid.Tanks = []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 0}}
+
+	result := core.RunRaidSim(&proto.RaidSimRequest{
+		Raid:      raid,
+		Encounter: core.MakeSingleTargetEncounter(60, 0),
+		SimOptions: &proto.SimOptions{
+			Iterations: 20,
+			RandomSeed: 101,
+		},
+	})
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+
+	metrics := result.RaidMetrics.Parties[0].Players[0]
+	return core.MapSlice(procs, func(proc *proto.ProcEffect) bool {
+		actionID := core.ActionID{ItemID: item.Id}
+		if proc.SpellId != 0 && proc.Trigger != proto.ProcTrigger_ProcTriggerUse && proc.Trigger != proto.ProcTrigger_ProcTriggerDefensiveUse {
+			actionID = core.ActionID{SpellID: proc.SpellId}
+		}
+		for _, action := range metrics.Actions {
+			if core.ProtoToActionID(action.Id).SameAction(actionID) {
+				for _, target := range action.Targets {
+					if target.Damage > 0 {
+						return true
+					}
+				}
+			}
+		}
+		for _, aura := range metrics.Auras {
+			if core.ProtoToActionID(aura.Id).SameAction(actionID) && aura.ProcsAvg > 0 {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+var procTestSword = &proto.SimItem{
+	Id:              990100,
+	Name:            "Sword Of Testing",
+	Type:            proto.ItemType_ItemTypeWeapon,
+	WeaponType:      proto.WeaponType_WeaponTypeSword,
+	HandType:        proto.HandType_HandTypeOneHand,
+	WeaponDamageMin: 100,
+	WeaponDamageMax: 150,
+	WeaponSpeed:     2,
+}
+
+// Returns an item which can have procs of the trigger.
+func procTestItem(id int32, name string, trigger proto.ProcTrigger) *proto.SimItem {
+	if trigger == proto.ProcTrigger_ProcTriggerWeaponHit || trigger == proto.ProcTrigger_ProcTriggerWeaponEquipHit {
+		return &proto.SimItem{
+			Id:              id,
+			Name:            name,
+			Type:            proto.ItemType_ItemTypeWeapon,
+			WeaponType:      proto.WeaponType_WeaponTypeSword,
+			HandType:        proto.HandType_HandTypeOneHand,
+			WeaponDamageMin: 100,
+			WeaponDamageMax: 150,
+			WeaponSpeed:     2,
+		}
+	}
+	return &proto.SimItem{Id: id, Name: name, Type: proto.ItemType_ItemTypeTrinket}
+}
+
+func TestProcEffects(t *testing.T) {
+	strength := stats.Stats{stats.Strength: 50}.ToFloatArray()
+	for i, proc := range []*proto.ProcEffect{
+		{Trigger: proto.ProcTrigger_ProcTriggerMeleeHit, Ppm: 2, Stats: strength, DurationSeconds: 10},
+		{Trigger: proto.ProcTrigger_ProcTriggerMeleeAutoHit, ProcChance: 1, Stats: strength, DurationSeconds: 10, MaxStacks: 5, IcdSeconds: 5},
+		{Trigger: proto.ProcTrigger_ProcTriggerSpellHit, ProcChance: 0.5, DamageSchool: proto.SpellSchool_SpellSchoolFire, DamageMin: 50, DamageMax: 100, SpellId: 990201},
+		{Trigger: proto.ProcTrigger_ProcTriggerSpellCast, ProcChance: 0.2, Stats: strength, DurationSeconds: 10, IcdSeconds: 30, SpellId: 990202},
+		{Trigger: proto.ProcTrigger_ProcTriggerMeleeHitTaken, ProcChance: 0.5, DamageSchool: proto.SpellSchool_SpellSchoolNature, DamageMin: 20, DamageMax: 20, SpellId: 990203},
+		{Trigger: proto.ProcTrigger_ProcTriggerWeaponHit, Ppm: 1, DamageSchool: proto.SpellSchool_SpellSchoolPhysical, DamageMin: 100, DamageMax: 200, MeleeDamage: true, SpellId: 990204},
+		{Trigger: proto.ProcTrigger_ProcTriggerWeaponEquipHit, Ppm: 2, IcdSeconds: 10, DamageSchool: proto.SpellSchool_SpellSchoolShadow, DamageMin: 100, DamageMax: 200, SpellId: 990205},
+		{Trigger: proto.ProcTrigger_ProcTriggerWeaponHit, ProcChance: 0.1, Stats: strength, DurationSeconds: 10},
+		{Trigger: proto.ProcTrigger_ProcTriggerUse, CooldownSeconds: 60, Stats: strength, DurationSeconds: 20},
+		{Trigger: proto.ProcTrigger_ProcTriggerDefensiveUse, CooldownSeconds: 120, Stats: strength, DurationSeconds: 20},
+	} {
+		name := fmt.Sprintf("%s %d", proc.Trigger, i)
+		t.Run(name, func(t *testing.T) {
+			if err := core.ValidateProcEffect(proc); err != nil {
+				t.Fatal(err)
+			}
+			item := procTestItem(990000+int32(i), name, proc.Trigger)
+			item.Procs = []*proto.ProcEffect{proc}
+			if activated := simProcs(t, item, &proto.ItemSpec{Id: item.Id}, &proto.SimDatabase{}, item.Procs)[0]; !activated {
+				t.Fatalf("Expected the proc to activate")
+			}
+		})
+	}
+}
+
+func TestEnchantProcEffects(t *testing.T) {
+	const enchantID = 990300
+	enchant := &proto.SimEnchant{EffectId: enchantID, Type: proto.ItemType_ItemTypeWeapon}
+	// Effects can only be registered for enchants in the database, when built with it.
+	core.EnchantsByEffectID[enchantID] = core.EnchantFromProto(enchant)
+	itemhelpers.RegisterProcEffectFile("test.hujson", []byte(`{
+		"enchants": [
+			{"id": 990300, "name": "Enchant Of Testing", "procs": [{"trigger": "ProcTriggerWeaponHit", "ppm": 2, "stats": {"Strength": 50}, "durationSeconds": 10, "spellId": 990301}]},
+		],
+	}`))
+
+	proc := itemhelpers.ProcEffectFiles()["test.hujson"].Enchants[0].Procs[0]
+	database := &proto.SimDatabase{Enchants: []*proto.SimEnchant{enchant}}
+	item := procTestItem(990302, "Weapon Of Testing", proto.ProcTrigger_ProcTriggerWeaponHit)
+	if activated := simProcs(t, item, &proto.ItemSpec{Id: item.Id, Enchant: enchantID}, database, []*proto.ProcEffect{proc})[0]; !activated {
+		t.Fatalf("Expected the enchant's proc to activate")
+	}
+}
+
+// Sims every item of the registered effect files, to check that each of
+// their procs does something.
+func TestProcEffectFiles(t *testing.T) {
+	files := itemhelpers.ProcEffectFiles()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, effects := range files[name].Items {
+			t.Run(fmt.Sprintf("%s %d %s", name, effects.Id, effects.Name), func(t *testing.T) {
+				item := procTestItem(effects.Id, effects.Name, effects.Procs[0].Trigger)
+				for i, activated := range simProcs(t, item, &proto.ItemSpec{Id: effects.Id}, &proto.SimDatabase{}, effects.Procs) {
+					if !activated {
+						t.Errorf("Expected procs[%d] to activate", i)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestParseProcEffectFile(t *testing.T) {
+	file, err := itemhelpers.ParseProcEffectFile([]byte(`
+		// Comments and trailing commas are allowed.
+		{
+			"items": [
+				{"id": 1, "name": "Trinket", "procs": [{"trigger": "ProcTriggerUse", "stats": {"AttackPower": 100}, "durationSeconds": 20, "cooldownSeconds": 120}]},
+			],
+		}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stats.FromFloatArray(file.Items[0].Procs[0].Stats); got != (stats.Stats{stats.AttackPower: 100}) {
+		t.Fatalf("Expected 100 attack power, got %v", got)
+	}
+
+	for _, tc := range []struct {
+		file string
+		err  string
+	}{
+		{
+			file: `{"items": [{"id": 1, "name": "Trinket", "procs": [{"trigger": "ProcTriggerUse", "stats": {"Power": 100}, "durationSeconds": 20, "cooldownSeconds": 120}]}]}`,
+			err:  `items[0].procs[0]: unknown stat "Power"`,
+		},
+		{
+			file: `{"items": [{"id": 1, "name": "Trinket", "procs": [{"trigger": "ProcTriggerUse", "durationSeconds": 20}]}]}`,
+			err:  "items[0]: procs[0]: proc.cooldown_seconds: Cooldown must be positive",
+		},
+		{
+			file: `{"items": [{"id": 1, "name": "Trinket", "procs": [{"trigger": "ProcTriggerUse", "stats": [100], "durationSeconds": 20, "cooldownSeconds": 120}, {"trigger": "ProcTriggerUse", "stats": [100], "durationSeconds": 20, "cooldownSeconds": 120}]}]}`,
+			err:  `items[0]: procs[1]: "Trinket" is already the name of another proc`,
+		},
+		{
+			file: `{"enchants": [{"id": 1, "name": "Enchant", "procs": [{"trigger": "ProcTriggerWeaponHit", "ppm": 1, "damageMin": 10, "damageMax": 20}]}]}`,
+			err:  "enchants[0]: procs[0]: procs of enchants need a spellId",
+		},
+		{
+			file: `{"items": [{"id": 1, "name": "Trinket", "procz": []}]}`,
+			err:  "procz",
+		},
+	} {
+		if _, err := itemhelpers.ParseProcEffectFile([]byte(tc.file)); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("Expected error containing %q, got %v", tc.err, err)
+		}
+	}
+}
@@ -13,6 +13,7 @@ type ProcStatBonusEffect struct {
 	AuraID     int32
 	Bonus      stats.Stats
 	Duration   time.Duration
+	MaxStacks  int32 // If above 1, each proc adds another stack of Bonus.
 	Callback   core.AuraCallback
 	ProcMask   core.ProcMask
 	Outcome    core.HitOutcome
@@ -21,44 +22,86 @@ type ProcStatBonusEffect struct {
 	PPM        float64
 	ICD        time.Duration
 
+	// Used instead of ProcMask if set, for procs of whichever weapons have the item or enchant.
+	ProcMaskFunc      func(*core.Character) core.ProcMask
+	SpellFlagsExclude core.SpellFlag
+	// The action of the trigger aura, if it isn't the item's, like for enchants.
+	TriggerActionID core.ActionID
+
 	// For ignoring a hardcoded spell.
 	IgnoreSpellID int32
 }
 
 func newProcStatBonusEffect(config ProcStatBonusEffect) {
-	core.NewItemEffect(config.ID, func(agent core.Agent) {
+	core.NewItemEffect(config.ID, makeProcStatBonusEffect(config))
+}
+
+func makeProcStatBonusEffect(config ProcStatBonusEffect) core.ApplyEffect {
+	return func(agent core.Agent) {
 		character := agent.GetCharacter()
 
 		procID := core.ActionID{SpellID: config.AuraID}
 		if procID.IsEmptyAction() {
 			procID = core.ActionID{ItemID: config.ID}
 		}
-		procAura := character.NewTemporaryStatsAura(config.Name+" Proc", procID, config.Bonus, config.Duration)
 
-		handler := func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+		var procAura *core.Aura
+		activate := func(sim *core.Simulation) {
 			procAura.Activate(sim)
 		}
+		if config.MaxStacks > 1 {
+			procAura = core.MakeStackingAura(character, core.StackingStatAura{
+				Aura: core.Aura{
+					Label:     config.Name + " Proc",
+					ActionID:  procID,
+					Duration:  config.Duration,
+					MaxStacks: config.MaxStacks,
+				},
+				BonusPerStack: config.Bonus,
+			})
+			activate = func(sim *core.Simulation) {
+				procAura.Activate(sim)
+				procAura.AddStack(sim)
+			}
+		} else {
+			procAura = character.NewTemporaryStatsAura(config.Name+" Proc", procID, config.Bonus, config.Duration)
+		}
+
+		handler := func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			activate(sim)
+		}
 		if config.IgnoreSpellID != 0 {
 			ignoreSpellID := config.IgnoreSpellID
 			handler = func(sim *core.Simulation, spell *core.Spell, _ *core.SpellResult) {
 				if !spell.IsSpellAction(ignoreSpellID) {
-					procAura.Activate(sim)
+					activate(sim)
 				}
 			}
 		}
 
+		procMask := config.ProcMask
+		if config.ProcMaskFunc != nil {
+			procMask = config.ProcMaskFunc(character)
+		}
+
+		triggerID := config.TriggerActionID
+		if triggerID.IsEmptyAction() {
+			triggerID = core.ActionID{ItemID: config.ID}
+		}
+
 		triggerAura := core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
-			ActionID:   core.ActionID{ItemID: config.ID},
-			Name:       config.Name,
-			Callback:   config.Callback,
-			ProcMask:   config.ProcMask,
-			Outcome:    config.Outcome,
-			Harmful:    config.Harmful,
-			ProcChance: config.ProcChance,
-			PPM:        config.PPM,
-			ICD:        config.ICD,
-			Handler:    handler,
+			ActionID:          triggerID,
+			Name:              config.Name,
+			Callback:          config.Callback,
+			ProcMask:          procMask,
+			SpellFlagsExclude: config.SpellFlagsExclude,
+			Outcome:           config.Outcome,
+			Harmful:           config.Harmful,
+			ProcChance:        config.ProcChance,
+			PPM:               config.PPM,
+			ICD:               config.ICD,
+			Handler:           handler,
 		})
 		procAura.Icd = triggerAura.Icd
-	})
+	}
 }
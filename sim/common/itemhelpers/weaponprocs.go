@@ -10,52 +10,63 @@ import (
 func CreateWeaponProcDamage(itemId int32, itemName string, ppm float64, spellId int32, school core.SpellSchool,
 	dmgMin float64, dmgRange float64, bonusCoef float64, defType core.DefenseType, spellFlagExclude core.SpellFlag) {
 
-	core.NewItemEffect(itemId, func(agent core.Agent) {
-		character := agent.GetCharacter()
-
-		sc := core.SpellConfig{
-			ActionID:    core.ActionID{SpellID: spellId},
-			SpellSchool: school,
-			DefenseType: defType,
-			ProcMask:    core.ProcMaskEmpty,
-			Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+	core.NewItemEffect(itemId, makeWeaponProcDamage(itemName, ppm, procDamageSpellConfig(itemName, spellId, school, dmgMin, dmgRange, bonusCoef, defType),
+		spellFlagExclude, func(character *core.Character) core.ProcMask {
+			return character.GetProcMaskForItem(itemId)
+		}))
+}
 
-			DamageMultiplier: 1,
-			ThreatMultiplier: 1,
-			BonusCoefficient: bonusCoef,
+// The spell of a proc that deals damage.
+func procDamageSpellConfig(label string, spellId int32, school core.SpellSchool, dmgMin float64, dmgRange float64, bonusCoef float64, defType core.DefenseType) core.SpellConfig {
+	sc := core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: spellId},
+		SpellSchool: school,
+		DefenseType: defType,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		BonusCoefficient: bonusCoef,
+	}
+
+	switch defType {
+	case core.DefenseTypeNone:
+		sc.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			dmg := dmgMin + core.TernaryFloat64(dmgRange > 0, sim.RandomFloat(label)*dmgRange, 0)
+			spell.CalcAndDealDamage(sim, target, dmg, spell.OutcomeAlwaysHit)
 		}
-
-		switch defType {
-		case core.DefenseTypeNone:
-			sc.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				dmg := dmgMin + core.TernaryFloat64(dmgRange > 0, sim.RandomFloat(itemName)*dmgRange, 0)
-				spell.CalcAndDealDamage(sim, target, dmg, spell.OutcomeAlwaysHit)
-			}
-		case core.DefenseTypeMagic:
-			sc.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				dmg := dmgMin + core.TernaryFloat64(dmgRange > 0, sim.RandomFloat(itemName)*dmgRange, 0)
-				spell.CalcAndDealDamage(sim, target, dmg, spell.OutcomeMagicHitAndCrit)
-			}
-		case core.DefenseTypeMelee:
-			// "Phantom Strike Procs"
-			// Can proc itself (Only for CoH proc), can't proc equip effects (in SoD at least - Tested), Weapon Enchants (confirmed - procs fiery), can proc imbues (oils),
-			// WildStrikes/Windfury (Wound/ Phantom Strike can't proc WF/WS in SoD, Tested for both, Appear to behave like equip affects in SoD)
-			sc.ProcMask = core.ProcMaskMeleeSpecial
-			sc.Flags = core.SpellFlagSuppressEquipProcs
-
-			sc.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				dmg := dmgMin + core.TernaryFloat64(dmgRange > 0, sim.RandomFloat(itemName)*dmgRange, 0)
-				spell.CalcAndDealDamage(sim, target, dmg, spell.OutcomeMeleeSpecialHitAndCrit)
-			}
-		case core.DefenseTypeRanged:
-			sc.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				dmg := dmgMin + core.TernaryFloat64(dmgRange > 0, sim.RandomFloat(itemName)*dmgRange, 0)
-				spell.CalcAndDealDamage(sim, target, dmg, spell.OutcomeRangedHitAndCrit)
-			}
+	case core.DefenseTypeMagic:
+		sc.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			dmg := dmgMin + core.TernaryFloat64(dmgRange > 0, sim.RandomFloat(label)*dmgRange, 0)
+			spell.CalcAndDealDamage(sim, target, dmg, spell.OutcomeMagicHitAndCrit)
+		}
+	case core.DefenseTypeMelee:
+		// "Phantom Strike Procs"
+		// Can proc itself (Only for CoH proc), can't proc equip effects (in SoD at least - Tested), Weapon Enchants (confirmed - procs fiery), can proc imbues (oils),
+		// WildStrikes/Windfury (Wound/ Phantom Strike can't proc WF/WS in SoD, Tested for both, Appear to behave like equip affects in SoD)
+		sc.ProcMask = core.ProcMaskMeleeSpecial
+		sc.Flags = core.SpellFlagSuppressEquipProcs
+
+		sc.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			dmg := dmgMin + core.TernaryFloat64(dmgRange > 0, sim.RandomFloat(label)*dmgRange, 0)
+			spell.CalcAndDealDamage(sim, target, dmg, spell.OutcomeMeleeSpecialHitAndCrit)
 		}
+	case core.DefenseTypeRanged:
+		sc.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			dmg := dmgMin + core.TernaryFloat64(dmgRange > 0, sim.RandomFloat(label)*dmgRange, 0)
+			spell.CalcAndDealDamage(sim, target, dmg, spell.OutcomeRangedHitAndCrit)
+		}
+	}
+	return sc
+}
+
+func makeWeaponProcDamage(itemName string, ppm float64, sc core.SpellConfig, spellFlagExclude core.SpellFlag, procMaskFunc func(*core.Character) core.ProcMask) core.ApplyEffect {
+	return func(agent core.Agent) {
+		character := agent.GetCharacter()
 
 		procSpell := character.RegisterSpell(sc)
-		procMask := character.GetProcMaskForItem(itemId)
+		procMask := procMaskFunc(character)
 		ppmm := character.AutoAttacks.NewPPMManager(ppm, procMask)
 
 		character.GetOrRegisterAura(core.Aura{
@@ -73,7 +84,7 @@ func CreateWeaponProcDamage(itemId int32, itemName string, ppm float64, spellId
 				}
 			},
 		})
-	})
+	}
 }
 
 // Creates a weapon proc for "Chance on Hit" weapon effects
@@ -394,18 +394,11 @@ func init() {
 		})
 	})
 
-	// https://www.wowhead.com/classic/item=228603/blackhand-doomsaw
-	// Chance on hit: Wounds the target for 324 to 540 damage.
-	// TODO: Proc rate based on the original item
-	itemhelpers.CreateWeaponCoHProcDamage(BlackhandDoomsaw, "Blackhand Doomsaw", 0.4, 16549, core.SpellSchoolPhysical, 324, 216, 0, core.DefenseTypeMelee)
-
 	// https://www.wowhead.com/classic/item=220569/blistering-ragehammer
 	// Chance on hit: Increases damage done by 20 and attack speed by 5% for 15 sec.
 	// TODO: Proc rate assumed and needs testing
 	itemhelpers.CreateWeaponProcAura(BlisteringRagehammer, "Blistering Ragehammer", 1.0, enrageAura446327)
 
-	itemhelpers.CreateWeaponCoHProcDamage(BloodletterScalpel, "Bloodletter Scalpel", 1.0, 18081, core.SpellSchoolPhysical, 60, 10, 0, core.DefenseTypeMelee)
-
 	itemhelpers.CreateWeaponProcSpell(Bloodrazor, "Bloodrazor", 1.0, func(character *core.Character) *core.Spell {
 		return character.GetOrRegisterSpell(core.SpellConfig{
 			ActionID:         core.ActionID{SpellID: 17504},
@@ -457,30 +450,11 @@ func init() {
 		})
 	})
 
-	// https://www.wowhead.com/classic/item=228586/chillpike
-	// Chance on hit: Blasts a target for 160 to 250 Frost damage.
-	// TODO: Proc rate assumed and needs testing
-	itemhelpers.CreateWeaponCoHProcDamage(Chillpike, "Chillpike", 1.0, 19260, core.SpellSchoolFrost, 160, 90, 0, core.DefenseTypeMagic)
-
-	// https://www.wowhead.com/classic/item=17068/deathbringer
-	// Chance on hit: Sends a shadowy bolt at the enemy causing 110 to 140 Shadow damage.
-	itemhelpers.CreateWeaponCoHProcDamage(Deathbringer, "Deathbringer", 1.0, 18138, core.SpellSchoolShadow, 110, 30, 0, core.DefenseTypeMagic)
-
 	// https://www.wowhead.com/classic/item=228410/dreadblade-of-the-destructor
 	// https://www.wowhead.com/classic/item=228498/dreadblade-of-the-destructor
 	// TODO: Proc rate assumed and needs testing
 	itemhelpers.CreateWeaponProcSpell(DreadbladeOfTheDestructor, "Dreadblade of the Destructor", 1.0, dreadbladeOfTheDestructorEffect)
 
-	// https://www.wowhead.com/classic/item=227842/ebon-fist
-	// Chance on hit: Sends a shadowy bolt at the enemy causing 125 to 275 Shadow damage.
-	// TODO: Proc rate assumed and needs testing
-	itemhelpers.CreateWeaponCoHProcDamage(EbonFist, "Ebon Fist", 1.0, 18211, core.SpellSchoolShadow, 125, 150, 0, core.DefenseTypeMagic)
-
-	// https://www.wowhead.com/classic/item=19170/ebon-hand
-	// Chance on hit: Sends a shadowy bolt at the enemy causing 125 to 275 Shadow damage.
-	// TODO: Proc rate assumed and needs testing
-	itemhelpers.CreateWeaponCoHProcDamage(EbonHand, "Ebon Hand", 1.0, 18211, core.SpellSchoolShadow, 125, 150, 0, core.DefenseTypeMagic)
-
 	// https://www.wowhead.com/classic/item=227993/ebon-hilt-of-marduk
 	// Chance on hit: Corrupts the target, causing 210 damage over 3 sec.
 	// TODO: Proc rate assumed and needs testing
@@ -916,8 +890,6 @@ func init() {
 		})
 	})
 
-	itemhelpers.CreateWeaponCoHProcDamage(GryphonRidersStormhammer, "Gryphon Rider's Stormhammer", 1.0, 18081, core.SpellSchoolNature, 91, 34, 0, core.DefenseTypeMagic)
-
 	// https://www.wowhead.com/classic/item=228267/gutgore-ripper
 	// Chance on hit: Sends a shadowy bolt at the enemy causing 150 Shadow damage and lowering all stats by 25 for 30 sec.
 	itemhelpers.CreateWeaponProcSpell(GutgoreRipper, "Gutgore Ripper", 1.0, gutgoreRipperEffect)
@@ -954,10 +926,6 @@ func init() {
 		})
 	})
 
-	itemhelpers.CreateWeaponCoHProcDamage(GutRipper, "Gut Ripper", 1.0, 18107, core.SpellSchoolPhysical, 95, 26, 0, core.DefenseTypeMelee)
-
-	itemhelpers.CreateWeaponCoHProcDamage(HammerOfTheNorthernWind, "Hammer of the Northern Wind", 3.5, 13439, core.SpellSchoolFrost, 20, 10, 0, core.DefenseTypeMagic)
-
 	// https://www.wowhead.com/classic/item=2243/hand-of-edward-the-odd
 	// Chance on hit: Next spell cast within 4 sec will cast instantly.
 	itemhelpers.CreateWeaponProcAura(HandOfEdwardTheOdd, "Hand of Edward the Odd", 1.0, func(character *core.Character) *core.Aura {
@@ -977,8 +945,6 @@ func init() {
 		})
 	})
 
-	itemhelpers.CreateWeaponCoHProcDamage(HanzoSword, "Hanzo Sword", 1.0, 16405, core.SpellSchoolPhysical, 75, 0, 0, core.DefenseTypeMelee)
-
 	// https://www.wowhead.com/classic/item=228022/headmasters-charge#comments
 	// Use: Gives 20 additional intellect to party members within 30 yards. (10 Min Cooldown)
 	// Originally did not stack with Arcane Intellect, but is reported to stack in SoD
@@ -1133,10 +1099,6 @@ func init() {
 		})
 	})
 
-	itemhelpers.CreateWeaponCoHProcDamage(JoonhosMercy, "Joonho's Mercy", 1.0, 20883, core.SpellSchoolArcane, 70, 0, 0, core.DefenseTypeMagic)
-
-	itemhelpers.CreateWeaponCoHProcDamage(LinkensSwordOfMastery, "Linken's Sword of Mastery", 1.0, 18089, core.SpellSchoolNature, 45, 30, 0, core.DefenseTypeMagic)
-
 	// https://www.wowhead.com/classic/item=227940/lord-generals-sword
 	// Chance on hit: Increases attack power by 50 for 30 sec.
 	// // TODO: Proc rate assumed and needs testing
@@ -1181,16 +1143,12 @@ func init() {
 		})
 	})
 
-	itemhelpers.CreateWeaponCoHProcDamage(Nightblade, "Nightblade", 1.0, 18211, core.SpellSchoolShadow, 125, 150, 0, core.DefenseTypeMagic)
-
 	// https://www.wowhead.com/classic/item=19169/nightfall
 	// Removed from SoD
 	// core.NewItemEffect(Nightfall, func(agent core.Agent) {
 	// 	makeNightfallProc(agent.GetCharacter(), "Nightfall")
 	// })
 
-	itemhelpers.CreateWeaponCoHProcDamage(PendulumOfDoom, "Pendulum of Doom", 0.5, 10373, core.SpellSchoolPhysical, 250, 100, 0, core.DefenseTypeMelee)
-
 	core.NewItemEffect(PipsSkinner, func(agent core.Agent) {
 		character := agent.GetCharacter()
 
@@ -1199,11 +1157,6 @@ func init() {
 		}
 	})
 
-	// https://www.wowhead.com/classic/item=228296/perditions-blade
-	// Chance on hit: Blasts a target for 98 to 122 Fire damage.
-	itemhelpers.CreateWeaponCoHProcDamage(PerditionsBlade, "Perdition's Blade", 2.8, 461695, core.SpellSchoolFire, 98, 24, 0, core.DefenseTypeMagic)
-	itemhelpers.CreateWeaponCoHProcDamage(PerditionsBladeMolten, "Perdition's Blade", 2.8, 461695, core.SpellSchoolFire, 98, 24, 0, core.DefenseTypeMagic)
-
 	// https://www.wowhead.com/classic/item=228679/quelserrar
 	// Chance on hit: When active, grants the wielder 25 defense and 300 armor for 10 sec.
 	// Proc rate estimated based on data from WoW Armaments Discord for the original item
@@ -1350,11 +1303,6 @@ func init() {
 	// 	makeNightfallProc(agent.GetCharacter(), "Reaving Nightfall")
 	// })
 
-	itemhelpers.CreateWeaponCoHProcDamage(SatyrsLash, "Satyr's Lash", 1.0, 18205, core.SpellSchoolShadow, 55, 30, 0, core.DefenseTypeMagic)
-
-	// TODO Searing Needle adds an "Apply Aura: Mod Damage Done (Fire): 10" aura to the /target/, buffing it; not currently modelled
-	itemhelpers.CreateWeaponCoHProcDamage(SearingNeedle, "Searing Needle", 1.0, 16454, core.SpellSchoolFire, 60, 0, 0, core.DefenseTypeMagic)
-
 	// https://www.wowhead.com/classic/item=228666/seeping-willow
 	// Chance on hit: Lowers all stats by 20 and deals 20 Nature damage every 3 sec to all enemies within an 8 yard radius of the caster for 30 sec.
 	// TODO: Proc rate assumed and needs testing
@@ -1444,8 +1392,6 @@ func init() {
 		})
 	})
 
-	itemhelpers.CreateWeaponCoHProcDamage(Shadowblade, "Shadowblade", 1.0, 18138, core.SpellSchoolShadow, 110, 30, 0, core.DefenseTypeMagic)
-
 	// https://www.wowhead.com/classic/item=228272/shadowstrike
 	// Chance on hit: Steals 180 to 220 life from target enemy.
 	// Estimated based on data from WoW Armaments Discord
@@ -1467,8 +1413,6 @@ func init() {
 		})
 	})
 
-	itemhelpers.CreateWeaponCoHProcDamage(ShortswordOfVengeance, "Shortsword of Vengeance", 1.0, 13519, core.SpellSchoolHoly, 30, 0, 0, core.DefenseTypeMagic)
-
 	// https://www.wowhead.com/classic/item=228542/skullforge-reaver
 	// Equip: Drains target for 2 Shadow damage every 1 sec and transfers it to the caster. Lasts for 30 sec.
 	// Estimated based on data from WoW Armaments Discord
@@ -1877,8 +1821,6 @@ func init() {
 		})
 	})
 
-	itemhelpers.CreateWeaponCoHProcDamage(TheNeedler, "The Needler", 3.0, 13060, core.SpellSchoolPhysical, 75, 0, 0, core.DefenseTypeMelee)
-
 	// https://www.wowhead.com/classic/item=230242/the-untamed-blade
 	// Chance on hit: Increases Strength by 300 for 8 sec.
 	// Estimated based on data from WoW Armaments Discord
@@ -2085,10 +2027,6 @@ func init() {
 		})
 	})
 
-	itemhelpers.CreateWeaponCoHProcDamage(VilerendSlicer, "Vilerend Slicer", 1.0, 16405, core.SpellSchoolPhysical, 75, 0, 0, core.DefenseTypeMelee)
-
-	itemhelpers.CreateWeaponCoHProcDamage(ViskagTheBloodletter, "Vis'kag the Bloodletter", 0.6, 21140, core.SpellSchoolPhysical, 240, 0, 0, core.DefenseTypeMelee)
-
 	// https://www.wowhead.com/classic/item=227941/wraith-scythe
 	// Chance on hit: Steals 45 life from target enemy.
 	itemhelpers.CreateWeaponProcSpell(WraithScythe, "Wraith Scythe", 1.0, func(character *core.Character) *core.Spell {
@@ -2162,10 +2100,6 @@ func init() {
 		})
 	})
 
-	// https://www.wowhead.com/classic/item=228678/draconic-infused-emblem
-	// Use: Increases your spell damage by up to 100 and your healing by up to 190 for 15 sec. (1 Min, 30 Sec Cooldown)
-	core.NewSimpleStatOffensiveTrinketEffect(DraconicInfusedEmblem, stats.Stats{stats.SpellDamage: 128, stats.HealingPower: 236}, time.Second*15, time.Second*90)
-
 	// https://www.wowhead.com/classic/item=19288/darkmoon-card-blue-dragon
 	// Equip: 2% chance on successful spellcast to allow 100% of your Mana regeneration to continue while casting for 15 sec. (Proc chance: 2%)
 	core.NewItemEffect(DarkmoonCardBlueDragon, func(agent core.Agent) {
@@ -2438,12 +2372,6 @@ func init() {
 		}))
 	})
 
-	// https://www.wowhead.com/classic/item=231271/nat-pagles-broken-reel
-	core.NewSimpleStatOffensiveTrinketEffect(NatPaglesBrokenReel, stats.Stats{
-		stats.SpellHit: 10 * core.SpellHitRatingPerHitChance,
-		stats.MeleeHit: 10 * core.MeleeHitRatingPerHitChance,
-	}, time.Second*15, time.Second*90)
-
 	// https://www.wowhead.com/classic/item=19812/rune-of-the-dawn
 	// Equip: Increases damage done to Undead by magical spells and effects by up to 48.
 	core.NewItemEffect(RuneOfTheDawn, func(agent core.Agent) {
@@ -2592,10 +2520,6 @@ func init() {
 		}
 	})
 
-	// https://www.wowhead.com/classic/item=228255/talisman-of-ephemeral-power
-	// Use: Increases damage and healing done by magical spells and effects by up to 184 for 15 sec. (1 Min, 30 Sec Cooldown)
-	core.NewSimpleStatOffensiveTrinketEffect(TalismanOfEphemeralPower, stats.Stats{stats.SpellPower: 184}, time.Second*15, time.Second*90)
-
 	// https://www.wowhead.com/classic/item=19948/zandalarian-hero-badge
 	// Increases your armor by 2000 and defense skill by 30 for 20 sec.
 	// Every time you take melee or ranged damage, this bonus is reduced by 200 armor and 3 defense.
@@ -0,0 +1,14 @@
+package vanilla
+
+import (
+	_ "embed"
+
+	"github.com/wowsims/sod/sim/common/itemhelpers"
+)
+
+//go:embed item_procs.hujson
+var itemProcsFile []byte
+
+func init() {
+	itemhelpers.RegisterProcEffectFile("vanilla/item_procs.hujson", itemProcsFile)
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"cmp"
 	"reflect"
 	"strconv"
 	"strings"
@@ -78,6 +79,19 @@ func (actionID ActionID) SameAction(other ActionID) bool {
 	return actionID.SameActionIgnoreTag(other) && actionID.Tag == other.Tag
 }
 
+func compareActionIDs(a, b ActionID) int {
+	if a.SpellID != b.SpellID {
+		return cmp.Compare(a.SpellID, b.SpellID)
+	}
+	if a.ItemID != b.ItemID {
+		return cmp.Compare(a.ItemID, b.ItemID)
+	}
+	if a.OtherID != b.OtherID {
+		return cmp.Compare(a.OtherID, b.OtherID)
+	}
+	return cmp.Compare(a.Tag, b.Tag)
+}
+
 func (actionID ActionID) String() string {
 	var sb strings.Builder
 	sb.WriteString("{")
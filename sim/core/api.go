@@ -12,6 +12,14 @@ import (
  * Returns character stats taking into account gear / buffs / consumes / etc
  */
 func ComputeStats(csr *proto.ComputeStatsRequest) *proto.ComputeStatsResult {
+	validation := validateComputeStatsRequest(csr)
+	if len(validation.Errors) > 0 {
+		return &proto.ComputeStatsResult{
+			Validation:  validation,
+			ErrorResult: validationErrorString(validation),
+		}
+	}
+
 	encounter := csr.Encounter
 	if encounter == nil {
 		encounter = &proto.Encounter{}
@@ -19,10 +27,22 @@ func ComputeStats(csr *proto.ComputeStatsRequest) *proto.ComputeStatsResult {
 
 	_, raidStats, encounterStats := NewEnvironment(csr.Raid, encounter, true)
 
-	return &proto.ComputeStatsResult{
+	result := &proto.ComputeStatsResult{
 		RaidStats:      raidStats,
 		EncounterStats: encounterStats,
 	}
+	if len(validation.Warnings) > 0 {
+		result.Validation = validation
+	}
+	return result
+}
+
+/**
+ * Returns the problems in a request which would keep it from being simmed, and
+ * those which the sim works around.
+ */
+func ValidateRaidSimRequest(request *proto.RaidSimRequest) *proto.ValidationResult {
+	return validateRaidSimRequest(request)
 }
 
 /**
@@ -42,6 +62,29 @@ func StatWeightsAsync(request *proto.StatWeightsRequest, progress chan *proto.Pr
 	}()
 }
 
+/**
+ * Returns the raid DPS lost when each buff is removed, highest first.
+ */
+func BuffAttribution(request *proto.BuffAttributionRequest) *proto.BuffAttributionResult {
+	return CalcBuffAttribution(request, nil)
+}
+
+func BuffAttributionAsync(request *proto.BuffAttributionRequest, progress chan *proto.ProgressMetrics) {
+	go func() {
+		result := CalcBuffAttribution(request, progress)
+		progress <- &proto.ProgressMetrics{
+			FinalBuffAttributionResult: result,
+		}
+	}()
+}
+
+/**
+ * Runs two sims with paired iterations, and returns the significance of the difference between them.
+ */
+func CompareSims(request *proto.CompareSimsRequest) *proto.CompareSimsResult {
+	return CalcCompareSims(request)
+}
+
 /**
  * Runs multiple iterations of the sim with a full raid.
  */
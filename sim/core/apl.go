@@ -29,6 +29,10 @@ type APLRotation struct {
 	// Used to avoid recursive APL loops.
 	inLoop bool
 
+	// Whether the GCD was triggered by the rotation, and the player skill GCD delay
+	// should be applied once it is ready again.
+	pendingGCDDelay bool
+
 	// Validation warnings that occur during proto parsing.
 	// We return these back to the user for display in the UI.
 	curWarnings          []string
@@ -126,10 +130,20 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 		})
 	}
 	for i, action := range rotation.priorityList {
-		rotation.doAndRecordWarnings(&rotation.priorityListWarnings[i], false, func() {
+		rotation.doAndRecordWarnings(&rotation.priorityListWarnings[configIdxs[i]], false, func() {
 			action.Finalize(rotation)
 		})
 	}
+	rotation.lintPriorityList(config, configIdxs)
+
+	// Inner actions share the metrics of their priority list entry, so that actions
+	// executed by sequences and controlling actions are attributed to the entry.
+	for i, action := range rotation.priorityList {
+		metrics := unit.Metrics.NewAPLActionMetrics(int32(configIdxs[i]))
+		for _, subaction := range action.GetAllActions() {
+			subaction.metrics = metrics
+		}
+	}
 
 	// Remove MCDs that are referenced by APL actions, so that the Autocast Other Cooldowns
 	// action does not include them.
@@ -165,6 +179,7 @@ func (rot *APLRotation) allPrepullActions() []*APLAction {
 func (rot *APLRotation) reset(sim *Simulation) {
 	rot.controllingActions = nil
 	rot.inLoop = false
+	rot.pendingGCDDelay = false
 	rot.interruptChannelIf = nil
 	rot.allowChannelRecastOnInterrupt = false
 	for _, action := range rot.allAPLActions() {
@@ -188,6 +203,14 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 		return
 	}
 
+	if apl.pendingGCDDelay && apl.unit.GCD.IsReady(sim) {
+		apl.pendingGCDDelay = false
+		if delay := apl.unit.Skill.sampleGCDDelay(sim, apl.unit); delay > 0 {
+			apl.unit.WaitUntil(sim, sim.CurrentTime+delay)
+			return
+		}
+	}
+
 	i := 0
 	apl.inLoop = true
 
@@ -196,7 +219,16 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 			panic(fmt.Sprintf("[USER_ERROR] Infinite loop detected, current action:\n%s", nextAction))
 		}
 
+		numControllingActions := len(apl.controllingActions)
 		nextAction.Execute(sim)
+		if nextAction.metrics != nil {
+			// Handing control to an action with sub-actions (e.g. a strict sequence) does
+			// not count as an execution by itself, the sub-actions it executes are counted
+			// instead. A wait has none, so it is counted when it starts.
+			countExecution := len(apl.controllingActions) <= numControllingActions ||
+				len(apl.controllingActions[len(apl.controllingActions)-1].GetInnerActions()) == 0
+			nextAction.metrics.onExecute(sim, countExecution)
+		}
 	}
 	apl.inLoop = false
 
@@ -207,6 +239,8 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 	gcdReady := apl.unit.GCD.IsReady(sim)
 	if gcdReady {
 		apl.unit.WaitUntil(sim, sim.CurrentTime+time.Millisecond*50)
+	} else if i > 0 && apl.unit.Skill.hasGCDDelay() {
+		apl.pendingGCDDelay = true
 	}
 }
 
@@ -215,8 +249,13 @@ func (apl *APLRotation) getNextAction(sim *Simulation) *APLAction {
 		return apl.controllingActions[len(apl.controllingActions)-1].GetNextAction(sim)
 	}
 
+	skippedTopAction := false
 	for _, action := range apl.priorityList {
-		if action.IsReady(sim) {
+		if action.isReadyWithMetrics(sim) {
+			if !skippedTopAction && apl.unit.Skill.skipsTopAction(sim, apl.unit) {
+				skippedTopAction = true
+				continue
+			}
 			return action
 		}
 	}
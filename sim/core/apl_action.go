@@ -9,6 +9,9 @@ import (
 type APLAction struct {
 	condition APLValue
 	impl      APLActionImpl
+
+	// Runtime counters of the priority list entry containing this action.
+	metrics *APLActionMetrics
 }
 
 func (action *APLAction) Finalize(rot *APLRotation) {
@@ -22,6 +25,17 @@ func (action *APLAction) IsReady(sim *Simulation) bool {
 	return (action.condition == nil || action.condition.GetBool(sim)) && action.impl.IsReady(sim)
 }
 
+// Same as IsReady, but also records evaluation metrics. Only used for top-level priority list entries.
+func (action *APLAction) isReadyWithMetrics(sim *Simulation) bool {
+	action.metrics.Evaluations++
+	if action.condition != nil && !action.condition.GetBool(sim) {
+		action.metrics.onConditionFalse()
+		return false
+	}
+	action.metrics.onConditionTrue(sim)
+	return action.impl.IsReady(sim)
+}
+
 func (action *APLAction) Execute(sim *Simulation) {
 	action.impl.Execute(sim)
 }
@@ -256,9 +256,27 @@ type APLActionMove struct {
 	defaultAPLActionImpl
 	unit      *Unit
 	moveRange APLValue
+	positionX APLValue
+	positionY APLValue
 }
 
 func (rot *APLRotation) newActionMove(config *proto.APLActionMove) APLActionImpl {
+	if config.PositionX != nil || config.PositionY != nil {
+		if config.PositionX == nil || config.PositionY == nil {
+			rot.ValidationWarning("Move requires both an x and y position")
+			return nil
+		}
+		if !rot.unit.Env.UsePositions {
+			rot.ValidationWarning("Moving to a position requires positions to be set on the raid or encounter")
+			return nil
+		}
+		return &APLActionMove{
+			unit:      rot.unit,
+			positionX: rot.newAPLValue(config.PositionX),
+			positionY: rot.newAPLValue(config.PositionY),
+		}
+	}
+
 	return &APLActionMove{
 		unit:      rot.unit,
 		moveRange: rot.newAPLValue(config.RangeFromTarget),
@@ -266,9 +284,22 @@ func (rot *APLRotation) newActionMove(config *proto.APLActionMove) APLActionImpl
 }
 func (action *APLActionMove) IsReady(sim *Simulation) bool {
 	isPrepull := sim.CurrentTime < 0
+	if action.positionX != nil {
+		return !action.unit.Moving && action.position(sim) != action.unit.Position && !action.unit.IsCasting(sim)
+	}
 	return !action.unit.Moving && (action.moveRange.GetFloat(sim) != action.unit.DistanceFromTarget || isPrepull) && !action.unit.IsCasting(sim)
 }
 func (action *APLActionMove) Execute(sim *Simulation) {
+	if action.positionX != nil {
+		position := action.position(sim)
+		if sim.Log != nil {
+			action.unit.Log(sim, "Moving to (%.1f, %.1f)", position.X, position.Y)
+		}
+
+		action.unit.MoveToPosition(position, sim)
+		return
+	}
+
 	moveRange := action.moveRange.GetFloat(sim)
 	if sim.Log != nil {
 		action.unit.Log(sim, "Moving to %s", moveRange)
@@ -276,7 +307,13 @@ func (action *APLActionMove) Execute(sim *Simulation) {
 
 	action.unit.MoveTo(moveRange, sim)
 }
+func (action *APLActionMove) position(sim *Simulation) Vector2 {
+	return Vector2{X: action.positionX.GetFloat(sim), Y: action.positionY.GetFloat(sim)}
+}
 func (action *APLActionMove) String() string {
+	if action.positionX != nil {
+		return fmt.Sprintf("Move(%s, %s)", action.positionX, action.positionY)
+	}
 	return fmt.Sprintf("Move(%s)", action.moveRange)
 }
 
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Static checks which run after the rotation has been finalized. These only use
+// information that is fixed for the whole sim (talents, runes, known spells and
+// constants), so they are reported alongside the parse warnings in ComputeStats.
+func (rot *APLRotation) lintPriorityList(config *proto.APLRotation, configIdxs []int) {
+	var blockingAction *APLAction
+	for i, action := range rot.priorityList {
+		configIdx := configIdxs[i]
+		rot.doAndRecordWarnings(&rot.priorityListWarnings[configIdx], false, func() {
+			if config.PriorityList[configIdx].Action.Condition.GetValue() != nil && action.condition == nil {
+				rot.ValidationWarning("Condition could not be parsed, so this action will be treated as unconditional")
+			}
+
+			if blockingAction != nil && isGCDBoundAction(action) {
+				rot.ValidationWarning("Unreachable: a previous action (%s) is always castable and triggers the GCD", blockingAction.impl)
+			}
+
+			for _, subaction := range action.GetAllActions() {
+				rot.lintAction(subaction)
+			}
+		})
+
+		if blockingAction == nil && isAlwaysCastable(action) {
+			blockingAction = action
+		}
+	}
+}
+
+func (rot *APLRotation) lintAction(action *APLAction) {
+	if action.condition != nil {
+		if result, known := aplStaticBool(action.condition); known {
+			// Guards on the loadout are always true for the loadout the APL was
+			// written for, which is their purpose, so only warn when they fail.
+			if result {
+				if !isLoadoutGuard(action.condition) {
+					rot.ValidationWarning("Condition is always true for %s", action.impl)
+				}
+			} else {
+				rot.ValidationWarning("Condition is always false, so %s will never be used", action.impl)
+			}
+		}
+	}
+
+	var label string
+	var subactions []*APLAction
+	switch impl := action.impl.(type) {
+	case *APLActionSequence:
+		label, subactions = fmt.Sprintf("Sequence '%s'", impl.name), impl.subactions
+	case *APLActionStrictSequence:
+		label, subactions = "Strict Sequence", impl.subactions
+	default:
+		return
+	}
+
+	for i, subaction := range subactions {
+		if result, known := aplStaticBool(subaction.condition); known && !result {
+			rot.ValidationWarning("%s can never complete, because sub-action %d (%s) will never be used", label, i+1, subaction.impl)
+		}
+	}
+}
+
+// Returns whether the action is a spell cast which is restricted by the GCD.
+func isGCDBoundAction(action *APLAction) bool {
+	castAction, ok := action.impl.(*APLActionCastSpell)
+	return ok && castAction.spell.DefaultCast.GCD > 0
+}
+
+// Returns whether the action can always be cast whenever the GCD is ready, i.e.
+// it is unconditional and its spell has no cooldown, cost or extra cast condition.
+func isAlwaysCastable(action *APLAction) bool {
+	if action.condition != nil {
+		if result, known := aplStaticBool(action.condition); !known || !result {
+			return false
+		}
+	}
+
+	castAction, ok := action.impl.(*APLActionCastSpell)
+	if !ok {
+		return false
+	}
+
+	spell := castAction.spell
+	return spell.DefaultCast.GCD > 0 &&
+		spell.DefaultCast.CastTime == 0 &&
+		spell.Cost == nil &&
+		spell.ExtraCastCondition == nil &&
+		spell.CD.Timer == nil &&
+		spell.SharedCD.Timer == nil
+}
+
+// Attempts to evaluate a bool APLValue without a running sim. The second return
+// value is false if the result depends on the state of the sim.
+func aplStaticBool(value APLValue) (bool, bool) {
+	switch v := value.(type) {
+	case nil:
+		return false, false
+	case *APLValueAnd:
+		allKnown := true
+		for _, val := range v.vals {
+			result, known := aplStaticBool(val)
+			if known && !result {
+				return false, true
+			}
+			allKnown = allKnown && known
+		}
+		return true, allKnown
+	case *APLValueOr:
+		allKnown := true
+		for _, val := range v.vals {
+			result, known := aplStaticBool(val)
+			if known && result {
+				return true, true
+			}
+			allKnown = allKnown && known
+		}
+		return false, allKnown
+	case *APLValueNot:
+		result, known := aplStaticBool(v.val)
+		return !result, known
+	}
+
+	if isStaticAPLValue(value) {
+		return value.GetBool(nil), true
+	}
+	return false, false
+}
+
+// Returns whether the value checks the player's loadout, i.e. whether a rune is
+// equipped or a spell or aura is known. Stock APLs use these to share one list
+// between several builds.
+func isLoadoutGuard(value APLValue) bool {
+	switch value.(type) {
+	case *APLValueRuneIsEquipped, *APLValueSpellIsKnown, *APLValueAuraIsKnown:
+		return true
+	}
+	for _, inner := range value.GetInnerValues() {
+		if isLoadoutGuard(inner) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns whether the value is fixed for the entire sim.
+func isStaticAPLValue(value APLValue) bool {
+	switch v := value.(type) {
+	case *APLValueConst, *APLValueRuneIsEquipped, *APLValueSpellIsKnown:
+		return true
+	case *APLValueAuraIsKnown:
+		return v.aura.curTargetSource == nil
+	case *APLValueCoerced, *APLValueCompare, *APLValueAnd, *APLValueOr, *APLValueNot:
+		for _, inner := range value.GetInnerValues() {
+			if !isStaticAPLValue(inner) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
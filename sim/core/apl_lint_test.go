@@ -0,0 +1,113 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestStaticBool(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{}
+	rot := &APLRotation{
+		unit: unit,
+	}
+
+	trueVal := rot.coerceTo(rot.newValueConst(&proto.APLValueConst{Val: "true"}), proto.APLValueType_ValueTypeBool)
+	falseVal := rot.coerceTo(rot.newValueConst(&proto.APLValueConst{Val: "false"}), proto.APLValueType_ValueTypeBool)
+	runeEquipped := &APLValueRuneIsEquipped{rune: APLRune{id: 1, equipped: true}}
+	unknownSpell := &APLValueSpellIsKnown{}
+	dynamic := &APLValueCurrentTime{}
+	dynamicBool := &APLValueCompare{op: proto.APLValueCompare_OpGt, lhs: dynamic, rhs: rot.newValueConst(&proto.APLValueConst{Val: "1s"})}
+
+	testCases := []struct {
+		name   string
+		value  APLValue
+		result bool
+		known  bool
+	}{
+		{"Const", trueVal, true, true},
+		{"Rune", runeEquipped, true, true},
+		{"UnknownSpell", unknownSpell, false, true},
+		{"Not", &APLValueNot{val: unknownSpell}, true, true},
+		{"AndShortCircuit", &APLValueAnd{vals: []APLValue{dynamicBool, falseVal}}, false, true},
+		{"AndDynamic", &APLValueAnd{vals: []APLValue{dynamicBool, trueVal}}, false, false},
+		{"OrShortCircuit", &APLValueOr{vals: []APLValue{dynamicBool, runeEquipped}}, true, true},
+		{"OrDynamic", &APLValueOr{vals: []APLValue{dynamicBool, falseVal}}, false, false},
+		{"Compare", dynamicBool, false, false},
+	}
+
+	for _, tc := range testCases {
+		result, known := aplStaticBool(tc.value)
+		if known != tc.known {
+			t.Fatalf("%s: expected known=%t, got %t", tc.name, tc.known, known)
+		}
+		if known && result != tc.result {
+			t.Fatalf("%s: expected %t, got %t", tc.name, tc.result, result)
+		}
+		if known && tc.value.GetBool(sim) != result {
+			t.Fatalf("%s: static result does not match runtime value", tc.name)
+		}
+	}
+}
+
+func TestLintPriorityList(t *testing.T) {
+	castSpell := func(spellID int32, condition string) *proto.APLListItem {
+		action := &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: ActionID{SpellID: spellID}.ToProto()}}}
+		if condition != "" {
+			action.Condition = &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: condition}}}
+		}
+		return &proto.APLListItem{Action: action}
+	}
+	neverCompletes := &proto.APLListItem{Action: &proto.APLAction{Action: &proto.APLAction_Sequence{Sequence: &proto.APLActionSequence{
+		Name:    "Opener",
+		Actions: []*proto.APLAction{castSpell(42, "").Action, castSpell(43, "false").Action},
+	}}}}
+
+	rsr := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 1, RandomSeed: 100})
+	rsr.Raid.Parties[0].Players[0].Rotation = &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PriorityList: []*proto.APLListItem{
+			castSpell(42, "false"),
+			neverCompletes,
+			castSpell(43, ""),
+			castSpell(42, ""),
+		},
+	}
+
+	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
+	warnings := env.Raid.Parties[0].Players[0].GetCharacter().Rotation.priorityListWarnings
+	for i, expected := range [][]string{
+		{"Condition is always false, so Cast Spell({SpellID: 42}) will never be used"},
+		{"Condition is always false, so Cast Spell({SpellID: 43}) will never be used", "Sequence 'Opener' can never complete, because sub-action 2"},
+		{},
+		{"Unreachable: a previous action (Cast Spell({SpellID: 43})) is always castable and triggers the GCD"},
+	} {
+		if len(warnings[i]) != len(expected) {
+			t.Fatalf("Expected %d warnings for entry %d, got %q", len(expected), i, warnings[i])
+		}
+		for j, warning := range expected {
+			if !strings.Contains(warnings[i][j], warning) {
+				t.Fatalf("Expected warning %q for entry %d, got %q", warning, i, warnings[i][j])
+			}
+		}
+	}
+
+	result := RunRaidSim(rsr)
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+	rotation := result.RaidMetrics.Parties[0].Players[0].Rotation
+	if len(rotation) != 4 {
+		t.Fatalf("Expected rotation metrics for 4 entries, got %d", len(rotation))
+	}
+	for i, entry := range rotation {
+		if entry.ListIndex != int32(i) {
+			t.Fatalf("Expected entry %d to have list index %d, got %d", i, i, entry.ListIndex)
+		}
+	}
+	if rotation[2].Executions == 0 || rotation[3].Executions != 0 {
+		t.Fatalf("Expected only the first always castable action to be used, got %v", rotation)
+	}
+}
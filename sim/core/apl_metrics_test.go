@@ -0,0 +1,68 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestAPLActionMetrics(t *testing.T) {
+	castSpell := func(spellID int32) *proto.APLAction {
+		return &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: ActionID{SpellID: spellID}.ToProto()}}}
+	}
+	dotIsActive := &proto.APLValue{Value: &proto.APLValue_AuraIsActive{AuraIsActive: &proto.APLValueAuraIsActive{
+		SourceUnit: &proto.UnitReference{Type: proto.UnitReference_CurrentTarget},
+		AuraId:     ActionID{SpellID: 42}.ToProto(),
+	}}}
+
+	dot := castSpell(42)
+	dot.Condition = &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: dotIsActive}}}
+
+	rsr := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 10, RandomSeed: 100})
+	rsr.Encounter.DurationVariation = 0
+	rsr.Raid.Parties[0].Players[0].Rotation = &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PriorityList: []*proto.APLListItem{
+			// Opens with a bolt, then waits for 0.5s, while the bolt's GCD lasts 1.5s.
+			{Action: &proto.APLAction{Action: &proto.APLAction_Sequence{Sequence: &proto.APLActionSequence{
+				Name: "Opener",
+				Actions: []*proto.APLAction{
+					castSpell(43),
+					{Action: &proto.APLAction_Wait{Wait: &proto.APLActionWait{Duration: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: "0.5s"}}}}}},
+				},
+			}}}},
+			{Action: dot},
+			{Action: castSpell(43)},
+		},
+	}
+
+	result := RunRaidSim(rsr)
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+	rotation := result.RaidMetrics.Parties[0].Players[0].Rotation
+	if len(rotation) != 3 {
+		t.Fatalf("Expected metrics for 3 entries, got %d", len(rotation))
+	}
+	opener, dotEntry, bolt := rotation[0], rotation[1], rotation[2]
+
+	// Both steps of the sequence are counted under its entry, once per iteration.
+	if opener.Executions != 20 || opener.WaitTimeSeconds != 0 {
+		t.Fatalf("Expected the opener to execute 2 steps per iteration without waiting, got %v", opener)
+	}
+
+	// The dot is checked while it is active, but only cast when it isn't.
+	if dotEntry.ConditionTrue >= dotEntry.Evaluations || dotEntry.Executions == 0 || dotEntry.Executions > dotEntry.ConditionTrue {
+		t.Fatalf("Expected the dot's condition to be false for some evaluations, got %v", dotEntry)
+	}
+
+	// When the wait ends, the dot's condition is true but the opener's GCD has 1s left.
+	// A missed dot waits for its own GCD before it is cast again.
+	if dotEntry.WaitTimeSeconds < 10 || int64(dotEntry.WaitTimeSeconds*1000)%500 != 0 {
+		t.Fatalf("Expected the dot to wait at least 1s for the GCD in each iteration, got %0.3fs in total", dotEntry.WaitTimeSeconds)
+	}
+
+	if bolt.ListIndex != 2 || bolt.Executions == 0 || bolt.ConditionTrue != bolt.Evaluations {
+		t.Fatalf("Expected the unconditional bolt to be cast, got %v", bolt)
+	}
+}
@@ -40,10 +40,30 @@ func (rot *APLRotation) newValueAuraIsActive(config *proto.APLValueAuraIsActive)
 		return nil
 	}
 
+	if reactionTime := rot.unit.Skill.ProcReaction; reactionTime > 0 && !rot.castsAura(aura.Get()) {
+		return &APLValueAuraIsActiveWithReactionTime{
+			aura:         aura,
+			reactionTime: reactionTime,
+		}
+	}
+
 	return &APLValueAuraIsActive{
 		aura: aura,
 	}
 }
+
+// Whether the aura comes from one of the unit's own spells, like its DoTs and
+// self buffs. The player knows about these as soon as they're applied, so only
+// other auras, like procs and buffs from other players, need reaction time.
+func (rot *APLRotation) castsAura(aura *Aura) bool {
+	for _, spell := range rot.unit.Spellbook {
+		if spell.Flags.Matches(SpellFlagAPL) && spell.ActionID.SameActionIgnoreTag(aura.ActionID) {
+			return true
+		}
+	}
+	return false
+}
+
 func (value *APLValueAuraIsActive) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeBool
 }
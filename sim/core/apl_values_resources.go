@@ -144,6 +144,31 @@ func (value *APLValueCurrentRage) String() string {
 	return "Current Rage"
 }
 
+type APLValueCurrentFocus struct {
+	DefaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueCurrentFocus(_ *proto.APLValueCurrentFocus) APLValue {
+	unit := rot.unit
+	if !unit.HasFocusBar() {
+		rot.ValidationWarning("%s does not use Focus", unit.Label)
+		return nil
+	}
+	return &APLValueCurrentFocus{
+		unit: unit,
+	}
+}
+func (value *APLValueCurrentFocus) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueCurrentFocus) GetFloat(_ *Simulation) float64 {
+	return value.unit.CurrentFocus()
+}
+func (value *APLValueCurrentFocus) String() string {
+	return "Current Focus"
+}
+
 type APLValueCurrentEnergy struct {
 	DefaultAPLValueImpl
 	unit *Unit
@@ -0,0 +1,95 @@
+package apltext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func TestRoundTripUIAPLs(t *testing.T) {
+	files, err := filepath.Glob("../../../ui/*/apls/*.apl.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("No APL files found")
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := &proto.APLRotation{}
+		if err := protojson.Unmarshal(data, expected); err != nil {
+			t.Fatalf("%s: %s", file, err)
+		}
+
+		text := Format(expected)
+		actual, err := Parse(text)
+		if err != nil {
+			t.Fatalf("%s: failed to parse formatted APL: %s\n%s", file, err, text)
+		}
+		if !googleProto.Equal(expected, actual) {
+			t.Fatalf("%s: round trip mismatch\nExpected: %s\nActual:   %s", file, expected, actual)
+		}
+		if reformatted := Format(actual); reformatted != text {
+			t.Fatalf("%s: formatting is not stable\n%s\n%s", file, text, reformatted)
+		}
+	}
+}
+
+func TestOperators(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{`current_time() > "5s" & !gcd_is_ready() | is_execute_phase(threshold: E20)`, `current_time() > "5s" & !gcd_is_ready() | is_execute_phase(threshold: E20)`},
+		{`(current_mana() | current_rage()) & current_energy()`, `(current_mana() | current_rage()) & current_energy()`},
+		{`!(current_time() < "1s")`, `!(current_time() < "1s")`},
+		{`current_mana() - (current_rage() - "1") * "2"`, `current_mana() - (current_rage() - "1") * "2"`},
+		{`((current_mana() - current_rage()) - "1")`, `current_mana() - current_rage() - "1"`},
+		{`and(vals: [current_time()])`, `and(vals: [current_time()])`},
+		{`const(val: "3")`, `"3"`},
+	}
+
+	for _, tc := range testCases {
+		rot, err := Parse("action: wait(duration: " + tc.input + ")")
+		if err != nil {
+			t.Fatalf("%s: %s", tc.input, err)
+		}
+		expected := "\naction: wait(duration: " + tc.expected + ")\n"
+		if actual := Format(rot); actual != expected {
+			t.Fatalf("Expected %q, got %q", expected, actual)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		input string
+		line  int
+		col   int
+	}{
+		{"action: cast_spell(spell_id: spell(123)) if", 1, 44},
+		{"type: TypeAPL\naction: cast_spel(spell_id: spell(123))", 2, 9},
+		{"action: cast_spell(\n  spell: spell(123))", 2, 3},
+		{"prepull \"-1s\": cast_spell(spell_id: spell(12x3))", 1, 45},
+		{"action: wait(duration: \"1s)", 1, 24},
+	}
+
+	for _, tc := range testCases {
+		_, err := Parse(tc.input)
+		parseErr, ok := err.(*ParseError)
+		if !ok {
+			t.Fatalf("%q: expected a ParseError, got %v", tc.input, err)
+		}
+		if parseErr.Line != tc.line || parseErr.Col != tc.col {
+			t.Fatalf("%q: expected error at %d:%d, got %s", tc.input, tc.line, tc.col, parseErr)
+		}
+	}
+}
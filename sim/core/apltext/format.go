@@ -0,0 +1,290 @@
+package apltext
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	rotationDesc = (&proto.APLRotation{}).ProtoReflect().Descriptor()
+	actionDesc   = (&proto.APLAction{}).ProtoReflect().Descriptor()
+	valueDesc    = (&proto.APLValue{}).ProtoReflect().Descriptor()
+	actionIDDesc = (&proto.ActionID{}).ProtoReflect().Descriptor()
+)
+
+// Operator precedences for values, from loosest to tightest binding.
+const (
+	precOr = iota + 1
+	precAnd
+	precNot
+	precCmp
+	precAdd
+	precMul
+	precPrimary
+)
+
+var cmpOps = map[proto.APLValueCompare_ComparisonOperator]string{
+	proto.APLValueCompare_OpEq: "==",
+	proto.APLValueCompare_OpNe: "!=",
+	proto.APLValueCompare_OpLt: "<",
+	proto.APLValueCompare_OpLe: "<=",
+	proto.APLValueCompare_OpGt: ">",
+	proto.APLValueCompare_OpGe: ">=",
+}
+
+var mathOps = map[proto.APLValueMath_MathOperator]string{
+	proto.APLValueMath_OpAdd: "+",
+	proto.APLValueMath_OpSub: "-",
+	proto.APLValueMath_OpMul: "*",
+	proto.APLValueMath_OpDiv: "/",
+}
+
+// Format converts a rotation into its text representation, with one prepull
+// action or priority list entry per line.
+func Format(rot *proto.APLRotation) string {
+	var sb strings.Builder
+	msg := rot.ProtoReflect()
+
+	fields := rotationDesc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Name() == "prepull_actions" || fd.Name() == "priority_list" || !msg.Has(fd) {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s: %s\n", fd.Name(), formatField(fd, msg.Get(fd)))
+	}
+
+	if len(rot.PrepullActions) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, item := range rot.PrepullActions {
+		sb.WriteString("prepull")
+		if item.Hide {
+			sb.WriteString(" hidden")
+		}
+		if item.DoAtValue != nil {
+			sb.WriteString(" " + formatValue(item.DoAtValue))
+		}
+		sb.WriteString(": " + formatItemAction(item.Action) + "\n")
+	}
+
+	if len(rot.PriorityList) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, item := range rot.PriorityList {
+		sb.WriteString("action")
+		if item.Hide {
+			sb.WriteString(" hidden")
+		}
+		if item.Notes != "" {
+			sb.WriteString(" notes " + strconv.Quote(item.Notes))
+		}
+		sb.WriteString(": " + formatItemAction(item.Action) + "\n")
+	}
+
+	return sb.String()
+}
+
+func formatItemAction(action *proto.APLAction) string {
+	if action == nil {
+		return "none"
+	}
+	return formatAction(action)
+}
+
+func formatAction(action *proto.APLAction) string {
+	msg := action.ProtoReflect()
+
+	var str string
+	if oneofField := msg.WhichOneof(actionDesc.Oneofs().ByName("action")); oneofField != nil {
+		str = formatCall(string(oneofField.Name()), msg.Get(oneofField).Message())
+	} else {
+		str = "{}"
+	}
+
+	if action.Condition != nil {
+		str += " if " + formatValue(action.Condition)
+	}
+	return str
+}
+
+func formatValue(value *proto.APLValue) string {
+	str, _ := formatValueWithPrec(value)
+	return str
+}
+
+func formatOperand(value *proto.APLValue, needParens func(prec int) bool) string {
+	str, prec := formatValueWithPrec(value)
+	if needParens(prec) {
+		return "(" + str + ")"
+	}
+	return str
+}
+
+// Returns the formatted value, along with the precedence of its outermost operator.
+func formatValueWithPrec(value *proto.APLValue) (string, int) {
+	switch v := value.Value.(type) {
+	case *proto.APLValue_Const:
+		if v.Const != nil {
+			return strconv.Quote(v.Const.Val), precPrimary
+		}
+	case *proto.APLValue_Or:
+		if v.Or != nil && len(v.Or.Vals) >= 2 && allSet(v.Or.Vals) {
+			return strings.Join(mapValues(v.Or.Vals, func(val *proto.APLValue) string {
+				return formatOperand(val, func(prec int) bool { return prec <= precOr })
+			}), " | "), precOr
+		}
+	case *proto.APLValue_And:
+		if v.And != nil && len(v.And.Vals) >= 2 && allSet(v.And.Vals) {
+			return strings.Join(mapValues(v.And.Vals, func(val *proto.APLValue) string {
+				return formatOperand(val, func(prec int) bool { return prec <= precAnd })
+			}), " & "), precAnd
+		}
+	case *proto.APLValue_Not:
+		if v.Not != nil && v.Not.Val != nil {
+			return "!" + formatOperand(v.Not.Val, func(prec int) bool { return prec < precPrimary && prec != precNot }), precNot
+		}
+	case *proto.APLValue_Cmp:
+		if op, ok := cmpOps[v.Cmp.GetOp()]; ok && v.Cmp.Lhs != nil && v.Cmp.Rhs != nil {
+			lhs := formatOperand(v.Cmp.Lhs, func(prec int) bool { return prec <= precCmp })
+			rhs := formatOperand(v.Cmp.Rhs, func(prec int) bool { return prec <= precCmp })
+			return lhs + " " + op + " " + rhs, precCmp
+		}
+	case *proto.APLValue_Math:
+		if op, ok := mathOps[v.Math.GetOp()]; ok && v.Math.Lhs != nil && v.Math.Rhs != nil {
+			opPrec := precAdd
+			if v.Math.Op == proto.APLValueMath_OpMul || v.Math.Op == proto.APLValueMath_OpDiv {
+				opPrec = precMul
+			}
+			lhs := formatOperand(v.Math.Lhs, func(prec int) bool { return prec < opPrec })
+			rhs := formatOperand(v.Math.Rhs, func(prec int) bool { return prec <= opPrec })
+			return lhs + " " + op + " " + rhs, opPrec
+		}
+	}
+
+	msg := value.ProtoReflect()
+	if oneofField := msg.WhichOneof(valueDesc.Oneofs().ByName("value")); oneofField != nil {
+		return formatCall(string(oneofField.Name()), msg.Get(oneofField).Message()), precPrimary
+	}
+	return "{}", precPrimary
+}
+
+func allSet(values []*proto.APLValue) bool {
+	for _, value := range values {
+		if value == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func mapValues(values []*proto.APLValue, fn func(*proto.APLValue) string) []string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = fn(value)
+	}
+	return strs
+}
+
+// Formats a message as name(field: value, ...).
+func formatCall(name string, msg protoreflect.Message) string {
+	return name + "(" + strings.Join(formatFields(msg), ", ") + ")"
+}
+
+// Formats a message as {field: value, ...}.
+func formatStruct(msg protoreflect.Message) string {
+	return "{" + strings.Join(formatFields(msg), ", ") + "}"
+}
+
+func formatFields(msg protoreflect.Message) []string {
+	var strs []string
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if msg.Has(fd) {
+			strs = append(strs, fmt.Sprintf("%s: %s", fd.Name(), formatField(fd, msg.Get(fd))))
+		}
+	}
+	return strs
+}
+
+func formatField(fd protoreflect.FieldDescriptor, val protoreflect.Value) string {
+	if fd.IsList() {
+		list := val.List()
+		elems := make([]string, list.Len())
+		for i := range elems {
+			elems[i] = formatSingular(fd, list.Get(i))
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	}
+	return formatSingular(fd, val)
+}
+
+func formatSingular(fd protoreflect.FieldDescriptor, val protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return formatMessage(val.Message())
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(val.Bool())
+	case protoreflect.EnumKind:
+		if enumVal := fd.Enum().Values().ByNumber(val.Enum()); enumVal != nil {
+			return string(enumVal.Name())
+		}
+		return strconv.FormatInt(int64(val.Enum()), 10)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(val.Int(), 10)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(val.Uint(), 10)
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(val.Float(), 'g', -1, 32)
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(val.Float(), 'g', -1, 64)
+	case protoreflect.StringKind:
+		return strconv.Quote(val.String())
+	case protoreflect.BytesKind:
+		return strconv.Quote(string(val.Bytes()))
+	default:
+		panic(fmt.Sprintf("unsupported field kind %s", fd.Kind()))
+	}
+}
+
+func formatMessage(msg protoreflect.Message) string {
+	switch msg.Descriptor() {
+	case valueDesc:
+		return formatValue(msg.Interface().(*proto.APLValue))
+	case actionDesc:
+		return formatAction(msg.Interface().(*proto.APLAction))
+	case actionIDDesc:
+		return formatActionID(msg.Interface().(*proto.ActionID))
+	default:
+		return formatStruct(msg)
+	}
+}
+
+// ActionIDs are written as spell(id), item(id) or other(id), with tag and rank as optional named args.
+func formatActionID(id *proto.ActionID) string {
+	var str string
+	switch rawID := id.RawId.(type) {
+	case *proto.ActionID_SpellId:
+		str = fmt.Sprintf("spell(%d", rawID.SpellId)
+	case *proto.ActionID_ItemId:
+		str = fmt.Sprintf("item(%d", rawID.ItemId)
+	case *proto.ActionID_OtherId:
+		str = fmt.Sprintf("other(%s", rawID.OtherId)
+	default:
+		return formatStruct(id.ProtoReflect())
+	}
+
+	if id.Tag != 0 {
+		str += fmt.Sprintf(", tag: %d", id.Tag)
+	}
+	if id.Rank != 0 {
+		str += fmt.Sprintf(", rank: %d", id.Rank)
+	}
+	return str + ")"
+}
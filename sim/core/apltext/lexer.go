@@ -0,0 +1,140 @@
+package apltext
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string // For strings, this is the unquoted value.
+	line int
+	col  int
+}
+
+func (tok token) String() string {
+	switch tok.kind {
+	case tokEOF:
+		return "end of input"
+	case tokString:
+		return strconv.Quote(tok.text)
+	default:
+		return fmt.Sprintf("'%s'", tok.text)
+	}
+}
+
+// Punctuation, longest first so that e.g. '<=' is matched before '<'.
+var puncts = []string{"==", "!=", "<=", ">=", "(", ")", "[", "]", "{", "}", ",", ":", "&", "|", "!", "<", ">", "+", "-", "*", "/"}
+
+// ParseError is returned for malformed input, and points at the offending token.
+type ParseError struct {
+	Line int
+	Col  int
+	Msg  string
+}
+
+func (err *ParseError) Error() string {
+	return fmt.Sprintf("line %d, col %d: %s", err.Line, err.Col, err.Msg)
+}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	line, col := 1, 1
+
+	advance := func(n int) {
+		for _, c := range src[:n] {
+			if c == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+		}
+		src = src[n:]
+	}
+
+	for len(src) > 0 {
+		c := src[0]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			advance(1)
+		case c == '#':
+			end := strings.IndexByte(src, '\n')
+			if end < 0 {
+				end = len(src)
+			}
+			advance(end)
+		case isIdentStart(c):
+			n := 1
+			for n < len(src) && isIdentChar(src[n]) {
+				n++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[:n], line: line, col: col})
+			advance(n)
+		case isDigit(c):
+			n := 1
+			for n < len(src) && (isDigit(src[n]) || src[n] == '.' || src[n] == 'e' || src[n] == 'E' ||
+				((src[n] == '+' || src[n] == '-') && (src[n-1] == 'e' || src[n-1] == 'E'))) {
+				n++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[:n], line: line, col: col})
+			advance(n)
+		case c == '"':
+			n := 1
+			for n < len(src) && src[n] != '"' && src[n] != '\n' {
+				if src[n] == '\\' {
+					n++
+				}
+				n++
+			}
+			if n >= len(src) || src[n] != '"' {
+				return nil, &ParseError{Line: line, Col: col, Msg: "unterminated string"}
+			}
+			str, err := strconv.Unquote(src[:n+1])
+			if err != nil {
+				return nil, &ParseError{Line: line, Col: col, Msg: fmt.Sprintf("invalid string: %s", err)}
+			}
+			tokens = append(tokens, token{kind: tokString, text: str, line: line, col: col})
+			advance(n + 1)
+		default:
+			matched := false
+			for _, punct := range puncts {
+				if strings.HasPrefix(src, punct) {
+					tokens = append(tokens, token{kind: tokPunct, text: punct, line: line, col: col})
+					advance(len(punct))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, &ParseError{Line: line, Col: col, Msg: fmt.Sprintf("unexpected character %q", c)}
+			}
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokEOF, line: line, col: col})
+	return tokens, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,586 @@
+// Package apltext implements a compact text syntax for APL rotations, which
+// round-trips to and from proto.APLRotation. Example:
+//
+//	type: TypeAPL
+//
+//	prepull "-1.5s": cast_spell(spell_id: spell(10181, rank: 4))
+//
+//	action: autocast_other_cooldowns()
+//	action notes "Keep the debuff up": cast_spell(spell_id: spell(12873)) if !aura_is_active(aura_id: spell(12873))
+//	action hidden: cast_spell(spell_id: spell(10151)) if current_mana_percent() > "30%" & gcd_is_ready()
+//
+// Actions and values are written as their proto oneof field name followed by the
+// fields of the inner message, e.g. cast_spell(spell_id: ...). Other messages are
+// written as {field: value, ...}, and ActionIDs as spell(id), item(id) or other(id).
+// String literals in a value position are constants, and cmp, math, and, or and not
+// can be written with the infix operators ==, !=, <, <=, >, >=, +, -, *, /, &, | and !.
+// Comments start with '#' and run until the end of the line.
+package apltext
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse converts the text representation of a rotation back into a proto.
+func Parse(src string) (*proto.APLRotation, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	rot := &proto.APLRotation{}
+	for p.peek().kind != tokEOF {
+		if err := p.parseStatement(rot); err != nil {
+			return nil, err
+		}
+	}
+	return rot, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isPunct(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokPunct && tok.text == text
+}
+
+func (p *parser) isIdent(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokIdent && tok.text == text
+}
+
+func (p *parser) expectPunct(text string) error {
+	if tok := p.next(); tok.kind != tokPunct || tok.text != text {
+		return p.errorf(tok, "expected '%s', found %s", text, tok)
+	}
+	return nil
+}
+
+func (p *parser) expectIdent() (token, error) {
+	tok := p.next()
+	if tok.kind != tokIdent {
+		return tok, p.errorf(tok, "expected identifier, found %s", tok)
+	}
+	return tok, nil
+}
+
+func (p *parser) errorf(tok token, format string, args ...interface{}) error {
+	return &ParseError{Line: tok.line, Col: tok.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseStatement(rot *proto.APLRotation) error {
+	keyword, err := p.expectIdent()
+	if err != nil {
+		return err
+	}
+
+	switch keyword.text {
+	case "prepull":
+		item := &proto.APLPrepullAction{}
+		if p.isIdent("hidden") {
+			p.next()
+			item.Hide = true
+		}
+		if !p.isPunct(":") {
+			if item.DoAtValue, err = p.parseValue(); err != nil {
+				return err
+			}
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return err
+		}
+		if item.Action, err = p.parseItemAction(); err != nil {
+			return err
+		}
+		rot.PrepullActions = append(rot.PrepullActions, item)
+	case "action":
+		item := &proto.APLListItem{}
+		if p.isIdent("hidden") {
+			p.next()
+			item.Hide = true
+		}
+		if p.isIdent("notes") {
+			p.next()
+			tok := p.next()
+			if tok.kind != tokString {
+				return p.errorf(tok, "expected notes string, found %s", tok)
+			}
+			item.Notes = tok.text
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return err
+		}
+		if item.Action, err = p.parseItemAction(); err != nil {
+			return err
+		}
+		rot.PriorityList = append(rot.PriorityList, item)
+	default:
+		fd := rotationDesc.Fields().ByName(protoreflect.Name(keyword.text))
+		if fd == nil || fd.Name() == "prepull_actions" || fd.Name() == "priority_list" {
+			return p.errorf(keyword, "unknown statement '%s', expected 'prepull', 'action' or a rotation field", keyword.text)
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return err
+		}
+		if err := p.parseField(rot.ProtoReflect(), fd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseItemAction() (*proto.APLAction, error) {
+	if p.isIdent("none") {
+		p.next()
+		return nil, nil
+	}
+	return p.parseAction()
+}
+
+func (p *parser) parseAction() (*proto.APLAction, error) {
+	action := &proto.APLAction{}
+	msg := action.ProtoReflect()
+
+	if p.isPunct("{") {
+		if err := p.parseStruct(msg); err != nil {
+			return nil, err
+		}
+	} else {
+		name, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+		fd := actionDesc.Oneofs().ByName("action").Fields().ByName(protoreflect.Name(name.text))
+		if fd == nil {
+			return nil, p.errorf(name, "unknown action '%s'", name.text)
+		}
+		if err := p.parseCallArgs(msg.Mutable(fd).Message()); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isIdent("if") {
+		p.next()
+		condition, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		action.Condition = condition
+	}
+	return action, nil
+}
+
+func (p *parser) parseValue() (*proto.APLValue, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (*proto.APLValue, error) {
+	vals, err := p.parseSeparated("|", p.parseAnd)
+	if err != nil || len(vals) == 1 {
+		return first(vals), err
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: vals}}}, nil
+}
+
+func (p *parser) parseAnd() (*proto.APLValue, error) {
+	vals, err := p.parseSeparated("&", p.parseNot)
+	if err != nil || len(vals) == 1 {
+		return first(vals), err
+	}
+	return &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: vals}}}, nil
+}
+
+func (p *parser) parseSeparated(sep string, parseOperand func() (*proto.APLValue, error)) ([]*proto.APLValue, error) {
+	var vals []*proto.APLValue
+	for {
+		val, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, val)
+		if !p.isPunct(sep) {
+			return vals, nil
+		}
+		p.next()
+	}
+}
+
+func first(vals []*proto.APLValue) *proto.APLValue {
+	if len(vals) == 0 {
+		return nil
+	}
+	return vals[0]
+}
+
+func (p *parser) parseNot() (*proto.APLValue, error) {
+	if !p.isPunct("!") {
+		return p.parseCmp()
+	}
+	p.next()
+	val, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: val}}}, nil
+}
+
+func (p *parser) parseCmp() (*proto.APLValue, error) {
+	lhs, err := p.parseMath(precAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	for op, text := range cmpOps {
+		if p.isPunct(text) {
+			p.next()
+			rhs, err := p.parseMath(precAdd)
+			if err != nil {
+				return nil, err
+			}
+			return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{Op: op, Lhs: lhs, Rhs: rhs}}}, nil
+		}
+	}
+	return lhs, nil
+}
+
+// Parses left-associative math operators with the given precedence or tighter.
+func (p *parser) parseMath(prec int) (*proto.APLValue, error) {
+	parseOperand := p.parsePrimary
+	if prec == precAdd {
+		parseOperand = func() (*proto.APLValue, error) { return p.parseMath(precMul) }
+	}
+
+	lhs, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.peekMathOp(prec)
+		if !ok {
+			return lhs, nil
+		}
+		p.next()
+		rhs, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{Op: op, Lhs: lhs, Rhs: rhs}}}
+	}
+}
+
+func (p *parser) peekMathOp(prec int) (proto.APLValueMath_MathOperator, bool) {
+	ops := []proto.APLValueMath_MathOperator{proto.APLValueMath_OpAdd, proto.APLValueMath_OpSub}
+	if prec == precMul {
+		ops = []proto.APLValueMath_MathOperator{proto.APLValueMath_OpMul, proto.APLValueMath_OpDiv}
+	}
+	for _, op := range ops {
+		if p.isPunct(mathOps[op]) {
+			return op, true
+		}
+	}
+	return 0, false
+}
+
+func (p *parser) parsePrimary() (*proto.APLValue, error) {
+	value := &proto.APLValue{}
+	tok := p.peek()
+
+	switch {
+	case tok.kind == tokString:
+		p.next()
+		value.Value = &proto.APLValue_Const{Const: &proto.APLValueConst{Val: tok.text}}
+	case tok.kind == tokPunct && tok.text == "(":
+		p.next()
+		inner, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case tok.kind == tokPunct && tok.text == "{":
+		if err := p.parseStruct(value.ProtoReflect()); err != nil {
+			return nil, err
+		}
+	case tok.kind == tokIdent:
+		p.next()
+		fd := valueDesc.Oneofs().ByName("value").Fields().ByName(protoreflect.Name(tok.text))
+		if fd == nil {
+			return nil, p.errorf(tok, "unknown value '%s'", tok.text)
+		}
+		if err := p.parseCallArgs(value.ProtoReflect().Mutable(fd).Message()); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.errorf(tok, "expected value, found %s", tok)
+	}
+	return value, nil
+}
+
+// Parses (field: value, ...) into msg.
+func (p *parser) parseCallArgs(msg protoreflect.Message) error {
+	if err := p.expectPunct("("); err != nil {
+		return err
+	}
+	return p.parseFields(msg, ")")
+}
+
+// Parses {field: value, ...} into msg.
+func (p *parser) parseStruct(msg protoreflect.Message) error {
+	if err := p.expectPunct("{"); err != nil {
+		return err
+	}
+	return p.parseFields(msg, "}")
+}
+
+func (p *parser) parseFields(msg protoreflect.Message, closing string) error {
+	for !p.isPunct(closing) {
+		name, err := p.expectIdent()
+		if err != nil {
+			return err
+		}
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name.text))
+		if fd == nil {
+			return p.errorf(name, "unknown field '%s' for %s", name.text, msg.Descriptor().Name())
+		}
+		if msg.Has(fd) {
+			return p.errorf(name, "duplicate field '%s'", name.text)
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return err
+		}
+		if err := p.parseField(msg, fd); err != nil {
+			return err
+		}
+		if !p.isPunct(",") {
+			break
+		}
+		p.next()
+	}
+	return p.expectPunct(closing)
+}
+
+func (p *parser) parseField(msg protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	if fd.IsMap() {
+		return p.errorf(p.peek(), "map field '%s' is not supported", fd.Name())
+	}
+
+	if !fd.IsList() {
+		if fd.Kind() == protoreflect.MessageKind {
+			return p.parseMessage(msg.Mutable(fd).Message())
+		}
+		val, err := p.parseScalar(fd)
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, val)
+		return nil
+	}
+
+	list := msg.Mutable(fd).List()
+	if err := p.expectPunct("["); err != nil {
+		return err
+	}
+	for !p.isPunct("]") {
+		if fd.Kind() == protoreflect.MessageKind {
+			elem := list.NewElement()
+			if err := p.parseMessage(elem.Message()); err != nil {
+				return err
+			}
+			list.Append(elem)
+		} else {
+			val, err := p.parseScalar(fd)
+			if err != nil {
+				return err
+			}
+			list.Append(val)
+		}
+		if !p.isPunct(",") {
+			break
+		}
+		p.next()
+	}
+	return p.expectPunct("]")
+}
+
+func (p *parser) parseMessage(msg protoreflect.Message) error {
+	var parsed interface{ ProtoReflect() protoreflect.Message }
+	var err error
+
+	switch msg.Descriptor() {
+	case valueDesc:
+		parsed, err = p.parseValue()
+	case actionDesc:
+		parsed, err = p.parseAction()
+	case actionIDDesc:
+		if p.isPunct("{") {
+			return p.parseStruct(msg)
+		}
+		parsed, err = p.parseActionID()
+	default:
+		return p.parseStruct(msg)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Copy the parsed message into the (empty) target message.
+	parsedMsg := parsed.ProtoReflect()
+	parsedMsg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		msg.Set(fd, v)
+		return true
+	})
+	return nil
+}
+
+func (p *parser) parseActionID() (*proto.ActionID, error) {
+	kind, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	id := &proto.ActionID{}
+	msg := id.ProtoReflect()
+	var fd protoreflect.FieldDescriptor
+	switch kind.text {
+	case "spell":
+		fd = actionIDDesc.Fields().ByName("spell_id")
+	case "item":
+		fd = actionIDDesc.Fields().ByName("item_id")
+	case "other":
+		fd = actionIDDesc.Fields().ByName("other_id")
+	default:
+		return nil, p.errorf(kind, "expected 'spell', 'item' or 'other', found %s", kind)
+	}
+
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	val, err := p.parseScalar(fd)
+	if err != nil {
+		return nil, err
+	}
+	msg.Set(fd, val)
+
+	if p.isPunct(",") {
+		p.next()
+		return id, p.parseFields(msg, ")")
+	}
+	return id, p.expectPunct(")")
+}
+
+func (p *parser) parseScalar(fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	tok := p.peek()
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		p.next()
+		if tok.kind == tokIdent && (tok.text == "true" || tok.text == "false") {
+			return protoreflect.ValueOfBool(tok.text == "true"), nil
+		}
+		return protoreflect.Value{}, p.errorf(tok, "expected 'true' or 'false' for field '%s', found %s", fd.Name(), tok)
+	case protoreflect.EnumKind:
+		if tok.kind == tokIdent {
+			p.next()
+			enumVal := fd.Enum().Values().ByName(protoreflect.Name(tok.text))
+			if enumVal == nil {
+				return protoreflect.Value{}, p.errorf(tok, "unknown %s value '%s'", fd.Enum().Name(), tok.text)
+			}
+			return protoreflect.ValueOfEnum(enumVal.Number()), nil
+		}
+		num, err := p.parseNumber(fd, func(s string) (interface{}, error) { return strconv.ParseInt(s, 10, 32) })
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(num.(int64))), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		num, err := p.parseNumber(fd, func(s string) (interface{}, error) { return strconv.ParseInt(s, 10, 32) })
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfInt32(int32(num.(int64))), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		num, err := p.parseNumber(fd, func(s string) (interface{}, error) { return strconv.ParseInt(s, 10, 64) })
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfInt64(num.(int64)), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		num, err := p.parseNumber(fd, func(s string) (interface{}, error) { return strconv.ParseUint(s, 10, 32) })
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfUint32(uint32(num.(uint64))), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		num, err := p.parseNumber(fd, func(s string) (interface{}, error) { return strconv.ParseUint(s, 10, 64) })
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfUint64(num.(uint64)), nil
+	case protoreflect.FloatKind:
+		num, err := p.parseNumber(fd, func(s string) (interface{}, error) { return strconv.ParseFloat(s, 32) })
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfFloat32(float32(num.(float64))), nil
+	case protoreflect.DoubleKind:
+		num, err := p.parseNumber(fd, func(s string) (interface{}, error) { return strconv.ParseFloat(s, 64) })
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfFloat64(num.(float64)), nil
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		p.next()
+		if tok.kind != tokString {
+			return protoreflect.Value{}, p.errorf(tok, "expected string for field '%s', found %s", fd.Name(), tok)
+		}
+		if fd.Kind() == protoreflect.BytesKind {
+			return protoreflect.ValueOfBytes([]byte(tok.text)), nil
+		}
+		return protoreflect.ValueOfString(tok.text), nil
+	default:
+		return protoreflect.Value{}, p.errorf(tok, "unsupported field kind %s for field '%s'", fd.Kind(), fd.Name())
+	}
+}
+
+// Parses an optionally negative number token using the provided conversion.
+func (p *parser) parseNumber(fd protoreflect.FieldDescriptor, convert func(string) (interface{}, error)) (interface{}, error) {
+	start := p.peek()
+	sign := ""
+	if p.isPunct("-") {
+		p.next()
+		sign = "-"
+	}
+
+	tok := p.next()
+	if tok.kind != tokNumber {
+		return nil, p.errorf(tok, "expected number for field '%s', found %s", fd.Name(), tok)
+	}
+	num, err := convert(sign + tok.text)
+	if err != nil {
+		return nil, p.errorf(start, "invalid number for field '%s': %s", fd.Name(), sign+tok.text)
+	}
+	return num, nil
+}
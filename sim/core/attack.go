@@ -356,7 +356,7 @@ func (wa *WeaponAttack) swing(sim *Simulation) time.Duration {
 		if isExtraAttack {
 			// For ranged extra attacks, we have to wait for the spell to hit before resettings the cast time and metrics split
 			if originalCastTime > 0 {
-				wa.spell.WaitTravelTime(sim, func(sim *Simulation) {
+				wa.spell.WaitTravelTime(sim, wa.unit.CurrentTarget, func(sim *Simulation) {
 					wa.spell.DefaultCast.CastTime = originalCastTime
 					wa.spell.SetMetricsSplit(0)
 				})
@@ -527,7 +527,7 @@ func (unit *Unit) EnableAutoAttacks(agent Agent, options AutoAttackOptions) {
 			baseDamage := spell.Unit.RangedWeaponDamage(sim, spell.RangedAttackPower(target, false))
 			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeRangedHitAndCrit)
 
-			spell.WaitTravelTime(sim, func(sim *Simulation) {
+			spell.WaitTravelTime(sim, target, func(sim *Simulation) {
 				spell.DealDamage(sim, result)
 			})
 		},
@@ -595,10 +595,10 @@ func (aa *AutoAttacks) reset(sim *Simulation) {
 			if aa.oh.unit.Type == EnemyUnit {
 				aa.oh.swingAt = DurationFromSeconds(aa.mh.SwingSpeed / 2)
 			} else {
-				if sim.RandomFloat("SwingResetWeapon") < 0.5 {
-					aa.mh.swingAt = DurationFromSeconds(sim.RandomFloat("SwingResetDelay") * aa.mh.SwingSpeed / 2)
+				if sim.UnitRandomFloat(aa.mh.unit, "SwingResetWeapon") < 0.5 {
+					aa.mh.swingAt = DurationFromSeconds(sim.UnitRandomFloat(aa.mh.unit, "SwingResetDelay") * aa.mh.SwingSpeed / 2)
 				} else {
-					aa.oh.swingAt = DurationFromSeconds(sim.RandomFloat("SwingResetDelay") * aa.mh.SwingSpeed / 2)
+					aa.oh.swingAt = DurationFromSeconds(sim.UnitRandomFloat(aa.mh.unit, "SwingResetDelay") * aa.mh.SwingSpeed / 2)
 				}
 			}
 		}
@@ -878,6 +878,7 @@ func (aa *AutoAttacks) NextRangedAttackAt() time.Duration {
 }
 
 type PPMManager struct {
+	unit        *Unit
 	ppm         float64
 	procMasks   []ProcMask
 	procChances []float64
@@ -892,7 +893,7 @@ type PPMManager struct {
 func (ppmm *PPMManager) Proc(sim *Simulation, procMask ProcMask, label string) bool {
 	for i, m := range ppmm.procMasks {
 		if m.Matches(procMask) {
-			return sim.RandomFloat(label) < ppmm.procChances[i]
+			return sim.UnitRandomFloat(ppmm.unit, label) < ppmm.procChances[i]
 		}
 	}
 	return false
@@ -902,13 +903,24 @@ func (ppmm *PPMManager) Proc(sim *Simulation, procMask ProcMask, label string) b
 // This is different from Proc() in that yellow melee hits use a proc chance based on the equipped
 // weapon speed rather than the base attack speed. This distinction matters for feral druids.
 func (ppmm *PPMManager) ProcWithWeaponSpecials(sim *Simulation, procMask ProcMask, label string) bool {
+	chance, ok := ppmm.chanceWithWeaponSpecials(procMask)
+	return ok && sim.UnitRandomFloat(ppmm.unit, label) < chance
+}
+
+// The chance used by ProcWithWeaponSpecials(), and false if the effect can't
+// proc from procMask at all, in which case nothing is rolled.
+func (ppmm *PPMManager) chanceWithWeaponSpecials(procMask ProcMask) (float64, bool) {
 	if procMask.Matches(ProcMaskMeleeMHSpecial) {
-		return sim.RandomFloat(label) < ppmm.mhSpecialProcChance
+		return ppmm.mhSpecialProcChance, true
 	} else if procMask.Matches(ProcMaskMeleeOHSpecial) {
-		return sim.RandomFloat(label) < ppmm.ohSpecialProcChance
-	} else {
-		return ppmm.Proc(sim, procMask, label)
+		return ppmm.ohSpecialProcChance, true
+	}
+	for i, m := range ppmm.procMasks {
+		if m.Matches(procMask) {
+			return ppmm.procChances[i], true
+		}
 	}
+	return 0, false
 }
 
 func (ppmm *PPMManager) Chance(procMask ProcMask) float64 {
@@ -929,7 +941,7 @@ func (aa *AutoAttacks) NewPPMManager(ppm float64, procMask ProcMask) PPMManager
 		return PPMManager{}
 	}
 
-	ppmm := PPMManager{ppm: ppm, procMasks: make([]ProcMask, 0, 2), procChances: make([]float64, 0, 2)}
+	ppmm := PPMManager{unit: aa.mh.unit, ppm: ppm, procMasks: make([]ProcMask, 0, 2), procChances: make([]float64, 0, 2)}
 
 	mergeOrAppend := func(speed float64, mask ProcMask) {
 		if speed == 0 || mask == 0 {
@@ -981,11 +993,11 @@ func (aa *AutoAttacks) PPMProc(sim *Simulation, ppm float64, procMask ProcMask,
 
 	switch {
 	case spell.ProcMask.Matches(procMask &^ ProcMaskMeleeOH &^ ProcMaskRanged):
-		return sim.RandomFloat(label) < ppm*aa.mh.SwingSpeed/60.0
+		return sim.UnitRandomFloat(aa.mh.unit, label) < ppm*aa.mh.SwingSpeed/60.0
 	case spell.ProcMask.Matches(procMask & ProcMaskMeleeOH):
-		return sim.RandomFloat(label) < ppm*aa.oh.SwingSpeed/60.0
+		return sim.UnitRandomFloat(aa.mh.unit, label) < ppm*aa.oh.SwingSpeed/60.0
 	case spell.ProcMask.Matches(procMask & ProcMaskRanged):
-		return sim.RandomFloat(label) < ppm*aa.ranged.SwingSpeed/60.0
+		return sim.UnitRandomFloat(aa.mh.unit, label) < ppm*aa.ranged.SwingSpeed/60.0
 	}
 	return false
 }
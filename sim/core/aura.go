@@ -65,6 +65,7 @@ type Aura struct {
 	Unit *Unit
 
 	active                     bool
+	disabled                   bool  // Disabled auras are never activated, see SimOptions.DisabledAuras.
 	activeIndex                int32 // Position of this aura's index in the activeAuras array.
 	onCastCompleteIndex        int32 // Position of this aura's index in the onCastCompleteAuras array.
 	onSpellHitDealtIndex       int32 // Position of this aura's index in the onSpellHitAuras array.
@@ -189,6 +190,9 @@ func (aura *Aura) GetStacks() int32 {
 }
 
 func (aura *Aura) SetStacks(sim *Simulation, newStacks int32) {
+	if aura.disabled {
+		return
+	}
 	if !aura.IsActive() && newStacks != 0 {
 		panic("Trying to set non-zero stacks on inactive aura!")
 	}
@@ -294,6 +298,13 @@ type AuraFactory func(*Simulation) *Aura
 // Callback for doing something on reset.
 type ResetEffect func(*Simulation)
 
+// An active aura with one of its callbacks, so that dispatching an event
+// doesn't need to load the callback from each Aura.
+type auraCallbackEntry[F any] struct {
+	aura     *Aura
+	callback F
+}
+
 // auraTracker is a centralized implementation of CD and Aura tracking.
 //
 //	This is used by all Units.
@@ -306,6 +317,10 @@ type auraTracker struct {
 	// All registered auras, both active and inactive.
 	auras []*Aura
 
+	// Auras that were only registered by the workers of a parallel sim, which
+	// just hold their merged metrics.
+	workerAuras []*Aura
+
 	aurasByTag map[string][]*Aura
 
 	// IDs of Auras that may expire and are currently active, in no particular order.
@@ -314,17 +329,18 @@ type auraTracker struct {
 	// caches the minimum expires time of all active auras; might be stale (too low) after Deactivate().
 	minExpires time.Duration
 
-	// Auras that have a non-nil XXX function set and are currently active.
-	onCastCompleteAuras        []*Aura
-	onSpellHitDealtAuras       []*Aura
-	onSpellHitTakenAuras       []*Aura
-	onPeriodicDamageDealtAuras []*Aura
-	onPeriodicDamageTakenAuras []*Aura
-	onHealDealtAuras           []*Aura
-	onHealTakenAuras           []*Aura
-	onPeriodicHealDealtAuras   []*Aura
-	onPeriodicHealTakenAuras   []*Aura
-	onRageChangeAuras          []*Aura
+	// Auras that have a non-nil XXX function set and are currently active, with
+	// that function as of their activation.
+	onCastCompleteAuras        []auraCallbackEntry[OnCastComplete]
+	onSpellHitDealtAuras       []auraCallbackEntry[OnSpellHit]
+	onSpellHitTakenAuras       []auraCallbackEntry[OnSpellHit]
+	onPeriodicDamageDealtAuras []auraCallbackEntry[OnPeriodicDamage]
+	onPeriodicDamageTakenAuras []auraCallbackEntry[OnPeriodicDamage]
+	onHealDealtAuras           []auraCallbackEntry[OnSpellHit]
+	onHealTakenAuras           []auraCallbackEntry[OnSpellHit]
+	onPeriodicHealDealtAuras   []auraCallbackEntry[OnPeriodicDamage]
+	onPeriodicHealTakenAuras   []auraCallbackEntry[OnPeriodicDamage]
+	onRageChangeAuras          []auraCallbackEntry[OnRageChange]
 }
 
 func newAuraTracker() auraTracker {
@@ -401,6 +417,7 @@ func (at *auraTracker) registerAura(unit *Unit, aura Aura) *Aura {
 	newAura.onPeriodicHealDealtIndex = Inactive
 	newAura.onPeriodicHealTakenIndex = Inactive
 	newAura.onRageChangeIndex = Inactive
+	newAura.disabled = unit.Env != nil && unit.Env.isAuraDisabled(aura.ActionID)
 
 	at.auras = append(at.auras, newAura)
 	if newAura.Tag != "" {
@@ -557,6 +574,10 @@ restart:
 // Adds a new aura to the simulation. If an aura with the same ID already
 // exists it will be replaced with the new one.
 func (aura *Aura) Activate(sim *Simulation) {
+	if aura.disabled {
+		return
+	}
+
 	aura.metrics.Procs++
 	if aura.IsActive() {
 		aura.Refresh(sim)
@@ -593,52 +614,52 @@ func (aura *Aura) Activate(sim *Simulation) {
 
 	if aura.OnCastComplete != nil {
 		aura.onCastCompleteIndex = int32(len(aura.Unit.onCastCompleteAuras))
-		aura.Unit.onCastCompleteAuras = append(aura.Unit.onCastCompleteAuras, aura)
+		aura.Unit.onCastCompleteAuras = append(aura.Unit.onCastCompleteAuras, auraCallbackEntry[OnCastComplete]{aura: aura, callback: aura.OnCastComplete})
 	}
 
 	if aura.OnSpellHitDealt != nil {
 		aura.onSpellHitDealtIndex = int32(len(aura.Unit.onSpellHitDealtAuras))
-		aura.Unit.onSpellHitDealtAuras = append(aura.Unit.onSpellHitDealtAuras, aura)
+		aura.Unit.onSpellHitDealtAuras = append(aura.Unit.onSpellHitDealtAuras, auraCallbackEntry[OnSpellHit]{aura: aura, callback: aura.OnSpellHitDealt})
 	}
 
 	if aura.OnSpellHitTaken != nil {
 		aura.onSpellHitTakenIndex = int32(len(aura.Unit.onSpellHitTakenAuras))
-		aura.Unit.onSpellHitTakenAuras = append(aura.Unit.onSpellHitTakenAuras, aura)
+		aura.Unit.onSpellHitTakenAuras = append(aura.Unit.onSpellHitTakenAuras, auraCallbackEntry[OnSpellHit]{aura: aura, callback: aura.OnSpellHitTaken})
 	}
 
 	if aura.OnPeriodicDamageDealt != nil {
 		aura.onPeriodicDamageDealtIndex = int32(len(aura.Unit.onPeriodicDamageDealtAuras))
-		aura.Unit.onPeriodicDamageDealtAuras = append(aura.Unit.onPeriodicDamageDealtAuras, aura)
+		aura.Unit.onPeriodicDamageDealtAuras = append(aura.Unit.onPeriodicDamageDealtAuras, auraCallbackEntry[OnPeriodicDamage]{aura: aura, callback: aura.OnPeriodicDamageDealt})
 	}
 
 	if aura.OnPeriodicDamageTaken != nil {
 		aura.onPeriodicDamageTakenIndex = int32(len(aura.Unit.onPeriodicDamageTakenAuras))
-		aura.Unit.onPeriodicDamageTakenAuras = append(aura.Unit.onPeriodicDamageTakenAuras, aura)
+		aura.Unit.onPeriodicDamageTakenAuras = append(aura.Unit.onPeriodicDamageTakenAuras, auraCallbackEntry[OnPeriodicDamage]{aura: aura, callback: aura.OnPeriodicDamageTaken})
 	}
 
 	if aura.OnHealDealt != nil {
 		aura.onHealDealtIndex = int32(len(aura.Unit.onHealDealtAuras))
-		aura.Unit.onHealDealtAuras = append(aura.Unit.onHealDealtAuras, aura)
+		aura.Unit.onHealDealtAuras = append(aura.Unit.onHealDealtAuras, auraCallbackEntry[OnSpellHit]{aura: aura, callback: aura.OnHealDealt})
 	}
 
 	if aura.OnHealTaken != nil {
 		aura.onHealTakenIndex = int32(len(aura.Unit.onHealTakenAuras))
-		aura.Unit.onHealTakenAuras = append(aura.Unit.onHealTakenAuras, aura)
+		aura.Unit.onHealTakenAuras = append(aura.Unit.onHealTakenAuras, auraCallbackEntry[OnSpellHit]{aura: aura, callback: aura.OnHealTaken})
 	}
 
 	if aura.OnPeriodicHealDealt != nil {
 		aura.onPeriodicHealDealtIndex = int32(len(aura.Unit.onPeriodicHealDealtAuras))
-		aura.Unit.onPeriodicHealDealtAuras = append(aura.Unit.onPeriodicHealDealtAuras, aura)
+		aura.Unit.onPeriodicHealDealtAuras = append(aura.Unit.onPeriodicHealDealtAuras, auraCallbackEntry[OnPeriodicDamage]{aura: aura, callback: aura.OnPeriodicHealDealt})
 	}
 
 	if aura.OnPeriodicHealTaken != nil {
 		aura.onPeriodicHealTakenIndex = int32(len(aura.Unit.onPeriodicHealTakenAuras))
-		aura.Unit.onPeriodicHealTakenAuras = append(aura.Unit.onPeriodicHealTakenAuras, aura)
+		aura.Unit.onPeriodicHealTakenAuras = append(aura.Unit.onPeriodicHealTakenAuras, auraCallbackEntry[OnPeriodicDamage]{aura: aura, callback: aura.OnPeriodicHealTaken})
 	}
 
 	if aura.OnRageChange != nil {
 		aura.onRageChangeIndex = int32(len(aura.Unit.onRageChangeAuras))
-		aura.Unit.onRageChangeAuras = append(aura.Unit.onRageChangeAuras, aura)
+		aura.Unit.onRageChangeAuras = append(aura.Unit.onRageChangeAuras, auraCallbackEntry[OnRageChange]{aura: aura, callback: aura.OnRageChange})
 	}
 
 	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
@@ -684,7 +705,7 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		removeOnCastCompleteIndex := aura.onCastCompleteIndex
 		aura.Unit.onCastCompleteAuras = removeBySwappingToBack(aura.Unit.onCastCompleteAuras, removeOnCastCompleteIndex)
 		if removeOnCastCompleteIndex < int32(len(aura.Unit.onCastCompleteAuras)) {
-			aura.Unit.onCastCompleteAuras[removeOnCastCompleteIndex].onCastCompleteIndex = removeOnCastCompleteIndex
+			aura.Unit.onCastCompleteAuras[removeOnCastCompleteIndex].aura.onCastCompleteIndex = removeOnCastCompleteIndex
 		}
 		aura.onCastCompleteIndex = Inactive
 	}
@@ -693,7 +714,7 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		removeOnSpellHitDealtIndex := aura.onSpellHitDealtIndex
 		aura.Unit.onSpellHitDealtAuras = removeBySwappingToBack(aura.Unit.onSpellHitDealtAuras, removeOnSpellHitDealtIndex)
 		if removeOnSpellHitDealtIndex < int32(len(aura.Unit.onSpellHitDealtAuras)) {
-			aura.Unit.onSpellHitDealtAuras[removeOnSpellHitDealtIndex].onSpellHitDealtIndex = removeOnSpellHitDealtIndex
+			aura.Unit.onSpellHitDealtAuras[removeOnSpellHitDealtIndex].aura.onSpellHitDealtIndex = removeOnSpellHitDealtIndex
 		}
 		aura.onSpellHitDealtIndex = Inactive
 	}
@@ -702,7 +723,7 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		removeOnSpellHitTakenIndex := aura.onSpellHitTakenIndex
 		aura.Unit.onSpellHitTakenAuras = removeBySwappingToBack(aura.Unit.onSpellHitTakenAuras, removeOnSpellHitTakenIndex)
 		if removeOnSpellHitTakenIndex < int32(len(aura.Unit.onSpellHitTakenAuras)) {
-			aura.Unit.onSpellHitTakenAuras[removeOnSpellHitTakenIndex].onSpellHitTakenIndex = removeOnSpellHitTakenIndex
+			aura.Unit.onSpellHitTakenAuras[removeOnSpellHitTakenIndex].aura.onSpellHitTakenIndex = removeOnSpellHitTakenIndex
 		}
 		aura.onSpellHitTakenIndex = Inactive
 	}
@@ -711,7 +732,7 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		removeOnPeriodicDamageDealt := aura.onPeriodicDamageDealtIndex
 		aura.Unit.onPeriodicDamageDealtAuras = removeBySwappingToBack(aura.Unit.onPeriodicDamageDealtAuras, removeOnPeriodicDamageDealt)
 		if removeOnPeriodicDamageDealt < int32(len(aura.Unit.onPeriodicDamageDealtAuras)) {
-			aura.Unit.onPeriodicDamageDealtAuras[removeOnPeriodicDamageDealt].onPeriodicDamageDealtIndex = removeOnPeriodicDamageDealt
+			aura.Unit.onPeriodicDamageDealtAuras[removeOnPeriodicDamageDealt].aura.onPeriodicDamageDealtIndex = removeOnPeriodicDamageDealt
 		}
 		aura.onPeriodicDamageDealtIndex = Inactive
 	}
@@ -720,7 +741,7 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		removeOnPeriodicDamageTaken := aura.onPeriodicDamageTakenIndex
 		aura.Unit.onPeriodicDamageTakenAuras = removeBySwappingToBack(aura.Unit.onPeriodicDamageTakenAuras, removeOnPeriodicDamageTaken)
 		if removeOnPeriodicDamageTaken < int32(len(aura.Unit.onPeriodicDamageTakenAuras)) {
-			aura.Unit.onPeriodicDamageTakenAuras[removeOnPeriodicDamageTaken].onPeriodicDamageTakenIndex = removeOnPeriodicDamageTaken
+			aura.Unit.onPeriodicDamageTakenAuras[removeOnPeriodicDamageTaken].aura.onPeriodicDamageTakenIndex = removeOnPeriodicDamageTaken
 		}
 		aura.onPeriodicDamageTakenIndex = Inactive
 	}
@@ -729,7 +750,7 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		removeOnHealDealtIndex := aura.onHealDealtIndex
 		aura.Unit.onHealDealtAuras = removeBySwappingToBack(aura.Unit.onHealDealtAuras, removeOnHealDealtIndex)
 		if removeOnHealDealtIndex < int32(len(aura.Unit.onHealDealtAuras)) {
-			aura.Unit.onHealDealtAuras[removeOnHealDealtIndex].onHealDealtIndex = removeOnHealDealtIndex
+			aura.Unit.onHealDealtAuras[removeOnHealDealtIndex].aura.onHealDealtIndex = removeOnHealDealtIndex
 		}
 		aura.onHealDealtIndex = Inactive
 	}
@@ -738,7 +759,7 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		removeOnHealTakenIndex := aura.onHealTakenIndex
 		aura.Unit.onHealTakenAuras = removeBySwappingToBack(aura.Unit.onHealTakenAuras, removeOnHealTakenIndex)
 		if removeOnHealTakenIndex < int32(len(aura.Unit.onHealTakenAuras)) {
-			aura.Unit.onHealTakenAuras[removeOnHealTakenIndex].onHealTakenIndex = removeOnHealTakenIndex
+			aura.Unit.onHealTakenAuras[removeOnHealTakenIndex].aura.onHealTakenIndex = removeOnHealTakenIndex
 		}
 		aura.onHealTakenIndex = Inactive
 	}
@@ -747,7 +768,7 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		removeOnPeriodicHealDealt := aura.onPeriodicHealDealtIndex
 		aura.Unit.onPeriodicHealDealtAuras = removeBySwappingToBack(aura.Unit.onPeriodicHealDealtAuras, removeOnPeriodicHealDealt)
 		if removeOnPeriodicHealDealt < int32(len(aura.Unit.onPeriodicHealDealtAuras)) {
-			aura.Unit.onPeriodicHealDealtAuras[removeOnPeriodicHealDealt].onPeriodicHealDealtIndex = removeOnPeriodicHealDealt
+			aura.Unit.onPeriodicHealDealtAuras[removeOnPeriodicHealDealt].aura.onPeriodicHealDealtIndex = removeOnPeriodicHealDealt
 		}
 		aura.onPeriodicHealDealtIndex = Inactive
 	}
@@ -756,7 +777,7 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		removeOnPeriodicHealTaken := aura.onPeriodicHealTakenIndex
 		aura.Unit.onPeriodicHealTakenAuras = removeBySwappingToBack(aura.Unit.onPeriodicHealTakenAuras, removeOnPeriodicHealTaken)
 		if removeOnPeriodicHealTaken < int32(len(aura.Unit.onPeriodicHealTakenAuras)) {
-			aura.Unit.onPeriodicHealTakenAuras[removeOnPeriodicHealTaken].onPeriodicHealTakenIndex = removeOnPeriodicHealTaken
+			aura.Unit.onPeriodicHealTakenAuras[removeOnPeriodicHealTaken].aura.onPeriodicHealTakenIndex = removeOnPeriodicHealTaken
 		}
 		aura.onPeriodicHealTakenIndex = Inactive
 	}
@@ -765,7 +786,7 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		removeOnRageChangeIndex := aura.onRageChangeIndex
 		aura.Unit.onRageChangeAuras = removeBySwappingToBack(aura.Unit.onRageChangeAuras, removeOnRageChangeIndex)
 		if removeOnRageChangeIndex < int32(len(aura.Unit.onRageChangeAuras)) {
-			aura.Unit.onRageChangeAuras[removeOnRageChangeIndex].onRageChangeIndex = removeOnRageChangeIndex
+			aura.Unit.onRageChangeAuras[removeOnRageChangeIndex].aura.onRageChangeIndex = removeOnRageChangeIndex
 		}
 		aura.onRageChangeIndex = Inactive
 	}
@@ -793,28 +814,28 @@ func removeBySwappingToBack[T any, U constraints.Integer](arr []T, removeIdx U)
 
 // Invokes the OnCastComplete event for all tracked Auras.
 func (at *auraTracker) OnCastComplete(sim *Simulation, spell *Spell) {
-	for _, aura := range at.onCastCompleteAuras {
-		aura.OnCastComplete(aura, sim, spell)
+	for _, entry := range at.onCastCompleteAuras {
+		entry.callback(entry.aura, sim, spell)
 	}
 }
 
 // Invokes the OnSpellHit event for all tracked Auras.
 func (at *auraTracker) OnSpellHitDealt(sim *Simulation, spell *Spell, result *SpellResult) {
-	for _, aura := range at.onSpellHitDealtAuras {
+	for _, entry := range at.onSpellHitDealtAuras {
 		// this check is to handle a case where auras are deactivated during iteration.
-		if !aura.active {
+		if !entry.aura.active {
 			continue
 		}
-		aura.OnSpellHitDealt(aura, sim, spell, result)
+		entry.callback(entry.aura, sim, spell, result)
 	}
 }
 func (at *auraTracker) OnSpellHitTaken(sim *Simulation, spell *Spell, result *SpellResult) {
-	for _, aura := range at.onSpellHitTakenAuras {
+	for _, entry := range at.onSpellHitTakenAuras {
 		// this check is to handle a case where auras are deactivated during iteration.
-		if !aura.active {
+		if !entry.aura.active {
 			continue
 		}
-		aura.OnSpellHitTaken(aura, sim, spell, result)
+		entry.callback(entry.aura, sim, spell, result)
 	}
 }
 
@@ -823,33 +844,33 @@ func (at *auraTracker) OnSpellHitTaken(sim *Simulation, spell *Spell, result *Sp
 //	As a debuff when target is being hit by dot.
 //	As a buff when caster's dots are ticking.
 func (at *auraTracker) OnPeriodicDamageDealt(sim *Simulation, spell *Spell, result *SpellResult) {
-	for _, aura := range at.onPeriodicDamageDealtAuras {
-		aura.OnPeriodicDamageDealt(aura, sim, spell, result)
+	for _, entry := range at.onPeriodicDamageDealtAuras {
+		entry.callback(entry.aura, sim, spell, result)
 	}
 }
 func (at *auraTracker) OnPeriodicDamageTaken(sim *Simulation, spell *Spell, result *SpellResult) {
-	for _, aura := range at.onPeriodicDamageTakenAuras {
-		aura.OnPeriodicDamageTaken(aura, sim, spell, result)
+	for _, entry := range at.onPeriodicDamageTakenAuras {
+		entry.callback(entry.aura, sim, spell, result)
 	}
 }
 
 // Invokes the OnHeal event for all tracked Auras.
 func (at *auraTracker) OnHealDealt(sim *Simulation, spell *Spell, result *SpellResult) {
-	for _, aura := range at.onHealDealtAuras {
+	for _, entry := range at.onHealDealtAuras {
 		// this check is to handle a case where auras are deactivated during iteration.
-		if !aura.active {
+		if !entry.aura.active {
 			continue
 		}
-		aura.OnHealDealt(aura, sim, spell, result)
+		entry.callback(entry.aura, sim, spell, result)
 	}
 }
 func (at *auraTracker) OnHealTaken(sim *Simulation, spell *Spell, result *SpellResult) {
-	for _, aura := range at.onHealTakenAuras {
+	for _, entry := range at.onHealTakenAuras {
 		// this check is to handle a case where auras are deactivated during iteration.
-		if !aura.active {
+		if !entry.aura.active {
 			continue
 		}
-		aura.OnHealTaken(aura, sim, spell, result)
+		entry.callback(entry.aura, sim, spell, result)
 	}
 }
 
@@ -858,13 +879,13 @@ func (at *auraTracker) OnHealTaken(sim *Simulation, spell *Spell, result *SpellR
 //	As a debuff when target is being hit by dot.
 //	As a buff when caster's dots are ticking.
 func (at *auraTracker) OnPeriodicHealDealt(sim *Simulation, spell *Spell, result *SpellResult) {
-	for _, aura := range at.onPeriodicHealDealtAuras {
-		aura.OnPeriodicHealDealt(aura, sim, spell, result)
+	for _, entry := range at.onPeriodicHealDealtAuras {
+		entry.callback(entry.aura, sim, spell, result)
 	}
 }
 func (at *auraTracker) OnPeriodicHealTaken(sim *Simulation, spell *Spell, result *SpellResult) {
-	for _, aura := range at.onPeriodicHealTakenAuras {
-		aura.OnPeriodicHealTaken(aura, sim, spell, result)
+	for _, entry := range at.onPeriodicHealTakenAuras {
+		entry.callback(entry.aura, sim, spell, result)
 	}
 }
 
@@ -876,14 +897,19 @@ func (at *auraTracker) GetMetricsProto() []*proto.AuraMetrics {
 			metrics = append(metrics, aura.metrics.ToProto())
 		}
 	}
+	for _, aura := range at.workerAuras {
+		if !aura.metrics.ID.IsEmptyAction() {
+			metrics = append(metrics, aura.metrics.ToProto())
+		}
+	}
 
 	return metrics
 }
 
 // Invokes the OnRageChange for all tracked auras
 func (at *auraTracker) OnRageChange(sim *Simulation, metrics *ResourceMetrics) {
-	for _, aura := range at.onRageChangeAuras {
-		aura.OnRageChange(aura, sim, metrics)
+	for _, entry := range at.onRageChangeAuras {
+		entry.callback(entry.aura, sim, metrics)
 	}
 }
 
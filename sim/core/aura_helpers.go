@@ -58,6 +58,11 @@ func ApplyProcTriggerCallback(unit *Unit, aura *Aura, config ProcTrigger) {
 		ppmm = unit.AutoAttacks.NewPPMManager(config.PPM, config.ProcMask)
 	}
 
+	// Rolls on every matching hit, so their Rands are kept instead of being
+	// looked up by label each time.
+	procChanceRand := &labelRandCache{label: config.Name}
+	ppmRand := &labelRandCache{label: config.Name}
+
 	handler := config.Handler
 	callback := func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
 		if config.SpellFlags != SpellFlagNone && !spell.Flags.Matches(config.SpellFlags) {
@@ -78,10 +83,12 @@ func ApplyProcTriggerCallback(unit *Unit, aura *Aura, config ProcTrigger) {
 		if icd.Duration != 0 && !icd.IsReady(sim) {
 			return
 		}
-		if config.ProcChance != 1 && sim.RandomFloat(config.Name) > config.ProcChance {
-			return
-		} else if config.PPM != 0 && !ppmm.ProcWithWeaponSpecials(sim, spell.ProcMask, config.Name) {
+		if config.ProcChance != 1 && procChanceRand.get(sim, aura.Unit).NextFloat64() > config.ProcChance {
 			return
+		} else if config.PPM != 0 {
+			if chance, ok := ppmm.chanceWithWeaponSpecials(spell.ProcMask); !ok || ppmRand.get(sim, ppmm.unit).NextFloat64() >= chance {
+				return
+			}
 		}
 
 		if icd.Duration != 0 {
@@ -123,7 +130,7 @@ func ApplyProcTriggerCallback(unit *Unit, aura *Aura, config ProcTrigger) {
 			if icd.Duration != 0 && !icd.IsReady(sim) {
 				return
 			}
-			if config.ProcChance != 1 && sim.RandomFloat(config.Name) > config.ProcChance {
+			if config.ProcChance != 1 && procChanceRand.get(sim, aura.Unit).NextFloat64() > config.ProcChance {
 				return
 			}
 
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Activates numAuras long auras on the fake caster, each with an
+// OnSpellHitDealt callback counting the landed hits, and returns its bolt.
+func activateBenchmarkAuras(sim *Simulation, numAuras int, hits *int) *Spell {
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	for i := 0; i < numAuras; i++ {
+		label := "Benchmark Aura " + strconv.Itoa(i)
+		aura := character.GetAura(label)
+		if aura == nil {
+			aura = character.RegisterAura(Aura{
+				Label:    label,
+				Duration: time.Hour * 1000,
+				OnSpellHitDealt: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+					if result.Landed() {
+						*hits++
+					}
+				},
+			})
+			aura.init(sim)
+		}
+		aura.Activate(sim)
+	}
+	return character.GetSpell(ActionID{SpellID: 43})
+}
+
+func BenchmarkOnSpellHitDealt(b *testing.B) {
+	sim := newBenchmarkSim()
+	sim.reset()
+	var hits int
+	spell := activateBenchmarkAuras(sim, 8, &hits)
+	target := &sim.Encounter.Targets[0].Unit
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := spell.NewResult(target)
+		result.Outcome = OutcomeHit
+		spell.Unit.OnSpellHitDealt(sim, spell, result)
+		spell.DisposeResult(result)
+	}
+	if hits != 8*b.N {
+		b.Fatalf("Expected %d hits, got %d", 8*b.N, hits)
+	}
+}
+
+func BenchmarkNewResult(b *testing.B) {
+	sim := newBenchmarkSim()
+	spell := sim.Raid.Parties[0].Players[0].GetCharacter().GetSpell(ActionID{SpellID: 43})
+	target := &sim.Encounter.Targets[0].Unit
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		spell.DisposeResult(spell.NewResult(target))
+	}
+}
+
+func BenchmarkAuraTrackerAdvance(b *testing.B) {
+	sim := newBenchmarkSim()
+	sim.reset()
+	var hits int
+	activateBenchmarkAuras(sim, 8, &hits)
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sim.CurrentTime += time.Millisecond
+		character.auraTracker.advance(sim)
+	}
+}
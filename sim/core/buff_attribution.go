@@ -0,0 +1,221 @@
+package core
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Marks every aura matching one of the given IDs as disabled, so it is never
+// activated. Auras registered later, like debuffs and pet auras created on
+// demand, are disabled as they're registered.
+func (env *Environment) disableAuras(auraIDs []*proto.ActionID) {
+	if len(auraIDs) == 0 && len(env.disabledAuras) == 0 {
+		return
+	}
+	env.disabledAuras = make([]ActionID, len(auraIDs))
+	for i, auraID := range auraIDs {
+		env.disabledAuras[i] = ProtoToActionID(auraID)
+	}
+
+	for _, unit := range env.AllUnits {
+		for _, aura := range unit.auras {
+			aura.disabled = env.isAuraDisabled(aura.ActionID)
+		}
+	}
+}
+
+func (env *Environment) isAuraDisabled(actionID ActionID) bool {
+	return slices.ContainsFunc(env.disabledAuras, actionID.SameAction)
+}
+
+// A buff measured by buff attribution, and how to remove it from a request.
+type buffRemoval struct {
+	value  *proto.BuffValue
+	remove func(request *proto.RaidSimRequest)
+}
+
+// Measures the value of each buff by removing it and comparing raid DPS against
+// a baseline. Like stat weights, every sim uses the same seed with per-label RNG
+// streams, so the differences between them are mostly due to the buff.
+func CalcBuffAttribution(request *proto.BuffAttributionRequest, progress chan *proto.ProgressMetrics) *proto.BuffAttributionResult {
+	baseSimRequest := googleProto.Clone(request.RaidSimRequest).(*proto.RaidSimRequest)
+	simOptions := baseSimRequest.SimOptions
+	simOptions.SaveAllValues = true
+	simOptions.RngBackend = proto.RngBackend_RngBackendCounter
+	simOptions.DisabledAuras = nil
+
+	// Make sure an RNG seed is always set, so all the sims line up.
+	if simOptions.RandomSeed == 0 {
+		simOptions.RandomSeed = time.Now().UnixNano()
+	}
+
+	baselineResult := RunRaidSim(baseSimRequest)
+	if baselineResult.ErrorResult != "" {
+		return &proto.BuffAttributionResult{
+			ErrorResult: baselineResult.ErrorResult,
+		}
+	}
+
+	// The baseline may have stopped early, and the other sims need to run the same
+	// iterations to line up with it.
+	simOptions.Iterations = baselineResult.Iterations
+	simOptions.TargetDpsStderr = 0
+
+	auraIDs := request.AuraIds
+	buffFields := request.BuffFields
+	if len(auraIDs) == 0 && len(buffFields) == 0 {
+		auraIDs = activeAuraIDs(baselineResult.RaidMetrics)
+		buffFields = setBuffFields(baseSimRequest.Raid)
+	}
+
+	var removals []buffRemoval
+	for _, buffField := range buffFields {
+		buffField := buffField
+		removals = append(removals, buffRemoval{
+			value: &proto.BuffValue{BuffField: buffField},
+			remove: func(request *proto.RaidSimRequest) {
+				clearBuffField(request.Raid, buffField)
+			},
+		})
+	}
+	for _, auraID := range auraIDs {
+		auraID := auraID
+		removals = append(removals, buffRemoval{
+			value: &proto.BuffValue{Id: auraID},
+			remove: func(request *proto.RaidSimRequest) {
+				request.SimOptions.DisabledAuras = []*proto.ActionID{auraID}
+			},
+		})
+	}
+
+	results := make([]*proto.RaidSimResult, len(removals))
+	var simsCompleted int32
+
+	var waitGroup sync.WaitGroup
+	tickets := make(chan struct{}, max(1, runtime.NumCPU()-1))
+	for i, removal := range removals {
+		waitGroup.Add(1)
+		go func(i int, removal buffRemoval) {
+			defer waitGroup.Done()
+			tickets <- struct{}{}
+			defer func() { <-tickets }()
+
+			simRequest := googleProto.Clone(baseSimRequest).(*proto.RaidSimRequest)
+			removal.remove(simRequest)
+			results[i] = RunRaidSim(simRequest)
+
+			completed := atomic.AddInt32(&simsCompleted, 1)
+			if progress != nil {
+				progress <- &proto.ProgressMetrics{
+					TotalIterations:     simOptions.Iterations * int32(len(removals)),
+					CompletedIterations: simOptions.Iterations * completed,
+					TotalSims:           int32(len(removals)),
+					CompletedSims:       completed,
+				}
+			}
+		}(i, removal)
+	}
+	waitGroup.Wait()
+
+	result := &proto.BuffAttributionResult{
+		BaselineDps: baselineResult.RaidMetrics.Dps.Avg,
+	}
+	baselineDps := baselineResult.RaidMetrics.Dps.AllValues
+	for i, simResult := range results {
+		if simResult.ErrorResult != "" {
+			return &proto.BuffAttributionResult{
+				ErrorResult: simResult.ErrorResult,
+			}
+		}
+
+		var loss aggregator
+		for iteration, dps := range simResult.RaidMetrics.Dps.AllValues {
+			loss.add(baselineDps[iteration] - dps)
+		}
+		value := removals[i].value
+		value.DpsLoss, value.DpsLossStdev = loss.meanAndStdDev()
+		result.Buffs = append(result.Buffs, value)
+	}
+
+	slices.SortStableFunc(result.Buffs, func(a, b *proto.BuffValue) int {
+		if a.DpsLoss > b.DpsLoss {
+			return -1
+		} else if a.DpsLoss < b.DpsLoss {
+			return 1
+		}
+		return 0
+	})
+
+	return result
+}
+
+// Returns the IDs of all auras which were active on a player or pet.
+func activeAuraIDs(raidMetrics *proto.RaidMetrics) []*proto.ActionID {
+	var auraIDs []*proto.ActionID
+	seen := make(map[ActionID]bool)
+
+	var addUnit func(unitMetrics *proto.UnitMetrics)
+	addUnit = func(unitMetrics *proto.UnitMetrics) {
+		for _, auraMetrics := range unitMetrics.Auras {
+			auraID := ProtoToActionID(auraMetrics.Id)
+			if auraMetrics.UptimeSecondsAvg == 0 || auraID.IsEmptyAction() || seen[auraID] {
+				continue
+			}
+			seen[auraID] = true
+			auraIDs = append(auraIDs, auraMetrics.Id)
+		}
+		for _, petMetrics := range unitMetrics.Pets {
+			addUnit(petMetrics)
+		}
+	}
+
+	for _, party := range raidMetrics.Parties {
+		for _, player := range party.Players {
+			addUnit(player)
+		}
+	}
+	return auraIDs
+}
+
+// Returns all the buff and consumable messages in the raid.
+func buffMessages(raid *proto.Raid) []protoreflect.Message {
+	messages := []protoreflect.Message{raid.GetBuffs().ProtoReflect(), raid.GetDebuffs().ProtoReflect()}
+	for _, party := range raid.Parties {
+		messages = append(messages, party.GetBuffs().ProtoReflect())
+		for _, player := range party.Players {
+			messages = append(messages, player.GetBuffs().ProtoReflect(), player.GetConsumes().ProtoReflect())
+		}
+	}
+	return slices.DeleteFunc(messages, func(message protoreflect.Message) bool {
+		return !message.IsValid()
+	})
+}
+
+// Returns the names of all buff fields which are set anywhere in the raid.
+func setBuffFields(raid *proto.Raid) []string {
+	var buffFields []string
+	for _, message := range buffMessages(raid) {
+		message.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if name := string(fd.Name()); !slices.Contains(buffFields, name) {
+				buffFields = append(buffFields, name)
+			}
+			return true
+		})
+	}
+	return buffFields
+}
+
+func clearBuffField(raid *proto.Raid, buffField string) {
+	for _, message := range buffMessages(raid) {
+		if fd := message.Descriptor().Fields().ByName(protoreflect.Name(buffField)); fd != nil {
+			message.Clear(fd)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestDisabledAuras(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	sim.Environment.disableAuras([]*proto.ActionID{{RawId: &proto.ActionID_SpellId{SpellId: 42}}})
+	fa.Dot.Apply(sim)
+	if fa.Dot.IsActive() {
+		t.Fatalf("Disabled aura was activated")
+	}
+
+	sim.Environment.disableAuras([]*proto.ActionID{{RawId: &proto.ActionID_SpellId{SpellId: 43}}})
+	fa.Dot.Apply(sim)
+	if !fa.Dot.IsActive() {
+		t.Fatalf("Aura with a different ID was not activated")
+	}
+
+	// Auras registered after the sim is built, like on-demand debuffs, are disabled too.
+	lateAura := sim.Encounter.Targets[0].RegisterAura(Aura{
+		Label:    "Late Aura",
+		ActionID: ActionID{SpellID: 43},
+		Duration: NeverExpires,
+	})
+	lateAura.Activate(sim)
+	if lateAura.IsActive() {
+		t.Fatalf("Disabled aura registered after the sim was built was activated")
+	}
+}
+
+func TestBuffAttribution(t *testing.T) {
+	rsr := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 50, RandomSeed: 100})
+	rsr.Raid.Parties[0].Players[0].Level = 60
+	rsr.Raid.Debuffs = &proto.Debuffs{CurseOfShadow: true}
+
+	result := CalcBuffAttribution(&proto.BuffAttributionRequest{
+		RaidSimRequest: rsr,
+		AuraIds:        []*proto.ActionID{ActionID{SpellID: 42}.ToProto()},
+		BuffFields:     []string{"curse_of_shadow"},
+	}, nil)
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+	if result.BaselineDps <= 0 || len(result.Buffs) != 2 {
+		t.Fatalf("Expected 2 buffs with a damaging baseline, got %d with %f DPS", len(result.Buffs), result.BaselineDps)
+	}
+
+	for _, buff := range result.Buffs {
+		// Curse of Shadow increases the bolt's shadow damage, and the caster
+		// never gets the DoT's damage with it disabled.
+		if buff.DpsLoss <= 0 {
+			t.Fatalf("Expected %s to have positive value, got %f", buff, buff.DpsLoss)
+		}
+	}
+}
+
+func TestClearBuffFields(t *testing.T) {
+	raid := SinglePlayerRaidProto(&proto.Player{
+		Buffs:    &proto.IndividualBuffs{BlessingOfKings: true},
+		Consumes: &proto.Consumes{Flask: proto.Flask_FlaskOfSupremePower},
+	}, &proto.PartyBuffs{ManaTideTotems: 1}, &proto.RaidBuffs{ArcaneBrilliance: true}, &proto.Debuffs{CurseOfShadow: true})
+
+	buffFields := setBuffFields(raid)
+	slices.Sort(buffFields)
+	expected := []string{"arcane_brilliance", "blessing_of_kings", "curse_of_shadow", "flask", "mana_tide_totems"}
+	if !slices.Equal(buffFields, expected) {
+		t.Fatalf("Expected buff fields %v, got %v", expected, buffFields)
+	}
+
+	for _, buffField := range buffFields {
+		clearBuffField(raid, buffField)
+	}
+	if buffFields := setBuffFields(raid); len(buffFields) != 0 {
+		t.Fatalf("Expected all buff fields to be cleared, got %v", buffFields)
+	}
+}
@@ -88,12 +88,11 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 	if playerCount != 1 || player == nil {
 		return nil, fmt.Errorf("bulksim: expected exactly 1 player, found %d", playerCount)
 	}
-	if player.GetDatabase() != nil {
-		addToDatabase(player.GetDatabase())
-	}
+	simDatabase := player.GetDatabase()
+	database := NewDatabase(simDatabase)
 	// reduce to just base party.
 	b.Request.BaseSettings.Raid.Parties = []*proto.Party{b.Request.BaseSettings.Raid.Parties[0]}
-	// clean to reduce memory
+	// clean to reduce memory, the requests for each combo share simDatabase instead of copying it.
 	player.Database = nil
 
 	// Gemming for now can happen before slots are decided.
@@ -116,7 +115,7 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 	// We verify later that we are not emitting any invalid equipment set.
 	var distinctItemSlotCombos []*itemWithSlot
 	for index, is := range items {
-		item, ok := ItemsByID[is.Id]
+		item, ok := database.LookupItem(is.Id)
 		if !ok {
 			return nil, fmt.Errorf("unknown item with id %d in bulk settings", is.Id)
 		}
@@ -139,8 +138,9 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 		if count > 1000000 {
 			panic("over 1 million combos, abandoning attempt")
 		}
-		substitutedRequest, changeLog := createNewRequestWithSubstitution(b.Request.BaseSettings, sub, b.Request.BulkSettings.AutoEnchant)
-		if isValidEquipment(substitutedRequest.Raid.Parties[0].Players[0].Equipment) {
+		substitutedRequest, changeLog := createNewRequestWithSubstitution(database, b.Request.BaseSettings, sub, b.Request.BulkSettings.AutoEnchant)
+		if isValidEquipment(database, substitutedRequest.Raid.Parties[0].Players[0].Equipment) {
+			substitutedRequest.Raid.Parties[0].Players[0].Database = simDatabase
 			validCombos = append(validCombos, singleBulkSim{req: substitutedRequest, cl: changeLog, eq: sub})
 		}
 	}
@@ -413,14 +413,14 @@ func (es *equipmentSubstitution) CanonicalHash() string {
 
 // isValidEquipment returns true if the specified equipment spec is valid. An equipment spec
 // is valid if it does not reference a two-hander and off-hand weapon combo.
-func isValidEquipment(equipment *proto.EquipmentSpec) bool {
+func isValidEquipment(database *Database, equipment *proto.EquipmentSpec) bool {
 	var usesTwoHander, usesOffhand bool
 
 	// Validate weapons
-	if knownItem, ok := ItemsByID[equipment.Items[proto.ItemSlot_ItemSlotMainHand].Id]; ok {
+	if knownItem, ok := database.LookupItem(equipment.Items[proto.ItemSlot_ItemSlotMainHand].Id); ok {
 		usesTwoHander = knownItem.HandType == proto.HandType_HandTypeTwoHand
 	}
-	if knownItem, ok := ItemsByID[equipment.Items[proto.ItemSlot_ItemSlotOffHand].Id]; ok {
+	if knownItem, ok := database.LookupItem(equipment.Items[proto.ItemSlot_ItemSlotOffHand].Id); ok {
 		usesOffhand = knownItem.HandType == proto.HandType_HandTypeOffHand
 	}
 	if usesTwoHander && usesOffhand {
@@ -435,14 +435,14 @@ func isValidEquipment(equipment *proto.EquipmentSpec) bool {
 	}
 
 	// Validate rings/trinkets for heroic/non-heroic (matching name)
-	f1, ok1 := ItemsByID[equipment.Items[proto.ItemSlot_ItemSlotFinger1].Id]
-	f2, ok2 := ItemsByID[equipment.Items[proto.ItemSlot_ItemSlotFinger2].Id]
+	f1, ok1 := database.LookupItem(equipment.Items[proto.ItemSlot_ItemSlotFinger1].Id)
+	f2, ok2 := database.LookupItem(equipment.Items[proto.ItemSlot_ItemSlotFinger2].Id)
 	if ok1 && ok2 && f1.Name == f2.Name {
 		return false
 	}
 
-	t1, ok1 := ItemsByID[equipment.Items[proto.ItemSlot_ItemSlotTrinket1].Id]
-	t2, ok2 := ItemsByID[equipment.Items[proto.ItemSlot_ItemSlotTrinket2].Id]
+	t1, ok1 := database.LookupItem(equipment.Items[proto.ItemSlot_ItemSlotTrinket1].Id)
+	t2, ok2 := database.LookupItem(equipment.Items[proto.ItemSlot_ItemSlotTrinket2].Id)
 	if ok1 && ok2 && t1.Name == t2.Name {
 		return false
 	}
@@ -581,33 +581,37 @@ type raidSimRequestChangeLog struct {
 }
 
 // createNewRequestWithSubstitution creates a copy of the input RaidSimRequest and applis the given
-// equipment susbstitution to the player's equipment. Copies enchant if specified and possible.
-func createNewRequestWithSubstitution(readonlyInputRequest *proto.RaidSimRequest, substitution *equipmentSubstitution, autoEnchant bool) (*proto.RaidSimRequest, *raidSimRequestChangeLog) {
+// equipment susbstitution to the player's equipment. Copies enchant if specified and possible, and
+// copies the rune if the new item can be engraved with it.
+func createNewRequestWithSubstitution(database *Database, readonlyInputRequest *proto.RaidSimRequest, substitution *equipmentSubstitution, autoEnchant bool) (*proto.RaidSimRequest, *raidSimRequestChangeLog) {
 	request := goproto.Clone(readonlyInputRequest).(*proto.RaidSimRequest)
 	changeLog := &raidSimRequestChangeLog{}
 	player := request.Raid.Parties[0].Players[0]
 	equipment := player.Equipment
 	for _, is := range substitution.Items {
 		oldItem := equipment.Items[is.Slot]
-		if autoEnchant && oldItem.Enchant > 0 && is.Item.Enchant == 0 {
-			equipment.Items[is.Slot] = goproto.Clone(is.Item).(*proto.ItemSpec)
-			equipment.Items[is.Slot].Enchant = oldItem.Enchant
+		newItem := is.Item
+		if autoEnchant && oldItem.Enchant > 0 && newItem.Enchant == 0 {
+			newItem = goproto.Clone(newItem).(*proto.ItemSpec)
+			newItem.Enchant = oldItem.Enchant
 			// TODO: logic to decide if the enchant can be applied to the new item...
 			// Specifically, offhand shouldn't get shield enchant
 			// Main/One hand shouldn't get staff enchant
 			// Later: replace normal enchant if replacement is staff.
-
-			changeLog.AddedItems = append(changeLog.AddedItems, &proto.ItemSpecWithSlot{
-				Item: equipment.Items[is.Slot],
-				Slot: is.Slot,
-			})
-		} else {
-			equipment.Items[is.Slot] = is.Item
-			changeLog.AddedItems = append(changeLog.AddedItems, &proto.ItemSpecWithSlot{
-				Item: is.Item,
-				Slot: is.Slot,
-			})
 		}
+		newItemData, _ := database.LookupItem(newItem.Id)
+		if oldItem.Rune != 0 && newItem.Rune == 0 && database.RuneAllowed(oldItem.Rune, newItemData.Type, player.Class) {
+			if newItem == is.Item {
+				newItem = goproto.Clone(newItem).(*proto.ItemSpec)
+			}
+			newItem.Rune = oldItem.Rune
+		}
+
+		equipment.Items[is.Slot] = newItem
+		changeLog.AddedItems = append(changeLog.AddedItems, &proto.ItemSpecWithSlot{
+			Item: newItem,
+			Slot: is.Slot,
+		})
 	}
 	return request, changeLog
 }
@@ -47,9 +47,7 @@ var (
 )
 
 func TestIsValidEquipment(t *testing.T) {
-	// This is a bit awkward because code everywhere accesses the global database maps. Hopefully
-	// this won't mess with any other unit tests that need existing item/gem/enchant databases?
-	addToDatabase(tinyItemDatabase)
+	database := NewDatabase(tinyItemDatabase)
 
 	for _, tc := range []struct {
 		comment string
@@ -67,7 +65,7 @@ func TestIsValidEquipment(t *testing.T) {
 			want:    false,
 		},
 	} {
-		if got := isValidEquipment(tc.spec); got != tc.want {
+		if got := isValidEquipment(database, tc.spec); got != tc.want {
 			t.Fatalf("%s: isValidEquipment(%v) = %v, want %v", tc.comment, tc.spec, got, tc.want)
 		}
 	}
@@ -380,3 +378,38 @@ func TestGenerateAllEquipmentSubstitutions(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateNewRequestWithSubstitutionCopiesRune(t *testing.T) {
+	const (
+		mainHandRune = 990201
+		offHandRune  = 990202
+	)
+	database := NewDatabase(&proto.SimDatabase{
+		Items: tinyItemDatabase.Items,
+		Runes: []*proto.SimRune{
+			{Id: mainHandRune, Type: proto.ItemType_ItemTypeWeapon},
+			{Id: offHandRune, Type: proto.ItemType_ItemTypeWeapon, ClassAllowlist: []proto.Class{proto.Class_ClassRogue}},
+		},
+	})
+
+	equipment := createEquipmentFromItems(
+		&itemWithSlot{Item: &proto.ItemSpec{Id: itemStarshardEdge, Rune: mainHandRune}, Slot: proto.ItemSlot_ItemSlotMainHand},
+		&itemWithSlot{Item: &proto.ItemSpec{Id: itemIronmender, Rune: offHandRune}, Slot: proto.ItemSlot_ItemSlotOffHand},
+	)
+	request := &proto.RaidSimRequest{
+		Raid: SinglePlayerRaidProto(&proto.Player{Class: proto.Class_ClassWarrior, Equipment: equipment}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+	}
+
+	newRequest, _ := createNewRequestWithSubstitution(database, request, &equipmentSubstitution{Items: []*itemWithSlot{
+		{Item: &proto.ItemSpec{Id: itemStarshardEdge}, Slot: proto.ItemSlot_ItemSlotMainHand},
+		{Item: &proto.ItemSpec{Id: itemIronmender}, Slot: proto.ItemSlot_ItemSlotOffHand},
+	}}, false)
+
+	items := newRequest.Raid.Parties[0].Players[0].Equipment.Items
+	if got := items[proto.ItemSlot_ItemSlotMainHand].Rune; got != mainHandRune {
+		t.Errorf("Expected the main hand rune to be copied, got %d", got)
+	}
+	if got := items[proto.ItemSlot_ItemSlotOffHand].Rune; got != 0 {
+		t.Errorf("Expected the off hand rune not to be copied for a warrior, got %d", got)
+	}
+}
@@ -209,6 +209,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			if !spell.Flags.Matches(SpellFlagChanneled) {
 				spell.SpellMetrics[target.UnitIndex].TotalCastTime += effectiveTime
 			}
+			if spell.CurCast.CastTime > 0 {
+				effectiveTime += spell.Unit.Skill.HardcastLatency
+			}
 			spell.Unit.SetGCDTimer(sim, sim.CurrentTime+effectiveTime)
 		}
 
@@ -43,6 +43,8 @@ type Character struct {
 
 	// Current gear.
 	Equipment
+	// Items, enchants and runes, including those sent with the request.
+	database *Database
 	//Item Swap Handler
 	ItemSwap ItemSwap
 
@@ -71,6 +73,7 @@ type Character struct {
 	professions [2]proto.Profession
 
 	runesMap          map[int32]bool
+	checkedRunes      map[int32]bool // Equipped runes which were checked for while the character was built.
 	PrimaryTalentTree uint8
 
 	// Provides major cooldown management behavior.
@@ -96,9 +99,7 @@ type Character struct {
 }
 
 func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character {
-	if player.Database != nil {
-		addToDatabase(player.Database)
-	}
+	database := NewDatabase(player.Database)
 
 	character := Character{
 		Unit: Unit{
@@ -113,8 +114,11 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 
 			ReactionTime:            max(0, time.Duration(player.ReactionTimeMs)*time.Millisecond),
 			ChannelClipDelay:        max(0, time.Duration(player.ChannelClipDelayMs)*time.Millisecond),
+			Skill:                   newPlayerSkill(player.Skill),
 			DistanceFromTarget:      player.DistanceFromTarget,
 			StartDistanceFromTarget: player.DistanceFromTarget,
+			StartPosition:           PositionFromProto(player.Position),
+			hasStartPosition:        player.Position != nil,
 		},
 
 		Name:  player.Name,
@@ -122,7 +126,8 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 		Class: player.Class,
 		Spec:  PlayerProtoToSpec(player),
 
-		Equipment: ProtoToEquipment(player.Equipment),
+		Equipment: database.ProtoToEquipment(player.Equipment),
+		database:  database,
 
 		professions: [2]proto.Profession{
 			player.Profession1,
@@ -140,8 +145,9 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 	character.Label = fmt.Sprintf("%s (#%d)", character.Name, character.Index+1)
 
 	character.runesMap = map[int32]bool{}
+	character.checkedRunes = map[int32]bool{}
 	for _, v := range character.Equipment {
-		if v.Rune != 0 {
+		if v.Rune != 0 && database.RuneUsableByClass(v.Rune, character.Class) {
 			character.runesMap[v.Rune] = true
 		}
 	}
@@ -253,7 +259,34 @@ func (character *Character) BaseEquipStats() stats.Stats {
 }
 
 func (character *Character) HasRuneById(id int32) bool {
-	return character.runesMap[id]
+	if !character.runesMap[id] {
+		return false
+	}
+	// Checks made once the sim runs, e.g. by APL values, don't mean the spec
+	// implements the rune.
+	if character.Env == nil || !character.Env.IsFinalized() {
+		character.checkedRunes[id] = true
+	}
+	return true
+}
+
+// Returns the equipped runes. Runes are checked for while the character is
+// built, so ones which were never checked for have no effect.
+func (character *Character) getEquippedRunes() []*proto.EquippedRune {
+	var runes []*proto.EquippedRune
+	for slot, item := range character.Equipment {
+		if item.Rune == 0 || !character.runesMap[item.Rune] {
+			continue
+		}
+		rune, _ := character.database.LookupRune(item.Rune)
+		runes = append(runes, &proto.EquippedRune{
+			Id:            item.Rune,
+			Name:          rune.Name,
+			Slot:          proto.ItemSlot(slot),
+			CheckedBySpec: character.checkedRunes[item.Rune],
+		})
+	}
+	return runes
 }
 
 func (character *Character) applyEquipment() {
@@ -364,7 +397,7 @@ func (character *Character) clearBuildPhaseAuras(phase CharacterBuildPhase) {
 // Apply effects from all equipped core.
 func (character *Character) applyItemEffects(agent Agent) {
 	for slot, eq := range character.Equipment {
-		if applyItemEffect, ok := itemEffects[eq.ID]; ok {
+		if applyItemEffect, ok := character.database.lookupItemEffect(eq.ID); ok {
 			applyItemEffect(agent)
 		}
 
@@ -391,6 +424,30 @@ func (character *Character) applyItemEffects(agent Agent) {
 	}
 }
 
+// Returns warnings for equipped items which are simmed as if they only had their
+// stats, because their effects or set bonuses aren't implemented.
+func (character *Character) getEquipmentWarnings() []string {
+	var warnings []string
+	unimplementedSetCounts := make(map[string]int32)
+	for _, item := range character.Equipment {
+		if item.ID == 0 {
+			continue
+		}
+
+		if character.database.HasUnimplementedItemEffect(item) {
+			warnings = append(warnings, fmt.Sprintf("%s has an effect which is not implemented, so only its stats are simmed.", item.Name))
+		}
+
+		if item.SetName != "" && !HasItemSet(item.SetID, item.SetName) {
+			unimplementedSetCounts[item.SetName]++
+			if unimplementedSetCounts[item.SetName] == 2 {
+				warnings = append(warnings, fmt.Sprintf("Set bonuses for %s are not implemented.", item.SetName))
+			}
+		}
+	}
+	return warnings
+}
+
 func (character *Character) AddPet(pet PetAgent) {
 	if character.Env != nil {
 		panic("Pets must be added during construction!")
@@ -473,12 +530,21 @@ func (character *Character) FillPlayerStats(playerStats *proto.PlayerStats) {
 	}
 	character.clearBuildPhaseAuras(CharacterBuildPhaseAll)
 	playerStats.Sets = character.GetActiveSetBonusNames()
+	playerStats.Warnings = character.getEquipmentWarnings()
+	playerStats.Runes = character.getEquippedRunes()
 
 	playerStats.Metadata = character.GetMetadata()
 	for _, pet := range character.Pets {
-		playerStats.Pets = append(playerStats.Pets, &proto.PetStats{
+		petStats := &proto.PetStats{
 			Metadata: pet.GetMetadata(),
-		})
+		}
+		if pet.usesAPLRotation {
+			petStats.RotationStats = pet.Rotation.getStats()
+		}
+		if pet.rotationWarning != "" {
+			playerStats.Warnings = append(playerStats.Warnings, pet.rotationWarning)
+		}
+		playerStats.Pets = append(playerStats.Pets, petStats)
 	}
 
 	if character.Rotation != nil {
@@ -638,6 +704,9 @@ func (character *Character) GetMetricsProto() *proto.UnitMetrics {
 	metrics.Name = character.Name
 	metrics.UnitIndex = character.UnitIndex
 	metrics.Auras = character.auraTracker.GetMetricsProto()
+	if character.Metrics.timeline != nil {
+		metrics.Timeline = character.Metrics.timeline.ToProto(&character.Unit)
+	}
 
 	metrics.Pets = make([]*proto.UnitMetrics, len(character.Pets))
 	for i, pet := range character.Pets {
@@ -0,0 +1,28 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestHasRuneByIdRecordsChecksUntilFinalized(t *testing.T) {
+	env := &Environment{State: Initialized}
+	character := &Character{
+		Unit:         Unit{Env: env},
+		runesMap:     map[int32]bool{1: true, 2: true},
+		checkedRunes: map[int32]bool{},
+	}
+
+	if !character.HasRuneById(1) || character.HasRuneById(3) {
+		t.Fatalf("Expected only equipped runes to be found")
+	}
+
+	// E.g. APL values may look for runes once the sim runs.
+	env.State = Finalized
+	if !character.HasRuneById(2) {
+		t.Fatalf("Expected rune 2 to be found after finalization")
+	}
+
+	if !character.checkedRunes[1] || character.checkedRunes[2] || character.checkedRunes[3] {
+		t.Fatalf("Expected only checks before finalization to be recorded, got %v", character.checkedRunes)
+	}
+}
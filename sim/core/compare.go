@@ -0,0 +1,141 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// z-score for a two-sided 95% confidence interval.
+const z95 = 1.959963984540054
+
+// Runs two sims with the same seed and per-label RNG streams, and compares their
+// raid DPS iteration by iteration. Pairing the iterations cancels out most of the
+// RNG, so much smaller differences can be resolved than by comparing averages.
+func CalcCompareSims(request *proto.CompareSimsRequest) *proto.CompareSimsResult {
+	if request.RequestA.GetSimOptions() == nil || request.RequestB.GetSimOptions() == nil {
+		return &proto.CompareSimsResult{
+			ErrorResult: "both requests need sim options",
+		}
+	}
+
+	requestA := googleProto.Clone(request.RequestA).(*proto.RaidSimRequest)
+	requestB := googleProto.Clone(request.RequestB).(*proto.RaidSimRequest)
+
+	if requestA.SimOptions.RandomSeed == 0 {
+		requestA.SimOptions.RandomSeed = time.Now().UnixNano()
+	}
+	for _, simRequest := range []*proto.RaidSimRequest{requestA, requestB} {
+		simRequest.SimOptions.SaveAllValues = true
+		simRequest.SimOptions.RngBackend = proto.RngBackend_RngBackendCounter
+	}
+	requestB.SimOptions.RandomSeed = requestA.SimOptions.RandomSeed
+	requestB.SimOptions.Iterations = requestA.SimOptions.Iterations
+	requestB.SimOptions.TargetDpsStderr = 0
+
+	var resultA, resultB *proto.RaidSimResult
+	if requestA.SimOptions.TargetDpsStderr > 0 {
+		// A may stop early, so B has to wait for it to know how many iterations to run.
+		resultA = RunRaidSim(requestA)
+		if resultA.ErrorResult == "" {
+			requestB.SimOptions.Iterations = resultA.Iterations
+			resultB = RunRaidSim(requestB)
+		}
+	} else {
+		var waitGroup sync.WaitGroup
+		waitGroup.Add(2)
+		go func() {
+			defer waitGroup.Done()
+			resultA = RunRaidSim(requestA)
+		}()
+		go func() {
+			defer waitGroup.Done()
+			resultB = RunRaidSim(requestB)
+		}()
+		waitGroup.Wait()
+	}
+
+	for _, simResult := range []*proto.RaidSimResult{resultA, resultB} {
+		if simResult != nil && simResult.ErrorResult != "" {
+			return &proto.CompareSimsResult{
+				ErrorResult: simResult.ErrorResult,
+			}
+		}
+	}
+
+	diff, err := newPairedDifference(resultA.RaidMetrics.Dps.AllValues, resultB.RaidMetrics.Dps.AllValues)
+	if err != nil {
+		return &proto.CompareSimsResult{
+			ErrorResult: err.Error(),
+		}
+	}
+
+	return &proto.CompareSimsResult{
+		ResultA:          resultA,
+		ResultB:          resultB,
+		MeanDifference:   diff.mean,
+		StderrDifference: diff.stderr,
+		Ci95Low:          diff.mean - z95*diff.stderr,
+		Ci95High:         diff.mean + z95*diff.stderr,
+		PValue:           diff.pValue(),
+		IterationsNeeded: diff.iterationsNeeded(request.TargetDifference),
+	}
+}
+
+// Statistics of the differences b[i] - a[i] between two paired samples.
+type pairedDifference struct {
+	n      int
+	mean   float64
+	stdev  float64 // Sample standard deviation of the differences.
+	stderr float64 // Standard error of the mean difference.
+}
+
+func newPairedDifference(a []float64, b []float64) (pairedDifference, error) {
+	if len(a) != len(b) {
+		return pairedDifference{}, errors.New("compared sims have a different number of iterations")
+	}
+	if len(a) < 2 {
+		return pairedDifference{}, errors.New("comparing sims requires at least 2 iterations")
+	}
+
+	diff := pairedDifference{n: len(a)}
+	for i := range a {
+		diff.mean += b[i] - a[i]
+	}
+	diff.mean /= float64(diff.n)
+
+	var sumSq float64
+	for i := range a {
+		d := b[i] - a[i] - diff.mean
+		sumSq += d * d
+	}
+	diff.stdev = math.Sqrt(sumSq / float64(diff.n-1))
+	diff.stderr = diff.stdev / math.Sqrt(float64(diff.n))
+	return diff, nil
+}
+
+// Two-sided p-value for the mean difference being 0, using a normal
+// approximation which is accurate for the iteration counts sims use.
+func (diff pairedDifference) pValue() float64 {
+	if diff.stderr == 0 {
+		if diff.mean == 0 {
+			return 1
+		}
+		return 0
+	}
+	z := math.Abs(diff.mean) / diff.stderr
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// Number of iterations needed for the half-width of the 95% confidence interval
+// to be at most target.
+func (diff pairedDifference) iterationsNeeded(target float64) int32 {
+	if target <= 0 {
+		return 0
+	}
+	return int32(math.Ceil(math.Pow(z95*diff.stdev/target, 2)))
+}
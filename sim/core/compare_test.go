@@ -0,0 +1,38 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPairedDifference(t *testing.T) {
+	a := []float64{100, 110, 90, 105, 95}
+	b := []float64{102, 111, 93, 106, 98}
+
+	diff, err := newPairedDifference(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Differences are 2, 1, 3, 1, 3.
+	if diff.mean != 2 {
+		t.Fatalf("Expected mean difference 2, got %f", diff.mean)
+	}
+	if expected := math.Sqrt(1.0 / 5); math.Abs(diff.stderr-expected) > 1e-9 {
+		t.Fatalf("Expected standard error %f, got %f", expected, diff.stderr)
+	}
+	if p := diff.pValue(); p > 1e-5 {
+		t.Fatalf("Expected a significant difference, got p-value %f", p)
+	}
+	if n := diff.iterationsNeeded(0.5); n != 16 {
+		t.Fatalf("Expected 16 iterations needed, got %d", n)
+	}
+
+	same, _ := newPairedDifference(a, a)
+	if p := same.pValue(); p != 1 {
+		t.Fatalf("Expected p-value 1 for identical samples, got %f", p)
+	}
+
+	if _, err := newPairedDifference(a, b[1:]); err == nil {
+		t.Fatalf("Expected an error for samples of different lengths")
+	}
+}
@@ -16,6 +16,11 @@ var rwMutex = sync.RWMutex{}
 var ItemsByID = map[int32]Item{}
 var RandomSuffixesByID = map[int32]RandomSuffix{}
 var EnchantsByEffectID = map[int32]Enchant{}
+var RunesByID = map[int32]Rune{}
+
+// Some effect IDs are shared by enchants for different slots, like bracer and
+// boots enchants with the same stats, so there can be several per ID.
+var enchantVariantsByEffectID = map[int32][]Enchant{}
 
 func addToDatabase(newDB *proto.SimDatabase) {
 	for _, v := range newDB.Items {
@@ -39,12 +44,148 @@ func addToDatabase(newDB *proto.SimDatabase) {
 		if _, ok := EnchantsByEffectID[v.EffectId]; !ok {
 			EnchantsByEffectID[v.EffectId] = EnchantFromProto(v)
 		}
+		if !slices.ContainsFunc(enchantVariantsByEffectID[v.EffectId], func(enchant Enchant) bool { return enchant.Type == v.Type && enchant.EnchantType == v.EnchantType }) {
+			enchantVariantsByEffectID[v.EffectId] = append(enchantVariantsByEffectID[v.EffectId], EnchantFromProto(v))
+		}
 		rwMutex.Unlock()
 	}
 
 	for _, v := range newDB.Runes {
-		fmt.Println(RuneFromProto(v))
+		rwMutex.Lock()
+		if _, ok := RunesByID[v.Id]; !ok {
+			RunesByID[v.Id] = RuneFromProto(v)
+		}
+		rwMutex.Unlock()
+	}
+}
+
+// The items, enchants, random suffixes and runes a sim looks up: the database
+// loaded at startup, overridden by the entries sent with a request. Each
+// character has its own, so requests can define unreleased items or change
+// existing ones without affecting other requests.
+type Database struct {
+	items           map[int32]Item
+	randomSuffixes  map[int32]RandomSuffix
+	enchants        map[int32]Enchant
+	enchantVariants map[int32][]Enchant
+	runes           map[int32]Rune
+	itemEffects     map[int32]ApplyEffect
+}
+
+// Creates a Database with the entries of simDB, which may be nil.
+func NewDatabase(simDB *proto.SimDatabase) *Database {
+	db := &Database{}
+	if simDB == nil {
+		return db
+	}
+
+	db.items = make(map[int32]Item, len(simDB.Items))
+	for _, v := range simDB.Items {
+		item := ItemFromProto(v)
+		if len(v.Procs) > 0 && procEffectFactory != nil {
+			if db.itemEffects == nil {
+				db.itemEffects = make(map[int32]ApplyEffect)
+			}
+			effects := MapSlice(v.Procs, func(proc *proto.ProcEffect) ApplyEffect {
+				return procEffectFactory(v.Id, v.Name, proc)
+			})
+			db.itemEffects[v.Id] = func(agent Agent) {
+				for _, effect := range effects {
+					effect(agent)
+				}
+			}
+			item.HasEffect = true
+		}
+		db.items[v.Id] = item
+	}
+
+	db.randomSuffixes = make(map[int32]RandomSuffix, len(simDB.RandomSuffixes))
+	for _, v := range simDB.RandomSuffixes {
+		db.randomSuffixes[v.Id] = RandomSuffixFromProto(v)
+	}
+
+	// Like enchantVariantsByEffectID, but the variants sent with the request
+	// replace all of the global ones for the effect ID.
+	db.enchants = make(map[int32]Enchant, len(simDB.Enchants))
+	db.enchantVariants = make(map[int32][]Enchant, len(simDB.Enchants))
+	for _, v := range simDB.Enchants {
+		if _, ok := db.enchants[v.EffectId]; !ok {
+			db.enchants[v.EffectId] = EnchantFromProto(v)
+		}
+		db.enchantVariants[v.EffectId] = append(db.enchantVariants[v.EffectId], EnchantFromProto(v))
+	}
+
+	db.runes = make(map[int32]Rune, len(simDB.Runes))
+	for _, v := range simDB.Runes {
+		db.runes[v.Id] = RuneFromProto(v)
+	}
+	return db
+}
+
+func (db *Database) LookupItem(id int32) (Item, bool) {
+	if item, ok := db.items[id]; ok {
+		return item, true
+	}
+	rwMutex.RLock()
+	defer rwMutex.RUnlock()
+	item, ok := ItemsByID[id]
+	return item, ok
+}
+
+func (db *Database) LookupRandomSuffix(id int32) (RandomSuffix, bool) {
+	if randomSuffix, ok := db.randomSuffixes[id]; ok {
+		return randomSuffix, true
+	}
+	rwMutex.RLock()
+	defer rwMutex.RUnlock()
+	randomSuffix, ok := RandomSuffixesByID[id]
+	return randomSuffix, ok
+}
+
+func (db *Database) LookupEnchant(effectID int32) (Enchant, bool) {
+	if enchant, ok := db.enchants[effectID]; ok {
+		return enchant, true
 	}
+	rwMutex.RLock()
+	defer rwMutex.RUnlock()
+	enchant, ok := EnchantsByEffectID[effectID]
+	return enchant, ok
+}
+
+func (db *Database) LookupRune(id int32) (Rune, bool) {
+	if rune, ok := db.runes[id]; ok {
+		return rune, true
+	}
+	rwMutex.RLock()
+	defer rwMutex.RUnlock()
+	rune, ok := RunesByID[id]
+	return rune, ok
+}
+
+func (db *Database) lookupEnchantVariants(effectID int32) []Enchant {
+	if variants, ok := db.enchantVariants[effectID]; ok {
+		return variants
+	}
+	rwMutex.RLock()
+	defer rwMutex.RUnlock()
+	return enchantVariantsByEffectID[effectID]
+}
+
+// Returns the effect of the item, preferring the procs sent with the request
+// over the effect registered in code.
+func (db *Database) lookupItemEffect(id int32) (ApplyEffect, bool) {
+	if effect, ok := db.itemEffects[id]; ok {
+		return effect, true
+	}
+	effect, ok := itemEffects[id]
+	return effect, ok
+}
+
+// Returns whether the item has an effect besides its stats which hasn't been
+// implemented, so the sim treats it as if it only had its stats.
+func (db *Database) HasUnimplementedItemEffect(item Item) bool {
+	_, ok := db.lookupItemEffect(item.ID)
+	return item.HasEffect && !ok
 }
 
 type Item struct {
@@ -68,6 +209,8 @@ type Item struct {
 	SetName      string // Empty string if not part of a set.
 	SetID        int32  // 0 if not part of a set.
 	WeaponSkills stats.WeaponSkills
+	HasEffect    bool // Whether the item has an effect besides its stats, which needs an ItemEffect.
+	Unique       bool
 
 	// Modified for each instance of the item.
 	RandomSuffix RandomSuffix
@@ -78,6 +221,29 @@ type Item struct {
 	TempEnchant int32
 }
 
+// Returns whether a database item has an effect besides its stats. Besides the
+// has_effect flag, which is only set for items scraped from tooltips, this
+// includes necks, rings, trinkets and relics without any stats, not even from
+// a random suffix, since those are only worn for their effect.
+//
+// This is only a fallback for when the tooltips aren't available, since it
+// misses every item with both stats and an effect.
+func UIItemHasEffect(item *proto.UIItem) bool {
+	if item.HasEffect {
+		return true
+	}
+	switch item.Type {
+	case proto.ItemType_ItemTypeNeck, proto.ItemType_ItemTypeFinger, proto.ItemType_ItemTypeTrinket:
+	case proto.ItemType_ItemTypeRanged:
+		if item.WeaponSpeed != 0 {
+			return false
+		}
+	default:
+		return false
+	}
+	return len(item.RandomSuffixOptions) == 0 && !slices.ContainsFunc(item.Stats, func(value float64) bool { return value != 0 })
+}
+
 func ItemFromProto(pData *proto.SimItem) Item {
 	return Item{
 		ID:               pData.Id,
@@ -96,6 +262,8 @@ func ItemFromProto(pData *proto.SimItem) Item {
 		SetName:          pData.SetName,
 		SetID:            pData.SetId,
 		WeaponSkills:     stats.WeaponSkillsFloatArray(pData.WeaponSkills),
+		HasEffect:        pData.HasEffect,
+		Unique:           pData.Unique,
 	}
 }
 
@@ -130,25 +298,58 @@ func RandomSuffixFromProto(pData *proto.ItemRandomSuffix) RandomSuffix {
 type Enchant struct {
 	EffectID int32 // Used by UI to apply effect to tooltip
 	Stats    stats.Stats
+
+	// Restrictions on which items and characters the enchant can be used by.
+	Type           proto.ItemType // ItemTypeUnknown if the restrictions aren't known.
+	ExtraTypes     []proto.ItemType
+	EnchantType    proto.EnchantType
+	ClassAllowlist []proto.Class
+	RequiresLevel  int32
 }
 
 func EnchantFromProto(pData *proto.SimEnchant) Enchant {
 	return Enchant{
-		EffectID: pData.EffectId,
-		Stats:    stats.FromFloatArray(pData.Stats),
+		EffectID:       pData.EffectId,
+		Stats:          stats.FromFloatArray(pData.Stats),
+		Type:           pData.Type,
+		ExtraTypes:     pData.ExtraTypes,
+		EnchantType:    pData.EnchantType,
+		ClassAllowlist: pData.ClassAllowlist,
+		RequiresLevel:  pData.RequiresLevel,
 	}
 }
 
 type Rune struct {
-	ID int32
+	ID             int32
+	Name           string
+	Type           proto.ItemType // Type of item the rune is engraved on.
+	ClassAllowlist []proto.Class
+	RequiresLevel  int32
 }
 
 func RuneFromProto(pData *proto.SimRune) Rune {
 	return Rune{
-		ID: pData.Id,
+		ID:             pData.Id,
+		Name:           pData.Name,
+		Type:           pData.Type,
+		ClassAllowlist: pData.ClassAllowlist,
+		RequiresLevel:  pData.RequiresLevel,
 	}
 }
 
+// Returns whether a character of this class can use the rune. Runes which
+// aren't in the database are allowed, since there's nothing to check them against.
+func (db *Database) RuneUsableByClass(runeID int32, class proto.Class) bool {
+	rune, ok := db.LookupRune(runeID)
+	return !ok || len(rune.ClassAllowlist) == 0 || slices.Contains(rune.ClassAllowlist, class)
+}
+
+// Same as RuneUsableByClass, but also requires the rune to be for this type of item.
+func (db *Database) RuneAllowed(runeID int32, itemType proto.ItemType, class proto.Class) bool {
+	rune, ok := db.LookupRune(runeID)
+	return db.RuneUsableByClass(runeID, class) && (!ok || rune.Type == itemType)
+}
+
 type ItemSpec struct {
 	ID           int32
 	RandomSuffix int32
@@ -254,16 +455,16 @@ func ProtoToEquipmentSpec(es *proto.EquipmentSpec) EquipmentSpec {
 	return coreEquip
 }
 
-func NewItem(itemSpec ItemSpec) Item {
+func (db *Database) NewItem(itemSpec ItemSpec) Item {
 	item := Item{}
-	if foundItem, ok := ItemsByID[itemSpec.ID]; ok {
+	if foundItem, ok := db.LookupItem(itemSpec.ID); ok {
 		item = foundItem
 	} else {
 		panic(fmt.Sprintf("No item with id: %d", itemSpec.ID))
 	}
 
 	if itemSpec.RandomSuffix != 0 {
-		if randomSuffix, ok := RandomSuffixesByID[itemSpec.RandomSuffix]; ok {
+		if randomSuffix, ok := db.LookupRandomSuffix(itemSpec.RandomSuffix); ok {
 			item.RandomSuffix = randomSuffix
 		} else {
 			panic(fmt.Sprintf("No random suffix with id: %d", itemSpec.RandomSuffix))
@@ -271,7 +472,7 @@ func NewItem(itemSpec ItemSpec) Item {
 	}
 
 	if itemSpec.Enchant != 0 {
-		if enchant, ok := EnchantsByEffectID[itemSpec.Enchant]; ok {
+		if enchant, ok := db.LookupEnchant(itemSpec.Enchant); ok {
 			item.Enchant = enchant
 		}
 		// else {
@@ -281,26 +482,23 @@ func NewItem(itemSpec ItemSpec) Item {
 
 	if itemSpec.Rune != 0 {
 		item.Rune = itemSpec.Rune
-		// if rune, ok := RuneBySpellID[itemSpec.Rune]; ok {
-		// 	item.Rune = rune.ID
-		// }
 	}
 
 	return item
 }
 
-func NewEquipmentSet(equipSpec EquipmentSpec) Equipment {
+func (db *Database) NewEquipmentSet(equipSpec EquipmentSpec) Equipment {
 	equipment := Equipment{}
 	for _, itemSpec := range equipSpec {
 		if itemSpec.ID != 0 {
-			equipment.EquipItem(NewItem(itemSpec))
+			equipment.EquipItem(db.NewItem(itemSpec))
 		}
 	}
 	return equipment
 }
 
-func ProtoToEquipment(es *proto.EquipmentSpec) Equipment {
-	return NewEquipmentSet(ProtoToEquipmentSpec(es))
+func (db *Database) ProtoToEquipment(es *proto.EquipmentSpec) Equipment {
+	return db.NewEquipmentSet(ProtoToEquipmentSpec(es))
 }
 
 // Like ItemSpec, but uses names for reference instead of ID.
@@ -5,6 +5,8 @@
 package core
 
 import (
+	"slices"
+
 	"github.com/wowsims/sod/assets/database"
 	"github.com/wowsims/sod/sim/core/proto"
 )
@@ -17,6 +19,15 @@ func init() {
 		Items:          make([]*proto.SimItem, len(db.Items)),
 		Enchants:       make([]*proto.SimEnchant, len(db.Enchants)),
 		RandomSuffixes: make([]*proto.ItemRandomSuffix, len(db.RandomSuffixes)),
+		Runes:          make([]*proto.SimRune, len(db.Runes)),
+	}
+
+	// Item effects come from the tooltips when db.json is generated with them,
+	// see gen_db. A database without any has_effect flags predates that, so the
+	// effects are guessed instead.
+	itemHasEffect := UIItemHasEffect
+	if slices.ContainsFunc(db.Items, func(item *proto.UIItem) bool { return item.HasEffect }) {
+		itemHasEffect = func(item *proto.UIItem) bool { return item.HasEffect }
 	}
 
 	for i, item := range db.Items {
@@ -37,13 +48,20 @@ func init() {
 			SetName:          item.SetName,
 			SetId:            item.SetId,
 			WeaponSkills:     item.WeaponSkills,
+			HasEffect:        itemHasEffect(item),
+			Unique:           item.Unique,
 		}
 	}
 
 	for i, enchant := range db.Enchants {
 		simDB.Enchants[i] = &proto.SimEnchant{
-			EffectId: enchant.EffectId,
-			Stats:    enchant.Stats,
+			EffectId:       enchant.EffectId,
+			Stats:          enchant.Stats,
+			Type:           enchant.Type,
+			ExtraTypes:     enchant.ExtraTypes,
+			EnchantType:    enchant.EnchantType,
+			ClassAllowlist: enchant.ClassAllowlist,
+			RequiresLevel:  enchant.RequiresLevel,
 		}
 	}
 
@@ -55,5 +73,15 @@ func init() {
 		}
 	}
 
+	for i, rune := range db.Runes {
+		simDB.Runes[i] = &proto.SimRune{
+			Id:             rune.Id,
+			Name:           rune.Name,
+			Type:           rune.Type,
+			ClassAllowlist: rune.ClassAllowlist,
+			RequiresLevel:  rune.RequiresLevel,
+		}
+	}
+
 	addToDatabase(simDB)
 }
@@ -49,8 +49,11 @@ type Dot struct {
 	SnapshotCritChance         float64
 	SnapshotAttackerMultiplier float64
 
-	tickAction *PendingAction
-	tickPeriod time.Duration
+	tickAction       *PendingAction
+	tickActionPeriod time.Duration // Period of tickAction, which keeps ticking at the tickPeriod it was started with.
+	tickPeriod       time.Duration
+	onTickAction     func(*Simulation)
+	onTickCleanUp    func(*Simulation)
 
 	// Number of ticks since last call to Apply().
 	TickCount int32
@@ -107,27 +110,19 @@ func (dot *Dot) Rollover(sim *Simulation) {
 	dot.Aura.Refresh(sim)       // update aura's duration
 
 	oldNextTick := dot.tickAction.NextActionAt
-	dot.tickAction.Cancel(sim) // remove old PA ticker
+	dot.cancelTickAction(sim) // remove old PA ticker
 
 	// recreate with new period, resetting the next tick.
-	periodicOptions := dot.basePeriodicOptions()
-	periodicOptions.Period = dot.tickPeriod
-	dot.tickAction = NewPeriodicAction(sim, periodicOptions)
-	dot.tickAction.NextActionAt = oldNextTick
-	sim.AddPendingAction(dot.tickAction)
+	dot.startTickAction(sim, oldNextTick)
 }
 
 func (dot *Dot) RescheduleNextTick(sim *Simulation) {
 	dot.RecomputeAuraDuration()
 
-	dot.tickAction.Cancel(sim) // remove old PA ticker
+	dot.cancelTickAction(sim) // remove old PA ticker
 
 	// recreate with new period, resetting the next tick.
-	periodicOptions := dot.basePeriodicOptions()
-	periodicOptions.Period = dot.tickPeriod
-	dot.tickAction = NewPeriodicAction(sim, periodicOptions)
-	dot.tickAction.NextActionAt = dot.lastTickTime + dot.tickPeriod
-	sim.AddPendingAction(dot.tickAction)
+	dot.startTickAction(sim, dot.lastTickTime+dot.tickPeriod)
 }
 
 func (dot *Dot) Apply(sim *Simulation) {
@@ -160,10 +155,7 @@ func (dot *Dot) ApplyOrReset(sim *Simulation) {
 	oldTickAction.Cancel(sim) // remove old PA ticker
 
 	// recreate with new period, resetting the next tick.
-	periodicOptions := dot.basePeriodicOptions()
-	periodicOptions.Period = dot.tickPeriod
-	dot.tickAction = NewPeriodicAction(sim, periodicOptions)
-	sim.AddPendingAction(dot.tickAction)
+	dot.startTickAction(sim, sim.CurrentTime+dot.tickPeriod)
 }
 
 // Like Apply(), but does not reset the tick timer.
@@ -238,26 +230,26 @@ func (dot *Dot) ManualTick(sim *Simulation) {
 	}
 }
 
-func (dot *Dot) basePeriodicOptions() PeriodicActionOptions {
-	return PeriodicActionOptions{
-		//Priority: ActionPriorityDOT,
-		OnAction: func(sim *Simulation) {
-			if dot.lastTickTime != sim.CurrentTime {
-				dot.TickCount++
-				dot.TickOnce(sim)
-			}
-		},
-		CleanUp: func(sim *Simulation) {
-			// In certain cases, the last tick and the dot aura expiration can happen in
-			// different orders, so we might need to apply the last tick.
-			if dot.tickAction != nil && dot.tickAction.NextActionAt == sim.CurrentTime {
-				if dot.lastTickTime != sim.CurrentTime {
-					dot.TickCount++
-					dot.TickOnce(sim)
-				}
-			}
-		},
-	}
+// Starts ticking every tickPeriod, with the first tick at nextTickAt. Tick
+// actions are pooled because dots are reapplied very often.
+func (dot *Dot) startTickAction(sim *Simulation, nextTickAt time.Duration) {
+	pa := sim.newPooledAction()
+	pa.NextActionAt = nextTickAt
+	//pa.Priority = ActionPriorityDOT
+	pa.OnAction = dot.onTickAction
+	pa.CleanUp = dot.onTickCleanUp
+
+	dot.tickAction = pa
+	dot.tickActionPeriod = dot.tickPeriod
+	sim.AddPendingAction(pa)
+}
+
+// Cancels the current tick action, which may still apply a tick that is due.
+// The action goes back to the pool once it leaves the queue, so the dot must
+// not hold on to it.
+func (dot *Dot) cancelTickAction(sim *Simulation) {
+	dot.tickAction.Cancel(sim)
+	dot.tickAction = nil
 }
 
 func newDot(config Dot) *Dot {
@@ -267,20 +259,40 @@ func newDot(config Dot) *Dot {
 	dot.tickPeriod = dot.TickLength
 	dot.Aura.Duration = dot.TickLength * time.Duration(dot.NumberOfTicks)
 
+	// Old tick actions are always cancelled before they're replaced, so the only
+	// tick action which can run is the current one.
+	dot.onTickAction = func(sim *Simulation) {
+		pa := dot.tickAction
+		if dot.lastTickTime != sim.CurrentTime {
+			dot.TickCount++
+			dot.TickOnce(sim)
+		}
+		if !pa.cancelled {
+			pa.NextActionAt = sim.CurrentTime + dot.tickActionPeriod
+			sim.AddPendingAction(pa)
+		}
+	}
+	dot.onTickCleanUp = func(sim *Simulation) {
+		// In certain cases, the last tick and the dot aura expiration can happen in
+		// different orders, so we might need to apply the last tick.
+		if dot.tickAction != nil && dot.tickAction.NextActionAt == sim.CurrentTime {
+			if dot.lastTickTime != sim.CurrentTime {
+				dot.TickCount++
+				dot.TickOnce(sim)
+			}
+		}
+	}
+
 	dot.Aura.ApplyOnGain(func(aura *Aura, sim *Simulation) {
 		dot.lastTickTime = sim.CurrentTime
-		periodicOptions := dot.basePeriodicOptions()
-		periodicOptions.Period = dot.tickPeriod
-		dot.tickAction = NewPeriodicAction(sim, periodicOptions)
-		sim.AddPendingAction(dot.tickAction)
+		dot.startTickAction(sim, sim.CurrentTime+dot.tickPeriod)
 		if dot.isChanneled {
 			dot.Spell.Unit.ChanneledDot = dot
 		}
 	})
 	dot.Aura.ApplyOnExpire(func(aura *Aura, sim *Simulation) {
 		if dot.tickAction != nil {
-			dot.tickAction.Cancel(sim)
-			dot.tickAction = nil
+			dot.cancelTickAction(sim)
 		}
 		if dot.isChanneled {
 			dot.Spell.Unit.ChanneledDot = nil
@@ -24,8 +24,12 @@ func init() {
 }
 
 type FakeAgent struct {
-	Spell *Spell
-	Dot   *Dot
+	Spell     *Spell
+	Dot       *Dot
+	Bolt      *Spell
+	Hardcast  *Spell
+	Proc      *Aura
+	Empowered *Spell
 	Character
 	Init func()
 }
@@ -55,8 +59,12 @@ func NewFakeElementalShaman(char *Character, _ *proto.Player) Agent {
 			ActionID:    ActionID{SpellID: 42},
 			SpellSchool: SpellSchoolShadow,
 			ProcMask:    ProcMaskSpellDamage,
-			Flags:       SpellFlagIgnoreResists,
-			Cast:        CastConfig{},
+			Flags:       SpellFlagIgnoreResists | SpellFlagAPL,
+			Cast: CastConfig{
+				DefaultCast: Cast{
+					GCD: GCDDefault,
+				},
+			},
 
 			BonusCritRating:  3 * CritRatingPerCritChance,
 			DamageMultiplier: 1.5,
@@ -87,6 +95,85 @@ func NewFakeElementalShaman(char *Character, _ *proto.Player) Agent {
 			},
 		})
 		fa.Dot = fa.Spell.CurDot()
+
+		fa.Bolt = fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 43},
+			SpellSchool: SpellSchoolShadow,
+			DefenseType: DefenseTypeMagic,
+			ProcMask:    ProcMaskSpellDamage,
+			Flags:       SpellFlagIgnoreResists | SpellFlagAPL,
+			Cast: CastConfig{
+				DefaultCast: Cast{
+					GCD: GCDDefault,
+				},
+			},
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+			BonusCoefficient: 0.5,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.CalcAndDealDamage(sim, target, sim.Roll(80, 120), spell.OutcomeMagicHitAndCrit)
+				if sim.Proc(0.2, "Fake Proc") {
+					fa.Proc.Activate(sim)
+				}
+			},
+		})
+
+		// A proc from the bolt, which the rotation doesn't cast itself, and a
+		// strong spell which can only be cast while it's active.
+		fa.Proc = fa.RegisterAura(Aura{
+			Label:    "Fake Proc",
+			ActionID: ActionID{SpellID: 45},
+			Duration: time.Second * 3,
+		})
+		fa.Empowered = fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 46},
+			SpellSchool: SpellSchoolShadow,
+			DefenseType: DefenseTypeMagic,
+			ProcMask:    ProcMaskSpellDamage,
+			Flags:       SpellFlagIgnoreResists | SpellFlagAPL,
+			Cast: CastConfig{
+				DefaultCast: Cast{
+					GCD: GCDDefault,
+				},
+			},
+			ExtraCastCondition: func(sim *Simulation, target *Unit) bool {
+				return fa.Proc.IsActive()
+			},
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+			BonusCoefficient: 1,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				fa.Proc.Deactivate(sim)
+				spell.CalcAndDealDamage(sim, target, 1000, spell.OutcomeMagicHitAndCrit)
+			},
+		})
+
+		// A bolt with a cast time, for tests of hardcasts.
+		fa.Hardcast = fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 44},
+			SpellSchool: SpellSchoolShadow,
+			DefenseType: DefenseTypeMagic,
+			ProcMask:    ProcMaskSpellDamage,
+			Flags:       SpellFlagIgnoreResists | SpellFlagAPL,
+			Cast: CastConfig{
+				DefaultCast: Cast{
+					GCD:      GCDDefault,
+					CastTime: time.Second * 2,
+				},
+			},
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+			BonusCoefficient: 1,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.CalcAndDealDamage(sim, target, sim.Roll(160, 240), spell.OutcomeMagicHitAndCrit)
+			},
+		})
 	}
 
 	return fa
@@ -126,6 +213,50 @@ func SetupFakeSim() *Simulation {
 	return sim
 }
 
+// Keeps the fake DoT up on the target, and casts the bolt otherwise.
+var fakeCasterRotation = &proto.APLRotation{
+	Type: proto.APLRotation_TypeAPL,
+	PriorityList: []*proto.APLListItem{
+		{Action: &proto.APLAction{
+			Condition: &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: &proto.APLValue{
+				Value: &proto.APLValue_AuraIsActive{AuraIsActive: &proto.APLValueAuraIsActive{
+					SourceUnit: &proto.UnitReference{Type: proto.UnitReference_CurrentTarget},
+					AuraId:     ActionID{SpellID: 42}.ToProto(),
+				}},
+			}}}},
+			Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: ActionID{SpellID: 42}.ToProto()}},
+		}},
+		{Action: &proto.APLAction{
+			Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: ActionID{SpellID: 43}.ToProto()}},
+		}},
+	},
+}
+
+// A request for the fake caster with its rotation, which deals damage with
+// some variance between iterations.
+func fakeCasterRaidSimRequest(simOptions *proto.SimOptions) *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+			BonusStats: &proto.UnitStats{
+				Stats: stats.Stats{stats.SpellPower: 200, stats.SpellHit: 3, stats.SpellCrit: 10}.ToFloatArray(),
+			},
+			Rotation: fakeCasterRotation,
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets:           []*proto.Target{{Name: "target", Level: 63, MobType: proto.MobType_MobTypeDemon}},
+			Duration:          60,
+			DurationVariation: 5,
+		},
+		SimOptions: simOptions,
+	}
+}
+
 func expectDotTickDamage(t *testing.T, sim *Simulation, dot *Dot, expectedDamage float64) {
 	damageBefore := dot.Spell.SpellMetrics[0].TotalDamage
 	dot.TickOnce(sim)
@@ -36,6 +36,9 @@ type Environment struct {
 	Encounter Encounter
 	AllUnits  []*Unit
 
+	// Whether units have 2D positions, see initPositions().
+	UsePositions bool
+
 	BaseDuration      time.Duration // base duration
 	DurationVariation time.Duration // variation per duration
 
@@ -44,6 +47,9 @@ type Environment struct {
 	postFinalizeEffects []PostFinalizeEffect
 
 	prepullActions []PrepullAction
+
+	// Auras which are never activated, see SimOptions.DisabledAuras.
+	disabledAuras []ActionID
 }
 
 func NewEnvironment(raidProto *proto.Raid, encounterProto *proto.Encounter, runFakePrepull bool) (*Environment, *proto.RaidStats, *proto.EncounterStats) {
@@ -108,6 +114,8 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 		}
 	}
 
+	env.initPositions(raidProto, encounterProto)
+
 	env.State = Constructed
 }
 
@@ -153,13 +161,19 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 		}
 	}
 
-	for _, party := range env.Raid.Parties {
-		for _, player := range party.Players {
+	for partyIdx, party := range env.Raid.Parties {
+		partyProto := raidProto.Parties[partyIdx]
+		for playerIdx, player := range party.Players {
+			var petRotations []*proto.PetRotation
+			if playerIdx < len(partyProto.Players) {
+				petRotations = partyProto.Players[playerIdx].PetRotations
+			}
+
 			character := player.GetCharacter()
 			character.Finalize()
 			for _, pet := range character.Pets {
 				pet.Finalize()
-				pet.Rotation = pet.newCustomRotation()
+				pet.Rotation = pet.newPetRotation(petRotations)
 			}
 		}
 	}
@@ -341,6 +355,11 @@ func (env *Environment) GetUnit(ref *proto.UnitReference, contextUnit *Unit) *Un
 		}
 	case proto.UnitReference_Self:
 		return contextUnit
+	case proto.UnitReference_Owner:
+		if petAgent, ok := env.GetAgentFromUnit(contextUnit).(PetAgent); ok {
+			return &petAgent.GetPet().Owner.Unit
+		}
+		return nil
 	case proto.UnitReference_CurrentTarget:
 		if contextUnit == nil {
 			return nil
@@ -0,0 +1,102 @@
+// Package hujsonproto reads protos from HuJSON, for data files which are written
+// by hand, like database overrides and proc effects.
+package hujsonproto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/tailscale/hujson"
+	"github.com/wowsims/sod/sim/core/stats"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Parses data into m in protojson format, so the field names are those of the
+// proto in lowerCamelCase, and unknown fields or enum values are errors.
+// Comments and trailing commas are allowed, and stats may be written as an
+// object keyed by stat name, like {"SpellPower": 12}.
+func Unmarshal(data []byte, m googleProto.Message) error {
+	standardized, err := hujson.Standardize(data)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(standardized))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+	if err := statsObjectsToLists(value, ""); err != nil {
+		return err
+	}
+
+	converted, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(converted, m)
+}
+
+// Replaces each stats object keyed by stat name with the list the protos use.
+// Errors start with the path of the object containing the stats, like
+// "items[0].procs[1]".
+func statsObjectsToLists(value any, path string) error {
+	switch value := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			if statsObject, ok := value[key].(map[string]any); ok && key == "stats" {
+				list, err := statsObjectToList(statsObject)
+				if err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+				value[key] = list
+				continue
+			}
+
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if err := statsObjectsToLists(value[key], childPath); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, element := range value {
+			if err := statsObjectsToLists(element, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func statsObjectToList(statsObject map[string]any) ([]any, error) {
+	list := make([]any, stats.Len)
+	for i := range list {
+		list[i] = 0
+	}
+	for name, value := range statsObject {
+		index := -1
+		for stat := stats.Stat(0); stat < stats.Len; stat++ {
+			if stat.StatName() == name {
+				index = int(stat)
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("unknown stat %q", name)
+		}
+		list[index] = value
+	}
+	return list, nil
+}
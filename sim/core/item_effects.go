@@ -33,6 +33,7 @@ func HasItemEffect(id int32) bool {
 	_, ok := itemEffects[id]
 	return ok
 }
+
 func HasItemEffectForTest(id int32) bool {
 	return slices.Contains(itemEffectsForTest, id)
 }
@@ -47,6 +48,17 @@ func HasEnchantEffect(id int32) bool {
 	return ok
 }
 
+// Makes the effect of one of the procs of an item sent with a request.
+type ProcEffectFactory func(itemID int32, itemName string, proc *proto.ProcEffect) ApplyEffect
+
+var procEffectFactory ProcEffectFactory
+
+// Registers the function which makes the effects of items' procs. This lives
+// outside of core, so it can use the item effect templates from itemhelpers.
+func RegisterProcEffectFactory(factory ProcEffectFactory) {
+	procEffectFactory = factory
+}
+
 // Registers an ApplyEffect function which will be called before the Sim
 // starts, for any Agent that is wearing the item.
 func NewItemEffect(id int32, itemEffect ApplyEffect) {
@@ -95,7 +107,21 @@ func AddWeaponEffect(id int32, weaponEffect ApplyWeaponEffect) {
 // Helpers for making common types of active item effects.
 
 func NewSimpleStatItemActiveEffect(itemID int32, bonus stats.Stats, duration time.Duration, cooldown time.Duration, flags SpellFlag, sharedCDFunc func(*Character) Cooldown, otherEffects ApplyEffect) {
-	registerCD := MakeTemporaryStatsOnUseCDRegistration(
+	registerCD := MakeSimpleStatItemActiveEffect(itemID, bonus, duration, cooldown, flags, sharedCDFunc)
+
+	if otherEffects == nil {
+		NewItemEffect(itemID, registerCD)
+	} else {
+		NewItemEffect(itemID, func(agent Agent) {
+			registerCD(agent)
+			otherEffects(agent)
+		})
+	}
+}
+
+// Like NewSimpleStatItemActiveEffect, but returns the effect instead of registering it.
+func MakeSimpleStatItemActiveEffect(itemID int32, bonus stats.Stats, duration time.Duration, cooldown time.Duration, flags SpellFlag, sharedCDFunc func(*Character) Cooldown) ApplyEffect {
+	return MakeTemporaryStatsOnUseCDRegistration(
 		"ItemActive-"+strconv.Itoa(int(itemID)),
 		bonus,
 		duration,
@@ -111,15 +137,6 @@ func NewSimpleStatItemActiveEffect(itemID int32, bonus stats.Stats, duration tim
 		},
 		sharedCDFunc,
 	)
-
-	if otherEffects == nil {
-		NewItemEffect(itemID, registerCD)
-	} else {
-		NewItemEffect(itemID, func(agent Agent) {
-			registerCD(agent)
-			otherEffects(agent)
-		})
-	}
 }
 
 // No shared CD
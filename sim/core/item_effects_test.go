@@ -0,0 +1,185 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func TestUnimplementedEffectWarnings(t *testing.T) {
+	const (
+		itemWithEffect  = 990001
+		itemSetHelm     = 990002
+		itemSetShoulder = 990003
+	)
+
+	equipment := createEquipmentFromItems(
+		&itemWithSlot{Item: &proto.ItemSpec{Id: itemWithEffect}, Slot: proto.ItemSlot_ItemSlotTrinket1},
+		&itemWithSlot{Item: &proto.ItemSpec{Id: itemSetHelm}, Slot: proto.ItemSlot_ItemSlotHead},
+		&itemWithSlot{Item: &proto.ItemSpec{Id: itemSetShoulder}, Slot: proto.ItemSlot_ItemSlotShoulder},
+	)
+
+	result := ComputeStats(&proto.ComputeStatsRequest{
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: equipment,
+			Database: &proto.SimDatabase{
+				Items: []*proto.SimItem{
+					{Id: itemWithEffect, Name: "Trinket Of Testing", Type: proto.ItemType_ItemTypeTrinket, HasEffect: true},
+					{Id: itemSetHelm, Name: "Helm Of Testing", Type: proto.ItemType_ItemTypeHead, SetName: "Garb Of Testing"},
+					{Id: itemSetShoulder, Name: "Mantle Of Testing", Type: proto.ItemType_ItemTypeShoulder, SetName: "Garb Of Testing"},
+				},
+			},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+	})
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+
+	expected := []string{
+		"Set bonuses for Garb Of Testing are not implemented.",
+		"Trinket Of Testing has an effect which is not implemented, so only its stats are simmed.",
+	}
+	warnings := slices.Clone(result.RaidStats.Parties[0].Players[0].Warnings)
+	slices.Sort(warnings)
+	if !slices.Equal(warnings, expected) {
+		t.Fatalf("Expected warnings %v, got %v", expected, warnings)
+	}
+}
+
+func TestUnimplementedEffectWarningForDatabaseItem(t *testing.T) {
+	if !WITH_DB {
+		t.Skip("Needs the item database")
+	}
+	// The database has no stats for Tidal Charm, whose use effect isn't implemented.
+	const tidalCharm = 1404
+
+	result := ComputeStats(&proto.ComputeStatsRequest{
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Level:     40,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: createEquipmentFromItems(&itemWithSlot{Item: &proto.ItemSpec{Id: tidalCharm}, Slot: proto.ItemSlot_ItemSlotTrinket1}),
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+	})
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+
+	expected := []string{"Tidal Charm has an effect which is not implemented, so only its stats are simmed."}
+	if warnings := result.RaidStats.Parties[0].Players[0].Warnings; !slices.Equal(warnings, expected) {
+		t.Fatalf("Expected warnings %v, got %v", expected, warnings)
+	}
+}
+
+func TestUIItemHasEffect(t *testing.T) {
+	for _, test := range []struct {
+		item      *proto.UIItem
+		hasEffect bool
+	}{
+		{&proto.UIItem{Type: proto.ItemType_ItemTypeHead, HasEffect: true}, true},
+		{&proto.UIItem{Type: proto.ItemType_ItemTypeTrinket, Stats: make([]float64, stats.Len)}, true},
+		{&proto.UIItem{Type: proto.ItemType_ItemTypeRanged}, true},
+		{&proto.UIItem{Type: proto.ItemType_ItemTypeTrinket, Stats: stats.Stats{stats.Stamina: 5}.ToFloatArray()}, false},
+		{&proto.UIItem{Type: proto.ItemType_ItemTypeFinger, RandomSuffixOptions: []int32{1}}, false},
+		{&proto.UIItem{Type: proto.ItemType_ItemTypeRanged, WeaponSpeed: 2.8}, false},
+		{&proto.UIItem{Type: proto.ItemType_ItemTypeHead}, false},
+	} {
+		if hasEffect := UIItemHasEffect(test.item); hasEffect != test.hasEffect {
+			t.Fatalf("Expected %v to have an effect: %t, got %t", test.item, test.hasEffect, hasEffect)
+		}
+	}
+}
+
+func TestRequestDatabaseIsScoped(t *testing.T) {
+	const testRing = 990011
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{{Id: testRing, Name: "Ring Of Testing", Type: proto.ItemType_ItemTypeFinger, Stats: stats.Stats{stats.Stamina: 10}.ToFloatArray()}},
+	})
+
+	gearStamina := func(database *proto.SimDatabase) float64 {
+		result := ComputeStats(&proto.ComputeStatsRequest{
+			Raid: SinglePlayerRaidProto(&proto.Player{
+				Name:      "Caster",
+				Class:     proto.Class_ClassShaman,
+				Consumes:  &proto.Consumes{},
+				Buffs:     &proto.IndividualBuffs{},
+				Spec:      &proto.Player_ElementalShaman{},
+				Equipment: createEquipmentFromItems(&itemWithSlot{Item: &proto.ItemSpec{Id: testRing}, Slot: proto.ItemSlot_ItemSlotFinger1}),
+				Database:  database,
+			}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		})
+		if result.ErrorResult != "" {
+			t.Fatal(result.ErrorResult)
+		}
+		return result.RaidStats.Parties[0].Players[0].GearStats.Stats[stats.Stamina]
+	}
+
+	baseStamina := gearStamina(nil)
+	override := &proto.SimDatabase{
+		Items: []*proto.SimItem{{Id: testRing, Name: "Ring Of Testing", Type: proto.ItemType_ItemTypeFinger, Stats: stats.Stats{stats.Stamina: 25}.ToFloatArray()}},
+	}
+	if stamina := gearStamina(override); stamina != baseStamina+15 {
+		t.Fatalf("Expected %f stamina with the request's ring, got %f", baseStamina+15, stamina)
+	}
+	if stamina := gearStamina(nil); stamina != baseStamina {
+		t.Fatalf("Expected the request's ring not to affect later requests, got %f stamina instead of %f", stamina, baseStamina)
+	}
+}
+
+func TestRequestDatabaseProcs(t *testing.T) {
+	const testTrinket = 990012
+	previousFactory := procEffectFactory
+	defer RegisterProcEffectFactory(previousFactory)
+
+	var applied []string
+	RegisterProcEffectFactory(func(itemID int32, itemName string, proc *proto.ProcEffect) ApplyEffect {
+		return func(agent Agent) {
+			applied = append(applied, fmt.Sprintf("%d %s %s", itemID, itemName, proc.Trigger))
+		}
+	})
+
+	result := ComputeStats(&proto.ComputeStatsRequest{
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: createEquipmentFromItems(&itemWithSlot{Item: &proto.ItemSpec{Id: testTrinket}, Slot: proto.ItemSlot_ItemSlotTrinket1}),
+			Database: &proto.SimDatabase{
+				Items: []*proto.SimItem{{
+					Id:   testTrinket,
+					Name: "Trinket Of Procs",
+					Type: proto.ItemType_ItemTypeTrinket,
+					Procs: []*proto.ProcEffect{{
+						Trigger:         proto.ProcTrigger_ProcTriggerSpellCast,
+						ProcChance:      0.1,
+						Stats:           stats.Stats{stats.SpellPower: 100}.ToFloatArray(),
+						DurationSeconds: 10,
+					}},
+				}},
+			},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+	})
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+
+	if expected := []string{"990012 Trinket Of Procs ProcTriggerSpellCast"}; !slices.Equal(applied, expected) {
+		t.Errorf("Expected effects %v to be applied, got %v", expected, applied)
+	}
+	if warnings := result.RaidStats.Parties[0].Players[0].Warnings; len(warnings) > 0 {
+		t.Errorf("Expected no warnings for an item with procs, got %v", warnings)
+	}
+}
@@ -69,6 +69,29 @@ func NewItemSet(set ItemSet) *ItemSet {
 	return &set
 }
 
+func findItemSet(setID int32, setName string) *ItemSet {
+	if setID > 0 {
+		// Try finding by ID first to make sure sets with different names but share id all point to the same count.
+		for _, set := range sets {
+			if set.ID == setID {
+				return set
+			}
+		}
+	}
+
+	for _, set := range sets {
+		if set.Name == setName || set.AlternativeName == setName {
+			return set
+		}
+	}
+	return nil
+}
+
+// Returns whether bonuses have been registered for the item set with the given ID or name.
+func HasItemSet(setID int32, setName string) bool {
+	return findItemSet(setID, setName) != nil
+}
+
 func (character *Character) HasSetBonus(set *ItemSet, numItems int32) bool {
 	if character.Env != nil && character.Env.IsFinalized() {
 		panic("HasSetBonus is very slow and should never be called after finalization. Try caching the value during construction instead!")
@@ -115,28 +138,7 @@ func (character *Character) GetActiveSetBonuses() []ActiveSetBonus {
 			continue
 		}
 
-		var foundSet *ItemSet = nil
-
-		if item.SetID > 0 {
-			// Try finding by ID first to make sure sets with different names but share id all point to the same count.
-			for _, set := range sets {
-				if set.ID == item.SetID {
-					foundSet = set
-					break
-				}
-			}
-		}
-
-		if foundSet == nil {
-			for _, set := range sets {
-				if set.Name == item.SetName || set.AlternativeName == item.SetName {
-					foundSet = set
-					break
-				}
-			}
-		}
-
-		if foundSet != nil {
+		if foundSet := findItemSet(item.SetID, item.SetName); foundSet != nil {
 			setItemCount[foundSet]++
 			if bonusEffect, ok := foundSet.Bonuses[setItemCount[foundSet]]; ok {
 				activeBonuses = append(activeBonuses, ActiveSetBonus{
@@ -38,9 +38,9 @@ func (character *Character) enableItemSwap(itemSwap *proto.ItemSwap) {
 		character.Equipment[proto.ItemSlot_ItemSlotRanged],
 	}
 	swapItems := [3]Item{
-		toItem(itemSwap.MhItem),
-		toItem(itemSwap.OhItem),
-		toItem(itemSwap.RangedItem),
+		character.database.toItem(itemSwap.MhItem),
+		character.database.toItem(itemSwap.OhItem),
+		character.database.toItem(itemSwap.RangedItem),
 	}
 
 	// Handle MH and OH together, because present MH + empty OH --> swap MH and unequip OH
@@ -255,12 +255,12 @@ func getInitialEquippedItems(character *Character) [3]Item {
 	return items
 }
 
-func toItem(itemSpec *proto.ItemSpec) Item {
+func (db *Database) toItem(itemSpec *proto.ItemSpec) Item {
 	if itemSpec == nil {
 		return Item{}
 	}
 
-	return NewItem(ItemSpec{
+	return db.NewItem(ItemSpec{
 		ID: itemSpec.Id,
 
 		Enchant: itemSpec.Enchant,
@@ -2,6 +2,7 @@ package core
 
 import (
 	"math"
+	"slices"
 	"time"
 
 	"github.com/wowsims/sod/sim/core/proto"
@@ -70,6 +71,15 @@ func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 	}
 }
 
+// Returns the standard error of the mean value.
+func (distMetrics *DistributionMetrics) stderr() float64 {
+	if distMetrics.n == 0 {
+		return 0
+	}
+	_, stdev := distMetrics.meanAndStdDev()
+	return stdev / math.Sqrt(float64(distMetrics.n))
+}
+
 func NewDistributionMetrics() DistributionMetrics {
 	return DistributionMetrics{
 		hist: make(map[int32]int32),
@@ -77,6 +87,38 @@ func NewDistributionMetrics() DistributionMetrics {
 	}
 }
 
+// Adds the aggregate values of other, which must cover later iterations.
+func (distMetrics *DistributionMetrics) mergeAggregates(other *DistributionMetrics) {
+	if other.n == 0 {
+		return
+	}
+	distMetrics.aggregator = *distMetrics.merge(&other.aggregator)
+	distMetrics.sample = append(distMetrics.sample, other.sample...)
+
+	if other.max > distMetrics.max {
+		distMetrics.max = other.max
+		distMetrics.maxSeed = other.maxSeed
+	}
+	if other.min <= distMetrics.min || distMetrics.min < 0 {
+		distMetrics.min = other.min
+		distMetrics.minSeed = other.minSeed
+	}
+
+	for dps, count := range other.hist {
+		distMetrics.hist[dps] += count
+	}
+}
+
+func (distMetrics *DistributionMetrics) resetAggregates() {
+	distMetrics.aggregator = aggregator{}
+	distMetrics.max = 0
+	distMetrics.min = -1
+	distMetrics.maxSeed = 0
+	distMetrics.minSeed = 0
+	clear(distMetrics.hist)
+	distMetrics.sample = distMetrics.sample[:0]
+}
+
 type UnitMetrics struct {
 	dps    DistributionMetrics
 	dpasp  DistributionMetrics
@@ -93,10 +135,13 @@ type UnitMetrics struct {
 	CharacterIterationMetrics
 
 	// Aggregate values. These are updated after each iteration.
-	numItersDead int32
-	oomTimeSum   float64
-	actions      map[ActionID]*ActionMetrics
-	resources    []*ResourceMetrics
+	numItersDead  int32
+	oomTimeSum    float64
+	yardsMovedSum float64
+	actions       map[ActionID]*ActionMetrics
+	resources     []*ResourceMetrics
+	rotation      []*APLActionMetrics
+	timeline      *timelineMetrics
 }
 
 // Metrics for the current iteration, for 1 agent. Keep this as a separate
@@ -111,6 +156,8 @@ type CharacterIterationMetrics struct {
 	OOMTime time.Duration // time spent not casting and waiting for regen.
 
 	FirstOOMTimestamp time.Duration // Timestamp at which unit first went OOM.
+
+	YardsMoved float64
 }
 
 type ActionMetrics struct {
@@ -253,6 +300,40 @@ func (tam *TargetedActionMetrics) ToProto(unitIndex int32) *proto.TargetedAction
 	}
 }
 
+func (tam *TargetedActionMetrics) merge(other *TargetedActionMetrics) {
+	tam.Casts += other.Casts
+	tam.Misses += other.Misses
+	tam.Hits += other.Hits
+	tam.ResistedHits += other.ResistedHits
+	tam.Crits += other.Crits
+	tam.ResistedCrits += other.ResistedCrits
+	tam.Ticks += other.Ticks
+	tam.ResistedTicks += other.ResistedTicks
+	tam.CritTicks += other.CritTicks
+	tam.ResistedCritTicks += other.ResistedCritTicks
+	tam.Dodges += other.Dodges
+	tam.Glances += other.Glances
+	tam.Parries += other.Parries
+	tam.Blocks += other.Blocks
+	tam.BlockedCrits += other.BlockedCrits
+	tam.Damage += other.Damage
+	tam.ResistedDamage += other.ResistedDamage
+	tam.CritDamage += other.CritDamage
+	tam.ResistedCritDamage += other.ResistedCritDamage
+	tam.TickDamage += other.TickDamage
+	tam.ResistedTickDamage += other.ResistedTickDamage
+	tam.CritTickDamage += other.CritTickDamage
+	tam.ResistedCritTickDamage += other.ResistedCritTickDamage
+	tam.GlanceDamage += other.GlanceDamage
+	tam.BlockDamage += other.BlockDamage
+	tam.BlockedCritDamage += other.BlockedCritDamage
+	tam.Threat += other.Threat
+	tam.Healing += other.Healing
+	tam.CritHealing += other.CritHealing
+	tam.Shielding += other.Shielding
+	tam.CastTime += other.CastTime
+}
+
 func NewUnitMetrics() UnitMetrics {
 	return UnitMetrics{
 		dps:     NewDistributionMetrics(),
@@ -306,6 +387,14 @@ func (resourceMetrics *ResourceMetrics) AddEvent(gain float64, actualGain float6
 	resourceMetrics.ActualGain += actualGain
 }
 
+func (resourceMetrics *ResourceMetrics) resetAggregates() {
+	resourceMetrics.Events = 0
+	resourceMetrics.Gain = 0
+	resourceMetrics.ActualGain = 0
+	resourceMetrics.EventsFromPreviousIterations = 0
+	resourceMetrics.ActualGainFromPreviousIterations = 0
+}
+
 func (unitMetrics *UnitMetrics) NewResourceMetrics(actionID ActionID, resourceType proto.ResourceType) *ResourceMetrics {
 	newMetrics := &ResourceMetrics{
 		ActionID: actionID,
@@ -345,6 +434,72 @@ func (unit *Unit) NewFocusMetrics(actionID ActionID) *ResourceMetrics {
 	return unit.Metrics.NewResourceMetrics(actionID, proto.ResourceType_ResourceTypeFocus)
 }
 
+// Runtime counters for a single entry of an APL priority list. Like ActionMetrics,
+// these are totals across all iterations.
+type APLActionMetrics struct {
+	ListIndex int32 // Index of the entry in the rotation config.
+
+	Evaluations   int64         // Number of times this entry was checked while choosing the next action.
+	ConditionTrue int64         // Number of evaluations where the entry's condition was true.
+	Executions    int64         // Number of times this entry, or one of its inner actions, was executed.
+	WaitTime      time.Duration // Time between the condition becoming true and the entry being executed.
+
+	// Timestamp at which the condition became true, or -1 if it isn't currently true.
+	// Only used for the current iteration.
+	conditionTrueSince time.Duration
+}
+
+func (aplMetrics *APLActionMetrics) onConditionTrue(sim *Simulation) {
+	aplMetrics.ConditionTrue++
+	if aplMetrics.conditionTrueSince < 0 {
+		aplMetrics.conditionTrueSince = sim.CurrentTime
+	}
+}
+
+func (aplMetrics *APLActionMetrics) onConditionFalse() {
+	aplMetrics.conditionTrueSince = -1
+}
+
+func (aplMetrics *APLActionMetrics) onExecute(sim *Simulation, countExecution bool) {
+	if countExecution {
+		aplMetrics.Executions++
+	}
+	if aplMetrics.conditionTrueSince >= 0 {
+		aplMetrics.WaitTime += sim.CurrentTime - aplMetrics.conditionTrueSince
+		aplMetrics.conditionTrueSince = -1
+	}
+}
+
+func (aplMetrics *APLActionMetrics) reset() {
+	aplMetrics.conditionTrueSince = -1
+}
+
+func (aplMetrics *APLActionMetrics) resetAggregates() {
+	aplMetrics.Evaluations = 0
+	aplMetrics.ConditionTrue = 0
+	aplMetrics.Executions = 0
+	aplMetrics.WaitTime = 0
+}
+
+func (aplMetrics *APLActionMetrics) ToProto() *proto.APLActionMetrics {
+	return &proto.APLActionMetrics{
+		ListIndex:       aplMetrics.ListIndex,
+		Evaluations:     aplMetrics.Evaluations,
+		ConditionTrue:   aplMetrics.ConditionTrue,
+		Executions:      aplMetrics.Executions,
+		WaitTimeSeconds: aplMetrics.WaitTime.Seconds(),
+	}
+}
+
+func (unitMetrics *UnitMetrics) NewAPLActionMetrics(listIndex int32) *APLActionMetrics {
+	newMetrics := &APLActionMetrics{
+		ListIndex:          listIndex,
+		conditionTrueSince: -1,
+	}
+	unitMetrics.rotation = append(unitMetrics.rotation, newMetrics)
+	return newMetrics
+}
+
 var emptySpellMetrics SpellMetrics
 
 func empty(spellMetrics []SpellMetrics) bool {
@@ -462,6 +617,115 @@ func (unitMetrics *UnitMetrics) reset() {
 	for _, resourceMetrics := range unitMetrics.resources {
 		resourceMetrics.reset()
 	}
+	for _, aplMetrics := range unitMetrics.rotation {
+		aplMetrics.reset()
+	}
+	if unitMetrics.timeline != nil {
+		unitMetrics.timeline.reset()
+	}
+}
+
+// Adds the aggregate values of other, which must be the metrics of the same unit
+// in another Simulation built from the same request, covering later iterations.
+func (unitMetrics *UnitMetrics) mergeAggregates(other *UnitMetrics) {
+	unitMetrics.dps.mergeAggregates(&other.dps)
+	unitMetrics.dpasp.mergeAggregates(&other.dpasp)
+	unitMetrics.threat.mergeAggregates(&other.threat)
+	unitMetrics.dtps.mergeAggregates(&other.dtps)
+	unitMetrics.tmi.mergeAggregates(&other.tmi)
+	unitMetrics.hps.mergeAggregates(&other.hps)
+	unitMetrics.tto.mergeAggregates(&other.tto)
+
+	unitMetrics.numItersDead += other.numItersDead
+	unitMetrics.oomTimeSum += other.oomTimeSum
+	unitMetrics.yardsMovedSum += other.yardsMovedSum
+
+	for actionID, otherAction := range other.actions {
+		actionMetrics, ok := unitMetrics.actions[actionID]
+		if !ok {
+			actionMetrics = &ActionMetrics{
+				IsMelee:     otherAction.IsMelee,
+				IsPassive:   otherAction.IsPassive,
+				SpellSchool: otherAction.SpellSchool,
+				Targets:     make([]TargetedActionMetrics, len(otherAction.Targets)),
+			}
+			unitMetrics.actions[actionID] = actionMetrics
+		}
+		for i := range otherAction.Targets {
+			actionMetrics.Targets[i].merge(&otherAction.Targets[i])
+		}
+	}
+
+	// Resource metrics can be created lazily, so match them by key and occurrence
+	// rather than by index.
+	seen := make(map[ResourceKey]int)
+	for _, otherResource := range other.resources {
+		key := ResourceKey{ActionID: otherResource.ActionID, Type: otherResource.Type}
+		occurrence := seen[key]
+		seen[key]++
+		if otherResource.Events == 0 {
+			continue
+		}
+
+		resourceMetrics := unitMetrics.findResourceMetrics(key, occurrence)
+		if resourceMetrics == nil {
+			resourceMetrics = unitMetrics.NewResourceMetrics(key.ActionID, key.Type)
+		}
+		resourceMetrics.Events += otherResource.Events
+		resourceMetrics.Gain += otherResource.Gain
+		resourceMetrics.ActualGain += otherResource.ActualGain
+	}
+
+	for i, otherAPLMetrics := range other.rotation {
+		aplMetrics := unitMetrics.rotation[i]
+		aplMetrics.Evaluations += otherAPLMetrics.Evaluations
+		aplMetrics.ConditionTrue += otherAPLMetrics.ConditionTrue
+		aplMetrics.Executions += otherAPLMetrics.Executions
+		aplMetrics.WaitTime += otherAPLMetrics.WaitTime
+	}
+
+	if unitMetrics.timeline != nil {
+		unitMetrics.timeline.mergeAggregates(other.timeline)
+	}
+}
+
+// Returns the n-th resource metrics with the given key, or nil if there aren't that many.
+func (unitMetrics *UnitMetrics) findResourceMetrics(key ResourceKey, n int) *ResourceMetrics {
+	for _, resourceMetrics := range unitMetrics.resources {
+		if resourceMetrics.ActionID == key.ActionID && resourceMetrics.Type == key.Type {
+			if n == 0 {
+				return resourceMetrics
+			}
+			n--
+		}
+	}
+	return nil
+}
+
+// Clears all aggregate values, as if no iterations had been run.
+func (unitMetrics *UnitMetrics) resetAggregates() {
+	unitMetrics.dps.resetAggregates()
+	unitMetrics.dpasp.resetAggregates()
+	unitMetrics.threat.resetAggregates()
+	unitMetrics.dtps.resetAggregates()
+	unitMetrics.tmi.resetAggregates()
+	unitMetrics.hps.resetAggregates()
+	unitMetrics.tto.resetAggregates()
+
+	unitMetrics.numItersDead = 0
+	unitMetrics.oomTimeSum = 0
+	unitMetrics.yardsMovedSum = 0
+	clear(unitMetrics.actions)
+
+	for _, resourceMetrics := range unitMetrics.resources {
+		resourceMetrics.resetAggregates()
+	}
+	for _, aplMetrics := range unitMetrics.rotation {
+		aplMetrics.resetAggregates()
+	}
+	if unitMetrics.timeline != nil {
+		unitMetrics.timeline.resetAggregates()
+	}
 }
 
 // This should be called when a Sim iteration is complete.
@@ -503,6 +767,7 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	unitMetrics.tto.doneIteration(sim)
 
 	unitMetrics.oomTimeSum += unitMetrics.OOMTime.Seconds()
+	unitMetrics.yardsMovedSum += unitMetrics.YardsMoved
 	if unitMetrics.Died {
 		unitMetrics.numItersDead++
 	}
@@ -592,11 +857,18 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		Tto:           unitMetrics.tto.ToProto(),
 		SecondsOomAvg: unitMetrics.oomTimeSum / n,
 		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
+		YardsMovedAvg: unitMetrics.yardsMovedSum / n,
 	}
 
-	protoMetrics.Actions = make([]*proto.ActionMetrics, 0, len(unitMetrics.actions))
-	for actionID, action := range unitMetrics.actions {
-		protoMetrics.Actions = append(protoMetrics.Actions, action.ToProto(actionID))
+	// Sorted, so results don't depend on the map order.
+	actionIDs := make([]ActionID, 0, len(unitMetrics.actions))
+	for actionID := range unitMetrics.actions {
+		actionIDs = append(actionIDs, actionID)
+	}
+	slices.SortFunc(actionIDs, compareActionIDs)
+	protoMetrics.Actions = make([]*proto.ActionMetrics, 0, len(actionIDs))
+	for _, actionID := range actionIDs {
+		protoMetrics.Actions = append(protoMetrics.Actions, unitMetrics.actions[actionID].ToProto(actionID))
 	}
 
 	protoMetrics.Resources = make([]*proto.ResourceMetrics, 0, len(unitMetrics.resources))
@@ -606,6 +878,8 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		}
 	}
 
+	protoMetrics.Rotation = MapSlice(unitMetrics.rotation, func(aplMetrics *APLActionMetrics) *proto.APLActionMetrics { return aplMetrics.ToProto() })
+
 	return protoMetrics
 }
 
@@ -632,6 +906,16 @@ func (auraMetrics *AuraMetrics) doneIteration() {
 	auraMetrics.procsSum += auraMetrics.Procs
 }
 
+func (auraMetrics *AuraMetrics) mergeAggregates(other *AuraMetrics) {
+	auraMetrics.aggregator = *auraMetrics.merge(&other.aggregator)
+	auraMetrics.procsSum += other.procsSum
+}
+
+func (auraMetrics *AuraMetrics) resetAggregates() {
+	auraMetrics.aggregator = aggregator{}
+	auraMetrics.procsSum = 0
+}
+
 func (auraMetrics *AuraMetrics) ToProto() *proto.AuraMetrics {
 	mean, stdev := auraMetrics.meanAndStdDev()
 
@@ -35,6 +35,7 @@ type PendingAction struct {
 
 	cancelled bool
 	consumed  bool
+	pooled    bool // Whether this action is returned to the sim's pool once it's no longer queued.
 }
 
 func (pa *PendingAction) Cancel(sim *Simulation) {
@@ -49,3 +50,22 @@ func (pa *PendingAction) Cancel(sim *Simulation) {
 
 	pa.cancelled = true
 }
+
+// Returns a blank action from the sim's pool, to avoid allocating actions which
+// are created very often, like dot ticks.
+//
+// Pooled actions are reused once they have been cancelled and removed from the
+// queue, so the owner must drop its reference to the action when cancelling it.
+func (sim *Simulation) newPooledAction() *PendingAction {
+	if n := len(sim.actionPool); n > 0 {
+		pa := sim.actionPool[n-1]
+		sim.actionPool = sim.actionPool[:n-1]
+		return pa
+	}
+	return &PendingAction{pooled: true}
+}
+
+func (sim *Simulation) releasePooledAction(pa *PendingAction) {
+	*pa = PendingAction{pooled: true}
+	sim.actionPool = append(sim.actionPool, pa)
+}
@@ -36,6 +36,14 @@ type Pet struct {
 	OnPetEnable  OnPetEnable
 	OnPetDisable OnPetDisable
 
+	// Whether a rotation from the player's settings may replace ExecuteCustomRotation().
+	// Only set for pets whose custom rotation can be expressed as an APL.
+	SupportsAPLRotation bool
+
+	// APL rotation used when the player's settings don't have one for this pet.
+	// Pets without one use ExecuteCustomRotation() by default.
+	DefaultRotation *proto.APLRotation
+
 	// Calculates inherited stats based on owner stats or stat changes.
 	statInheritance        PetStatInheritance
 	dynamicStatInheritance PetStatInheritance
@@ -49,6 +57,13 @@ type Pet struct {
 	// Some pets expire after a certain duration. This is the pending action that disables
 	// the pet on expiration.
 	timeoutAction *PendingAction
+
+	// Whether this pet uses an APL rotation, configured by the player or its
+	// DefaultRotation, rather than its ExecuteCustomRotation().
+	usesAPLRotation bool
+
+	// Set if the player configured a rotation for this pet which it doesn't support.
+	rotationWarning string
 }
 
 func NewPet(name string, owner *Character, baseStats stats.Stats, statInheritance PetStatInheritance, enabledOnStart bool, isGuardian bool) Pet {
@@ -124,6 +139,34 @@ func (pet *Pet) IsGuardian() bool {
 	return pet.isGuardian
 }
 
+// Returns whether this pet uses an APL rotation rather than ExecuteCustomRotation().
+func (pet *Pet) UsesAPLRotation() bool {
+	return pet.usesAPLRotation
+}
+
+// Uses the rotation in rotations matching this pet's name, falling back to the
+// pet's DefaultRotation if there isn't one or the pet doesn't support it, and
+// then to ExecuteCustomRotation().
+func (pet *Pet) newPetRotation(rotations []*proto.PetRotation) *APLRotation {
+	config := pet.DefaultRotation
+	for _, rotation := range rotations {
+		if rotation.PetName == pet.Name && rotation.Rotation != nil {
+			if !pet.SupportsAPLRotation {
+				pet.rotationWarning = fmt.Sprintf("%s does not support APL rotations, so its rotation is ignored.", pet.Name)
+			} else {
+				config = rotation.Rotation
+			}
+			break
+		}
+	}
+
+	if config == nil {
+		return pet.newCustomRotation()
+	}
+	pet.usesAPLRotation = true
+	return pet.newAPLRotation(config)
+}
+
 // petAgent should be the PetAgent which embeds this Pet.
 func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 	if pet.enabled {
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Models the imperfections of a human player, see proto.PlayerSkill.
+// The zero value represents perfect play.
+type PlayerSkill struct {
+	GCDDelayMean  time.Duration
+	GCDDelayStdev time.Duration
+
+	ProcReaction time.Duration
+
+	SkipTopActionChance float64
+
+	HardcastLatency time.Duration
+
+	// Kept for the unit, as making one for every GCD is slow.
+	gcdDelayRand *rand.Rand
+}
+
+func newPlayerSkill(config *proto.PlayerSkill) PlayerSkill {
+	if config == nil {
+		return PlayerSkill{}
+	}
+	return PlayerSkill{
+		GCDDelayMean:        max(0, time.Duration(config.GcdDelayMeanMs)*time.Millisecond),
+		GCDDelayStdev:       max(0, time.Duration(config.GcdDelayStdevMs)*time.Millisecond),
+		ProcReaction:        max(0, time.Duration(config.ProcReactionMs)*time.Millisecond),
+		SkipTopActionChance: min(max(0, config.SkipTopActionChance), 1),
+		HardcastLatency:     max(0, time.Duration(config.HardcastLatencyMs)*time.Millisecond),
+	}
+}
+
+func (skill *PlayerSkill) IsPerfect() bool {
+	return skill.GCDDelayMean == 0 && skill.GCDDelayStdev == 0 && skill.ProcReaction == 0 &&
+		skill.SkipTopActionChance == 0 && skill.HardcastLatency == 0
+}
+
+func (skill *PlayerSkill) hasGCDDelay() bool {
+	return skill.GCDDelayMean > 0 || skill.GCDDelayStdev > 0
+}
+
+// Samples the delay between the GCD becoming ready and the next input.
+func (skill *PlayerSkill) sampleGCDDelay(sim *Simulation, unit *Unit) time.Duration {
+	delay := skill.GCDDelayMean
+	if skill.GCDDelayStdev > 0 {
+		if skill.gcdDelayRand == nil {
+			skill.gcdDelayRand = rand.New(sim.labelRand(unit, "Player Skill GCD Delay"))
+		}
+		delay += time.Duration(skill.gcdDelayRand.NormFloat64() * float64(skill.GCDDelayStdev))
+	}
+	return max(0, delay)
+}
+
+func (skill *PlayerSkill) skipsTopAction(sim *Simulation, unit *Unit) bool {
+	return skill.SkipTopActionChance > 0 && sim.UnitProc(unit, skill.SkipTopActionChance, "Player Skill Skip Action")
+}
+
+func hasPlayerSkill(raid *proto.Raid) bool {
+	for _, party := range raid.Parties {
+		for _, player := range party.Players {
+			if skill := newPlayerSkill(player.GetSkill()); !skill.IsPerfect() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns a copy of the request with perfect play for all players, using the
+// same seed and number of iterations so the two runs can be compared.
+func perfectPlayRequest(rsr *proto.RaidSimRequest, rseed int64, iterations int32) *proto.RaidSimRequest {
+	perfectRsr := googleProto.Clone(rsr).(*proto.RaidSimRequest)
+	for _, party := range perfectRsr.Raid.Parties {
+		for _, player := range party.Players {
+			if player != nil {
+				player.Skill = nil
+			}
+		}
+	}
+	perfectRsr.SimOptions.RandomSeed = rseed
+	perfectRsr.SimOptions.Iterations = iterations
+	perfectRsr.SimOptions.TargetDpsStderr = 0
+	perfectRsr.SimOptions.Debug = false
+	perfectRsr.SimOptions.DebugFirstIteration = false
+	perfectRsr.SimOptions.TimelineBucketSeconds = 0
+	return perfectRsr
+}
+
+// Runs a perfect play request from perfectPlayRequest. The request was already
+// validated, so this skips validation, and it reuses the presim results of the
+// sim it's paired with so both runs share the same presim settings.
+func runPerfectPlaySim(rsr *proto.RaidSimRequest, presimResults []*proto.RaidSimResult) *proto.RaidSimResult {
+	sim := NewSim(rsr)
+	sim.replayPresims(rsr, presimResults)
+	if len(presimResults) > 0 {
+		sim.usePresimResult(presimResults[len(presimResults)-1])
+	}
+	sim.newWorker = newWorkerFunc(rsr, presimResults)
+	return sim.run()
+}
+
+// Fills in the DPS lost by each player with a skill model, compared to the perfect play metrics.
+func (raid *Raid) addSkillDpsLoss(metrics *proto.RaidMetrics, perfectMetrics *proto.RaidMetrics) {
+	for partyIdx, party := range raid.Parties {
+		for _, player := range party.Players {
+			character := player.GetCharacter()
+			if character.Skill.IsPerfect() {
+				continue
+			}
+			playerMetrics := metrics.Parties[partyIdx].Players[character.PartyIndex]
+			perfectPlayerMetrics := perfectMetrics.Parties[partyIdx].Players[character.PartyIndex]
+			playerMetrics.SkillDpsLoss = perfectPlayerMetrics.Dps.Avg - playerMetrics.Dps.Avg
+		}
+	}
+}
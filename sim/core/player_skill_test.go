@@ -0,0 +1,126 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func TestPlayerSkillDefaultsToPerfectPlay(t *testing.T) {
+	if skill := newPlayerSkill(nil); !skill.IsPerfect() {
+		t.Fatalf("Expected perfect play for nil config, got %+v", skill)
+	}
+	if skill := newPlayerSkill(&proto.PlayerSkill{}); !skill.IsPerfect() {
+		t.Fatalf("Expected perfect play for empty config, got %+v", skill)
+	}
+
+	skill := newPlayerSkill(&proto.PlayerSkill{GcdDelayMeanMs: -50, HardcastLatencyMs: 100, SkipTopActionChance: 2})
+	if skill.GCDDelayMean != 0 || skill.HardcastLatency != time.Millisecond*100 || skill.SkipTopActionChance != 1 {
+		t.Fatalf("Unexpected skill values %+v", skill)
+	}
+}
+
+func TestPerfectPlayRequest(t *testing.T) {
+	rsr := &proto.RaidSimRequest{
+		Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{
+			{Skill: &proto.PlayerSkill{GcdDelayMeanMs: 100}},
+		}}}},
+		SimOptions: &proto.SimOptions{Iterations: 10, Debug: true, TargetDpsStderr: 5},
+	}
+	if !hasPlayerSkill(rsr.Raid) {
+		t.Fatalf("Expected raid to have a player skill model")
+	}
+
+	perfectRsr := perfectPlayRequest(rsr, 1234, 7)
+	if hasPlayerSkill(perfectRsr.Raid) || perfectRsr.SimOptions.RandomSeed != 1234 || perfectRsr.SimOptions.Debug {
+		t.Fatalf("Unexpected perfect play request %s", perfectRsr)
+	}
+	if perfectRsr.SimOptions.Iterations != 7 || perfectRsr.SimOptions.TargetDpsStderr != 0 {
+		t.Fatalf("Unexpected perfect play request %s", perfectRsr)
+	}
+	if rsr.Raid.Parties[0].Players[0].Skill == nil {
+		t.Fatalf("Original request should not be modified")
+	}
+}
+
+func TestProcReactionIgnoresOwnDots(t *testing.T) {
+	rsr := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 20, RandomSeed: 100})
+	rsr.Raid.Parties[0].Players[0].Skill = &proto.PlayerSkill{ProcReactionMs: 2000}
+
+	result := RunRaidSim(rsr)
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+	player := result.RaidMetrics.Parties[0].Players[0]
+	if player.Dps.Avg <= 0 {
+		t.Fatalf("Expected the fake caster to deal damage")
+	}
+
+	// The caster sees their own DoT at once, so they don't recast it during the
+	// reaction time, and play exactly like the perfect play run.
+	if player.SkillDpsLoss != 0 {
+		t.Fatalf("Expected no DPS loss from reaction time to an own DoT, got %f", player.SkillDpsLoss)
+	}
+}
+
+func TestProcReactionLosesDps(t *testing.T) {
+	rsr := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 100, RandomSeed: 100})
+	player := rsr.Raid.Parties[0].Players[0]
+	player.Skill = &proto.PlayerSkill{ProcReactionMs: 2000}
+	// Casts the empowered spell while the bolt's proc is active, and the bolt otherwise.
+	player.Rotation = &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PriorityList: []*proto.APLListItem{
+			{Action: &proto.APLAction{
+				Condition: &proto.APLValue{Value: &proto.APLValue_AuraIsActive{AuraIsActive: &proto.APLValueAuraIsActive{
+					AuraId: ActionID{SpellID: 45}.ToProto(),
+				}}},
+				Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: ActionID{SpellID: 46}.ToProto()}},
+			}},
+			{Action: &proto.APLAction{
+				Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: ActionID{SpellID: 43}.ToProto()}},
+			}},
+		},
+	}
+
+	result := RunRaidSim(rsr)
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+
+	// The reaction time is longer than a GCD, so the player casts another bolt
+	// before noticing each proc, and many procs expire unused.
+	if loss := result.RaidMetrics.Parties[0].Players[0].SkillDpsLoss; loss <= 0 {
+		t.Fatalf("Expected a DPS loss from reaction time to a proc, got %f", loss)
+	}
+}
+
+func TestPlayerSkillLosesDps(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		skill *proto.PlayerSkill
+	}{
+		{"GCDDelay", &proto.PlayerSkill{GcdDelayMeanMs: 300, GcdDelayStdevMs: 100}},
+		{"SkipTopAction", &proto.PlayerSkill{SkipTopActionChance: 0.5}},
+		{"HardcastLatency", &proto.PlayerSkill{HardcastLatencyMs: 300}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			rsr := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 100, RandomSeed: 100})
+			player := rsr.Raid.Parties[0].Players[0]
+			player.Skill = test.skill
+			// Use the bolt with a cast time as filler, so that hardcasts are affected.
+			player.Rotation = googleProto.Clone(player.Rotation).(*proto.APLRotation)
+			player.Rotation.PriorityList[1].Action.GetCastSpell().SpellId = ActionID{SpellID: 44}.ToProto()
+
+			result := RunRaidSim(rsr)
+			if result.ErrorResult != "" {
+				t.Fatal(result.ErrorResult)
+			}
+			if loss := result.RaidMetrics.Parties[0].Players[0].SkillDpsLoss; loss <= 0 {
+				t.Fatalf("Expected a DPS loss compared to perfect play, got %f", loss)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// A point or offset on the ground, in yards.
+type Vector2 struct {
+	X float64
+	Y float64
+}
+
+func PositionFromProto(position *proto.Position) Vector2 {
+	if position == nil {
+		return Vector2{}
+	}
+	return Vector2{X: position.X, Y: position.Y}
+}
+
+func (v Vector2) Add(other Vector2) Vector2 {
+	return Vector2{X: v.X + other.X, Y: v.Y + other.Y}
+}
+func (v Vector2) Sub(other Vector2) Vector2 {
+	return Vector2{X: v.X - other.X, Y: v.Y - other.Y}
+}
+func (v Vector2) Scale(factor float64) Vector2 {
+	return Vector2{X: v.X * factor, Y: v.Y * factor}
+}
+func (v Vector2) Length() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+func (v Vector2) DistanceTo(other Vector2) float64 {
+	return other.Sub(v).Length()
+}
+
+// Returns the angle between v and other, in degrees. Zero vectors are treated
+// as pointing in every direction.
+func (v Vector2) AngleTo(other Vector2) float64 {
+	lengths := v.Length() * other.Length()
+	if lengths == 0 {
+		return 0
+	}
+	cos := (v.X*other.X + v.Y*other.Y) / lengths
+	return math.Acos(max(-1, min(1, cos))) * 180 / math.Pi
+}
+
+// Where the area of an AoE spell is centered.
+type AoeOrigin byte
+
+const (
+	AoeOriginTarget AoeOrigin = iota // Centered on the target, e.g. Blizzard.
+	AoeOriginCaster                  // Centered on the caster, e.g. Arcane Explosion.
+)
+
+// Positions are only modeled if at least one player or target has one set, so
+// that sims without positions behave exactly as before.
+func (env *Environment) initPositions(raidProto *proto.Raid, encounterProto *proto.Encounter) {
+	env.UsePositions = slices.ContainsFunc(encounterProto.Targets, func(target *proto.Target) bool {
+		return target.Position != nil
+	}) || slices.ContainsFunc(raidProto.Parties, func(party *proto.Party) bool {
+		return slices.ContainsFunc(party.Players, func(player *proto.Player) bool {
+			return player != nil && player.Position != nil
+		})
+	})
+	if !env.UsePositions {
+		return
+	}
+
+	for _, target := range env.Encounter.TargetUnits {
+		target.Position = target.StartPosition
+	}
+
+	// Units without a position start distance_from_target yards in front of or
+	// behind their target. Targets face towards -y.
+	for _, unit := range env.Raid.AllUnits {
+		if unit.hasStartPosition {
+			unit.StartDistanceFromTarget = unit.StartPosition.DistanceTo(unit.CurrentTarget.StartPosition)
+		} else {
+			offset := unit.StartDistanceFromTarget
+			if unit.PseudoStats.InFrontOfTarget {
+				offset = -offset
+			}
+			unit.StartPosition = unit.CurrentTarget.StartPosition.Add(Vector2{Y: offset})
+		}
+		unit.Position = unit.StartPosition
+		unit.DistanceFromTarget = unit.StartDistanceFromTarget
+	}
+}
+
+// Returns the distance between this unit and other, in yards.
+//
+// Without positions, player units are DistanceFromTarget away from every enemy.
+func (unit *Unit) DistanceTo(other *Unit) float64 {
+	if !unit.Env.UsePositions || other == nil {
+		if unit.Type == EnemyUnit && other != nil {
+			return other.DistanceFromTarget
+		}
+		return unit.DistanceFromTarget
+	}
+	return unit.Position.DistanceTo(other.Position)
+}
+
+// Direction this unit would move in to get further away from its target.
+func (unit *Unit) awayFromTarget() Vector2 {
+	away := unit.Position.Sub(unit.CurrentTarget.Position)
+	if length := away.Length(); length > 0 {
+		return away.Scale(1 / length)
+	}
+	if unit.PseudoStats.InFrontOfTarget {
+		return Vector2{Y: -1}
+	}
+	return Vector2{Y: 1}
+}
+
+// Moves this unit in a straight line to point, at MoveSpeed.
+func (unit *Unit) MoveToPosition(point Vector2, sim *Simulation) {
+	start := unit.Position
+	moveDistance := start.DistanceTo(point)
+	if moveDistance == 0 {
+		return
+	}
+
+	moveTicks := int(math.Ceil(moveDistance))
+	moveInterval := point.Sub(start).Scale(1 / moveDistance)
+	tick := 0
+
+	unit.moveSpell.Cast(sim, unit.CurrentTarget)
+
+	sim.AddPendingAction(NewPeriodicAction(sim, PeriodicActionOptions{
+		Period:          time.Millisecond * 1000 / time.Duration(unit.MoveSpeed),
+		NumTicks:        moveTicks,
+		TickImmediately: false,
+
+		OnAction: func(sim *Simulation) {
+			tick++
+			lastPosition := unit.Position
+			if tick == moveTicks {
+				unit.Position = point
+			} else {
+				unit.Position = start.Add(moveInterval.Scale(float64(tick)))
+			}
+			unit.Metrics.YardsMoved += lastPosition.DistanceTo(unit.Position)
+			unit.DistanceFromTarget = unit.DistanceTo(unit.CurrentTarget)
+			unit.moveAura.SetStacks(sim, int32(unit.DistanceFromTarget))
+
+			if tick == moveTicks {
+				unit.moveAura.Deactivate(sim)
+			}
+		},
+	}))
+}
+
+// Returns the units hit by this spell when cast on target, based on AoeRadius,
+// AoeArc and AoeOrigin. Without positions or a radius, every enemy is hit.
+//
+// The returned slice is reused by later calls, and must not be modified.
+func (spell *Spell) AoeTargets(target *Unit) []*Unit {
+	return spell.aoeTargetsAt(spell.AoeCenter(target), target.Position.Sub(spell.Unit.Position))
+}
+
+// Returns the units within AoeRadius of center, ignoring AoeArc. Used for ground
+// effects, which keep hitting the area they were cast on.
+func (spell *Spell) AoeTargetsAt(center Vector2) []*Unit {
+	return spell.aoeTargetsAt(center, Vector2{})
+}
+
+// Returns the center of the area hit by this spell when cast on target.
+func (spell *Spell) AoeCenter(target *Unit) Vector2 {
+	if spell.AoeOrigin == AoeOriginCaster {
+		return spell.Unit.Position
+	}
+	return target.Position
+}
+
+func (spell *Spell) aoeTargetsAt(center Vector2, facing Vector2) []*Unit {
+	enemies := spell.Unit.Env.Encounter.TargetUnits
+	if spell.Unit.Type == EnemyUnit {
+		enemies = spell.Unit.Env.Raid.AllPlayerUnits
+	}
+	if !spell.Unit.Env.UsePositions || spell.AoeRadius == 0 {
+		return enemies
+	}
+
+	spell.aoeTargets = spell.aoeTargets[:0]
+	for _, enemy := range enemies {
+		if center.DistanceTo(enemy.Position) > spell.AoeRadius {
+			continue
+		}
+		if spell.AoeOrigin == AoeOriginCaster && spell.AoeArc != 0 && facing.AngleTo(enemy.Position.Sub(center)) > spell.AoeArc/2 {
+			continue
+		}
+		spell.aoeTargets = append(spell.aoeTargets, enemy)
+	}
+	return spell.aoeTargets
+}
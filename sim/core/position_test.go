@@ -0,0 +1,92 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAoeTargets(t *testing.T) {
+	env := &Environment{UsePositions: true}
+	newTarget := func(x, y float64) *Unit {
+		target := &Unit{Env: env, Type: EnemyUnit, Position: Vector2{X: x, Y: y}}
+		env.Encounter.TargetUnits = append(env.Encounter.TargetUnits, target)
+		return target
+	}
+	main := newTarget(0, 0)
+	near := newTarget(0, 6)
+	behind := newTarget(0, -4)
+	far := newTarget(20, 0)
+	side := newTarget(3, -1)
+
+	caster := &Unit{Env: env, Type: PlayerUnit, Position: Vector2{X: 0, Y: -5}}
+
+	check := func(name string, spell *Spell, expected ...*Unit) {
+		t.Helper()
+		got := spell.AoeTargets(main)
+		if len(got) != len(expected) {
+			t.Fatalf("%s: expected %d targets, got %d", name, len(expected), len(got))
+		}
+		for i := range expected {
+			if got[i] != expected[i] {
+				t.Fatalf("%s: unexpected target at index %d", name, i)
+			}
+		}
+	}
+
+	check("No radius", &Spell{Unit: caster}, main, near, behind, far, side)
+	check("Around target", &Spell{Unit: caster, AoeRadius: 8}, main, near, behind, side)
+	check("Around caster", &Spell{Unit: caster, AoeRadius: 8, AoeOrigin: AoeOriginCaster}, main, behind, side)
+	check("Arc", &Spell{Unit: caster, AoeRadius: 12, AoeOrigin: AoeOriginCaster, AoeArc: 90}, main, near, behind, side)
+	check("Narrow arc", &Spell{Unit: caster, AoeRadius: 12, AoeOrigin: AoeOriginCaster, AoeArc: 10}, main, near, behind)
+
+	caster.Position = Vector2{X: -5, Y: 0}
+	check("Arc from the side", &Spell{Unit: caster, AoeRadius: 12, AoeOrigin: AoeOriginCaster, AoeArc: 90}, main, behind, side)
+
+	env.UsePositions = false
+	check("Without positions", &Spell{Unit: caster, AoeRadius: 8}, main, near, behind, far, side)
+}
+
+func TestVector2AngleTo(t *testing.T) {
+	if angle := (Vector2{X: 1}).AngleTo(Vector2{Y: 2}); angle != 90 {
+		t.Fatalf("Expected 90 degrees, got %f", angle)
+	}
+	if angle := (Vector2{X: 1}).AngleTo(Vector2{X: -3}); angle != 180 {
+		t.Fatalf("Expected 180 degrees, got %f", angle)
+	}
+	if angle := (Vector2{}).AngleTo(Vector2{X: -3}); angle != 0 {
+		t.Fatalf("Expected 0 degrees for a zero vector, got %f", angle)
+	}
+}
+
+func TestWaitTravelTime(t *testing.T) {
+	env := &Environment{UsePositions: true}
+	caster := &Unit{Env: env, Type: PlayerUnit, Position: Vector2{X: 0, Y: -5}}
+	near := &Unit{Env: env, Type: EnemyUnit, Position: Vector2{X: 0, Y: 5}}
+	far := &Unit{Env: env, Type: EnemyUnit, Position: Vector2{X: 0, Y: 35}}
+	caster.CurrentTarget = near
+
+	sim := &Simulation{Environment: env, pendingActions: []*PendingAction{sentinelPendingAction}}
+	spell := &Spell{Unit: caster, MissileSpeed: 20}
+
+	landedAt := make(map[*Unit]time.Duration)
+	for _, target := range []*Unit{far, near} {
+		target := target
+		spell.WaitTravelTime(sim, target, func(sim *Simulation) {
+			landedAt[target] = sim.CurrentTime
+		})
+	}
+	for len(sim.pendingActions) > 1 {
+		pa := sim.pendingActions[len(sim.pendingActions)-1]
+		sim.pendingActions = sim.pendingActions[:len(sim.pendingActions)-1]
+		sim.CurrentTime = pa.NextActionAt
+		pa.OnAction(sim)
+	}
+
+	// 10 and 40 yards away, at 20 yards per second.
+	if landedAt[near] != time.Millisecond*500 {
+		t.Fatalf("Expected the projectile to reach the near target after 0.5s, got %s", landedAt[near])
+	}
+	if landedAt[far] != time.Second*2 {
+		t.Fatalf("Expected the projectile to reach the far target after 2s, got %s", landedAt[far])
+	}
+}
@@ -31,9 +31,11 @@ type PresimOptions struct {
 	OnPresimResult func(presimResult *proto.UnitMetrics, iterations int32, duration time.Duration) bool
 }
 
-func (sim *Simulation) runPresims(request *proto.RaidSimRequest) *proto.RaidSimResult {
-	const numPresimIterations = 100
+const numPresimIterations = 100
 
+// Runs presim rounds until every Agent is done, and returns the result of each
+// round. The last result has the error, if any.
+func (sim *Simulation) runPresims(request *proto.RaidSimRequest) []*proto.RaidSimResult {
 	// Run presims if requested.
 	raidPresimOptions := make([]*PresimOptions, 25)
 	remainingAgents := 0
@@ -70,9 +72,12 @@ func (sim *Simulation) runPresims(request *proto.RaidSimRequest) *proto.RaidSimR
 	presimRequest.SimOptions.Debug = false
 	presimRequest.SimOptions.DebugFirstIteration = false
 	presimRequest.SimOptions.Iterations = numPresimIterations
+	presimRequest.SimOptions.NumThreads = 0
+	presimRequest.SimOptions.TimelineBucketSeconds = 0
+	presimRequest.SimOptions.TargetDpsStderr = 0
 	duration := DurationFromSeconds(presimRequest.Encounter.Duration)
 
-	var lastResult *proto.RaidSimResult
+	var presimResults []*proto.RaidSimResult
 
 	doOne := sim.Encounter.EndFightAtHealth > 0
 	for doOne || remainingAgents > 0 {
@@ -95,7 +100,7 @@ func (sim *Simulation) runPresims(request *proto.RaidSimRequest) *proto.RaidSimR
 
 		// Run the presim.
 		presimResult := runSim(presimRequest, nil, true)
-		lastResult = presimResult
+		presimResults = append(presimResults, presimResult)
 
 		if presimResult.ErrorResult != "" {
 			break
@@ -118,5 +123,45 @@ func (sim *Simulation) runPresims(request *proto.RaidSimRequest) *proto.RaidSimR
 		}
 		doOne = false
 	}
-	return lastResult
+	return presimResults
+}
+
+// Gives each Agent the results of presims that were run by another Simulation
+// built from the same request, round by round, so both end up with the same
+// settings.
+func (sim *Simulation) replayPresims(request *proto.RaidSimRequest, presimResults []*proto.RaidSimResult) {
+	if len(presimResults) == 0 || presimResults[len(presimResults)-1].ErrorResult != "" {
+		return
+	}
+
+	raidPresimOptions := make([]*PresimOptions, 25)
+	for _, party := range sim.Raid.Parties {
+		partyConfig := request.Raid.Parties[party.Index]
+		for _, player := range party.Players {
+			presimmer, ok := player.(Presimmer)
+			if !ok || player.GetCharacter().PartyIndex >= len(partyConfig.Players) {
+				continue
+			}
+			raidPresimOptions[player.GetCharacter().Index] = presimmer.GetPresimOptions(partyConfig.Players[player.GetCharacter().PartyIndex])
+		}
+	}
+
+	duration := DurationFromSeconds(request.Encounter.Duration)
+	for _, presimResult := range presimResults {
+		for partyIdx, party := range sim.Raid.Parties {
+			partyMetrics := presimResult.RaidMetrics.Parties[partyIdx]
+			for _, player := range party.Players {
+				presimOptions := raidPresimOptions[player.GetCharacter().Index]
+				if presimOptions == nil {
+					continue
+				}
+				playerMetrics := partyMetrics.Players[player.GetCharacter().PartyIndex]
+				if presimOptions.OnPresimResult(playerMetrics, numPresimIterations, duration) {
+					raidPresimOptions[player.GetCharacter().Index] = nil
+				}
+			}
+		}
+	}
+
+	sim.usePresimResult(presimResults[len(presimResults)-1])
 }
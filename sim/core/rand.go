@@ -1,6 +1,7 @@
 package core
 
 import (
+	"hash/fnv"
 	"math"
 	"math/rand"
 )
@@ -71,3 +72,56 @@ func (sm *SplitMix64) Int63() int64 {
 func (sm *SplitMix64) Uint64() uint64 {
 	return sm.Next()
 }
+
+func NewCounterRand(key uint64) *CounterRand {
+	return &CounterRand{key: key}
+}
+
+// Counter-based generator, where the n-th output is a keyed hash of n. Each output
+// only depends on (key, n), so streams with different keys are independent, and
+// any output can be computed without generating the ones before it.
+type CounterRand struct {
+	key     uint64
+	counter uint64
+}
+
+func (cr *CounterRand) Next() uint64 {
+	cr.counter++
+	return mix64(cr.key ^ mix64(cr.counter))
+}
+
+func (cr *CounterRand) NextFloat64() float64 {
+	return float64(cr.Next()>>11) * 0x1p-53
+}
+
+func (cr *CounterRand) Seed(s int64) {
+	cr.key = uint64(s)
+	cr.counter = 0
+}
+
+func (cr *CounterRand) GetSeed() int64 {
+	return int64(cr.key)
+}
+
+func (cr *CounterRand) Int63() int64 {
+	return int64(cr.Next() & math.MaxInt64)
+}
+
+func (cr *CounterRand) Uint64() uint64 {
+	return cr.Next()
+}
+
+// Derives the key of a counter-based stream from the iteration seed, the index
+// of the unit making the roll, or noUnitIndex, and the roll label.
+func makeCounterRandKey(rseed int64, unitIndex int32, label string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(label))
+	return mix64(mix64(uint64(rseed))^uint64(int64(unitIndex))) ^ h.Sum64()
+}
+
+// The SplitMix64 output function.
+func mix64(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -3,6 +3,8 @@ package core
 import (
 	"math"
 	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
 )
 
 func TestSplitMix64(t *testing.T) {
@@ -34,6 +36,77 @@ func TestSplitMix64(t *testing.T) {
 	t.Logf("chiSquare = %.1f", chiSquare)
 }
 
+func TestCounterRand(t *testing.T) {
+	x := NewCounterRand(1234567)
+	distribution := make([]int, 500)
+	n := 10_000_000
+	for i := 0; i < n; i++ {
+		f := x.NextFloat64()
+		if f < 0 || f >= 1 {
+			t.Fatalf("f = %f out of [0, 1)", f)
+		}
+		distribution[int(math.Trunc(f*500))]++
+	}
+	e := float64(n) / 500
+	var chiSquare float64
+	for _, v := range distribution {
+		chiSquare += (float64(v) - e) * (float64(v) - e) / e
+	}
+	if chiSquare > 540.93 {
+		t.Fatalf("fails chi-square (k = 500) at a = 0.1 (%.1f >= 540.93)", chiSquare)
+	}
+}
+
+func TestCounterRandLabelStreams(t *testing.T) {
+	newSim := func() *Simulation {
+		return &Simulation{
+			rand:         NewSplitMix(42),
+			Options:      &proto.SimOptions{RandomSeed: 42},
+			counterRands: true,
+			labelRands:   make(map[labelRandKey]Rand),
+		}
+	}
+
+	// Rolls of one label must not depend on rolls made with other labels.
+	a, b := newSim(), newSim()
+	for i := 0; i < 100; i++ {
+		b.RandomFloat("Other")
+		if fa, fb := a.RandomFloat("Proc"), b.RandomFloat("Proc"); fa != fb {
+			t.Fatalf("roll %d differs: %f != %f", i, fa, fb)
+		}
+	}
+
+	// Reseeding restarts each stream at the iteration's key.
+	a.reseedRands(1)
+	b.reseedRands(1)
+	if fa, fb := a.RandomFloat("Proc"), b.RandomFloat("Proc"); fa != fb {
+		t.Fatalf("rolls differ after reseed: %f != %f", fa, fb)
+	}
+}
+
+func TestCounterRandUnitStreams(t *testing.T) {
+	sim := &Simulation{
+		rand:         NewSplitMix(42),
+		Options:      &proto.SimOptions{RandomSeed: 42},
+		counterRands: true,
+		labelRands:   make(map[labelRandKey]Rand),
+	}
+	first, second := &Unit{UnitIndex: 1}, &Unit{UnitIndex: 2}
+
+	// Each unit has its own stream for a label, which rolls of other units don't advance.
+	firstRolls := []float64{sim.UnitRandomFloat(first, "Proc"), sim.UnitRandomFloat(first, "Proc")}
+	sim.reseedRands(0)
+	if f := sim.UnitRandomFloat(second, "Proc"); f == firstRolls[0] {
+		t.Fatalf("units share a stream: %f", f)
+	}
+	if f := sim.UnitRandomFloat(first, "Proc"); f != firstRolls[0] {
+		t.Fatalf("roll of the first unit changed after a roll of the second: %f != %f", f, firstRolls[0])
+	}
+	if f := sim.RandomFloat("Proc"); f == firstRolls[1] {
+		t.Fatalf("rolls without a unit share the first unit's stream: %f", f)
+	}
+}
+
 var result float64
 
 func BenchmarkRnds(b *testing.B) {
@@ -101,6 +174,20 @@ func BenchmarkRnds(b *testing.B) {
 		result += sum
 	})
 
+	cr := NewCounterRand(444)
+	b.Run("CounterRand", func(b *testing.B) {
+		b.ReportAllocs()
+		var sum float64
+		for i := 0; i < b.N; i++ {
+			sum += cr.NextFloat64()
+			sum += cr.NextFloat64()
+			sum += cr.NextFloat64()
+			sum += cr.NextFloat64()
+			sum += cr.NextFloat64()
+		}
+		result += sum
+	})
+
 	b.Run("Addition", func(b *testing.B) {
 		var sum float64
 		for i := 0; i < b.N; i++ {
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
 )
 
 type Task interface {
@@ -27,9 +28,10 @@ type Simulation struct {
 	rand  Rand
 	rseed int64
 
-	// Used for testing only, see RandomFloat().
-	isTest    bool
-	testRands map[string]Rand
+	// Used for testing and the counter RNG backend, see RandomFloat().
+	isTest       bool
+	counterRands bool
+	labelRands   map[labelRandKey]Rand
 
 	// Current Simulation State
 	pendingActions []*PendingAction
@@ -37,6 +39,9 @@ type Simulation struct {
 	Duration       time.Duration // Duration of current iteration
 	NeedsInput     bool          // Sim is in interactive mode and needs input
 
+	// Actions which can be reused, see newPooledAction().
+	actionPool []*PendingAction
+
 	ProgressReport func(*proto.ProgressMetrics)
 
 	Log func(string, ...interface{})
@@ -56,10 +61,27 @@ type Simulation struct {
 
 	minWeaponAttackTime time.Duration
 	weaponAttacks       []*WeaponAttack
-	extraAttacks int32
+	extraAttacks        int32
 
 	minTaskTime time.Duration
 	tasks       []Task
+
+	// Timeline metrics, see Options.TimelineBucketSeconds. The bucket size is 0
+	// if timelines are disabled.
+	timelineBucketSize time.Duration
+	timelineBucket     int
+	timelineBucketEnd  time.Duration
+
+	// Runs the paired perfect play sim for the given number of iterations, if any
+	// players have a skill model.
+	runPerfectPlay func(iterations int32) *proto.RaidSimResult
+
+	// Warnings about the request, which are included in the result.
+	validation *proto.ValidationResult
+
+	// Builds a Simulation for running blocks of iterations, see
+	// Options.NumThreads and runIterationsInBlocks.
+	newWorker func() *Simulation
 }
 
 func (sim *Simulation) rescheduleTracker(trackerTime time.Duration) {
@@ -110,6 +132,23 @@ func RunSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics) *pr
 }
 
 func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool) (result *proto.RaidSimResult) {
+	// Report invalid input as a list of problems, rather than whichever panic it
+	// would cause while building the sim.
+	validation := validateRaidSimRequest(rsr)
+	if len(validation.Errors) > 0 {
+		result = &proto.RaidSimResult{
+			Validation:  validation,
+			ErrorResult: validationErrorString(validation),
+		}
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{
+				FinalRaidResult: result,
+			}
+			close(progress)
+		}
+		return result
+	}
+
 	if !rsr.SimOptions.IsTest {
 		defer func() {
 			if err := recover(); err != nil {
@@ -138,7 +177,11 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 	}
 
 	sim := NewSim(rsr)
+	if len(validation.Warnings) > 0 {
+		sim.validation = validation
+	}
 
+	var presimResults []*proto.RaidSimResult
 	if !skipPresim {
 		if progress != nil {
 			progress <- &proto.ProgressMetrics{
@@ -147,15 +190,15 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 			}
 			runtime.Gosched() // allow time for message to make it back out.
 		}
-		presimResult := sim.runPresims(rsr)
-		if presimResult != nil && presimResult.ErrorResult != "" {
+		presimResults = sim.runPresims(rsr)
+		if n := len(presimResults); n > 0 && presimResults[n-1].ErrorResult != "" {
 			if progress != nil {
 				progress <- &proto.ProgressMetrics{
 					TotalIterations: sim.Options.Iterations,
-					FinalRaidResult: presimResult,
+					FinalRaidResult: presimResults[n-1],
 				}
 			}
-			return presimResult
+			return presimResults[n-1]
 		}
 		if progress != nil {
 			progress <- &proto.ProgressMetrics{
@@ -167,11 +210,17 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 			}
 			runtime.Gosched() // allow time for message to make it back out.
 		}
-		// Use pre-sim as estimate for length of fight (when using health fight)
-		if sim.Encounter.EndFightAtHealth > 0 && presimResult != nil {
-			sim.BaseDuration = time.Duration(presimResult.AvgIterationDuration) * time.Second
-			sim.Duration = time.Duration(presimResult.AvgIterationDuration) * time.Second
-			sim.Encounter.DurationIsEstimate = false // we now have a pretty good value for duration
+		if len(presimResults) > 0 {
+			sim.usePresimResult(presimResults[len(presimResults)-1])
+		}
+	}
+
+	sim.newWorker = newWorkerFunc(rsr, presimResults)
+
+	// Run a paired sim with perfect play, to measure the DPS lost to player skill models.
+	if hasPlayerSkill(rsr.Raid) {
+		sim.runPerfectPlay = func(iterations int32) *proto.RaidSimResult {
+			return runPerfectPlaySim(perfectPlayRequest(rsr, sim.rseed, iterations), presimResults)
 		}
 	}
 
@@ -181,6 +230,28 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 	return result
 }
 
+// Returns a builder for workers running the given request, see Simulation.newWorker.
+func newWorkerFunc(rsr *proto.RaidSimRequest, presimResults []*proto.RaidSimResult) func() *Simulation {
+	// Workers are built concurrently, and may still be building after the sim
+	// returns, so each one gets its own copy of the request.
+	sharedRsr := googleProto.Clone(rsr).(*proto.RaidSimRequest)
+	return func() *Simulation {
+		workerRsr := googleProto.Clone(sharedRsr).(*proto.RaidSimRequest)
+		worker := NewSim(workerRsr)
+		worker.replayPresims(workerRsr, presimResults)
+		return worker
+	}
+}
+
+func (sim *Simulation) usePresimResult(presimResult *proto.RaidSimResult) {
+	// Use pre-sim as estimate for length of fight (when using health fight)
+	if sim.Encounter.EndFightAtHealth > 0 && presimResult != nil {
+		sim.BaseDuration = time.Duration(presimResult.AvgIterationDuration) * time.Second
+		sim.Duration = time.Duration(presimResult.AvgIterationDuration) * time.Second
+		sim.Encounter.DurationIsEstimate = false // we now have a pretty good value for duration
+	}
+}
+
 func NewSim(rsr *proto.RaidSimRequest) *Simulation {
 	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
 	return newSimWithEnv(env, rsr.SimOptions)
@@ -192,16 +263,20 @@ func newSimWithEnv(env *Environment, simOptions *proto.SimOptions) *Simulation {
 		rseed = time.Now().UnixNano()
 	}
 
-	return &Simulation{
+	sim := &Simulation{
 		Environment: env,
 		Options:     simOptions,
 
 		rand:  NewSplitMix(uint64(rseed)),
 		rseed: rseed,
 
-		isTest:    simOptions.IsTest,
-		testRands: make(map[string]Rand),
+		isTest:       simOptions.IsTest,
+		counterRands: simOptions.RngBackend == proto.RngBackend_RngBackendCounter,
+		labelRands:   make(map[labelRandKey]Rand),
 	}
+	env.disableAuras(simOptions.DisabledAuras)
+	sim.initTimeline()
+	return sim
 }
 
 // Returns a random float64 between 0.0 (inclusive) and 1.0 (exclusive).
@@ -210,31 +285,77 @@ func newSimWithEnv(env *Environment, simOptions *proto.SimOptions) *Simulation {
 // sensitive to the exact order of RandomFloat() calls. To mitigate this, when
 // testing we use a separate rand object for each RandomFloat callsite,
 // distinguished by the label string.
+//
+// The counter RNG backend does the same outside of tests, using a counter-based
+// generator for each label.
 func (sim *Simulation) RandomFloat(label string) float64 {
-	return sim.labelRand(label).NextFloat64()
+	return sim.labelRand(nil, label).NextFloat64()
+}
+
+// Like RandomFloat, for a roll made by the unit. With the counter RNG backend,
+// each unit has its own stream for the label, so units with the same rolls
+// don't change each other's results.
+func (sim *Simulation) UnitRandomFloat(unit *Unit, label string) float64 {
+	return sim.labelRand(unit, label).NextFloat64()
 }
 
-func (sim *Simulation) labelRand(label string) Rand {
-	if !sim.isTest {
+// Streams of the counter RNG backend are per unit and label, while tests only
+// use the label, so that their results don't depend on the unit order.
+type labelRandKey struct {
+	unitIndex int32
+	label     string
+}
+
+const noUnitIndex = -1
+
+func (sim *Simulation) labelRand(unit *Unit, label string) Rand {
+	if !sim.counterRands && !sim.isTest {
 		return sim.rand
 	}
 
-	labelRng, ok := sim.testRands[label]
+	key := labelRandKey{unitIndex: noUnitIndex, label: label}
+	if sim.counterRands && unit != nil {
+		key.unitIndex = unit.UnitIndex
+	}
+
+	labelRng, ok := sim.labelRands[key]
 	if !ok {
 		// Add rseed to the label, so we still have run-run variance for stat weights.
-		labelRng = NewSplitMix(uint64(makeTestRandSeed(sim.rand.GetSeed(), label)))
-		sim.testRands[label] = labelRng
+		if sim.counterRands {
+			labelRng = NewCounterRand(makeCounterRandKey(sim.rand.GetSeed(), key.unitIndex, label))
+		} else {
+			labelRng = NewSplitMix(uint64(makeTestRandSeed(sim.rand.GetSeed(), label)))
+		}
+		sim.labelRands[key] = labelRng
 	}
 	return labelRng
 }
 
+// The Rand of one roll site, looked up once per Simulation instead of on every
+// roll. Reseeding keeps the Rands of labelRands, so the cached one stays valid.
+type labelRandCache struct {
+	label string
+	sim   *Simulation
+	rand  Rand
+}
+
+func (cache *labelRandCache) get(sim *Simulation, unit *Unit) Rand {
+	if cache.sim != sim {
+		cache.sim = sim
+		cache.rand = sim.labelRand(unit, cache.label)
+	}
+	return cache.rand
+}
+
 func (sim *Simulation) reseedRands(i int64) {
 	rseed := sim.Options.RandomSeed + i
 	sim.rand.Seed(rseed)
 
-	if sim.isTest {
-		for label, rng := range sim.testRands {
-			rng.Seed(makeTestRandSeed(rseed, label))
+	for key, rng := range sim.labelRands {
+		if sim.counterRands {
+			rng.Seed(int64(makeCounterRandKey(rseed, key.unitIndex, key.label)))
+		} else {
+			rng.Seed(makeTestRandSeed(rseed, key.label))
 		}
 	}
 }
@@ -244,7 +365,7 @@ func makeTestRandSeed(rseed int64, label string) int64 {
 }
 
 func (sim *Simulation) RandomExpFloat(label string) float64 {
-	return rand.New(sim.labelRand(label)).ExpFloat64()
+	return rand.New(sim.labelRand(nil, label)).ExpFloat64()
 }
 
 // Shorthand for commonly-used RNG behavior.
@@ -257,13 +378,18 @@ func (sim *Simulation) RollWithLabel(min float64, max float64, label string) flo
 }
 
 func (sim *Simulation) Proc(p float64, label string) bool {
+	return sim.UnitProc(nil, p, label)
+}
+
+// Like Proc, for a roll made by the unit, see UnitRandomFloat.
+func (sim *Simulation) UnitProc(unit *Unit, p float64, label string) bool {
 	switch {
 	case p >= 1:
 		return true
 	case p <= 0:
 		return false
 	default:
-		return sim.RandomFloat(label) < p
+		return sim.UnitRandomFloat(unit, label) < p
 	}
 }
 
@@ -294,35 +420,40 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	// }
 
 	sim.runOnce()
-	firstIterationDuration := sim.Duration
-	if sim.Encounter.EndFightAtHealth != 0 {
-		firstIterationDuration = sim.CurrentTime
-	}
+	firstIterationDuration := sim.iterationDuration()
 	totalDuration := firstIterationDuration
 
 	if !sim.Options.Debug {
 		sim.Log = nil
 	}
 
-	var st time.Time
-	for i := int32(1); i < sim.Options.Iterations; i++ {
-		// fmt.Printf("Iteration: %d\n", i)
-		if sim.ProgressReport != nil && time.Since(st) > time.Millisecond*100 {
-			metrics := sim.Raid.GetMetrics()
-			sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: i, Dps: metrics.Dps.Avg, Hps: metrics.Hps.Avg})
-			runtime.Gosched() // ensure that reporting threads are given time to report, mostly only important in wasm (only 1 thread)
-			st = time.Now()
-		}
+	iterations := sim.Options.Iterations
+	if sim.runsInBlocks() {
+		var blocksDuration time.Duration
+		blocksDuration, iterations = sim.runIterationsInBlocks()
+		totalDuration += blocksDuration
+	} else {
+		var st time.Time
+		for i := int32(1); i < sim.Options.Iterations; i++ {
+			// fmt.Printf("Iteration: %d\n", i)
+			if sim.ProgressReport != nil && time.Since(st) > time.Millisecond*100 {
+				metrics := sim.Raid.GetMetrics()
+				sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: i, Dps: metrics.Dps.Avg, Hps: metrics.Hps.Avg})
+				runtime.Gosched() // ensure that reporting threads are given time to report, mostly only important in wasm (only 1 thread)
+				st = time.Now()
+			}
 
-		// Before each iteration, reset state to seed+iterations
-		sim.reseedRands(int64(i))
+			// Before each iteration, reset state to seed+iterations
+			sim.reseedRands(int64(i))
 
-		sim.runOnce()
-		iterDuration := sim.Duration
-		if sim.Encounter.EndFightAtHealth != 0 {
-			iterDuration = sim.CurrentTime
+			sim.runOnce()
+			totalDuration += sim.iterationDuration()
+
+			if sim.reachedTargetPrecision(i + 1) {
+				iterations = i + 1
+				break
+			}
 		}
-		totalDuration += iterDuration
 	}
 	result := &proto.RaidSimResult{
 		RaidMetrics:      sim.Raid.GetMetrics(),
@@ -330,21 +461,45 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 
 		Logs:                   logsBuffer.String(),
 		FirstIterationDuration: firstIterationDuration.Seconds(),
-		AvgIterationDuration:   totalDuration.Seconds() / float64(sim.Options.Iterations),
+		AvgIterationDuration:   totalDuration.Seconds() / float64(iterations),
+		Iterations:             iterations,
+		DpsStderr:              sim.Raid.dpsMetrics.stderr(),
+		Validation:             sim.validation,
+	}
+
+	// The paired run uses the same number of iterations, even if this one stopped early.
+	if sim.runPerfectPlay != nil {
+		if perfectPlayResult := sim.runPerfectPlay(iterations); perfectPlayResult.ErrorResult == "" {
+			sim.Raid.addSkillDpsLoss(result.RaidMetrics, perfectPlayResult.RaidMetrics)
+		}
 	}
 
 	// Final progress report
 	if sim.ProgressReport != nil {
-		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: sim.Options.Iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
+		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: iterations, CompletedIterations: iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
 	}
 
-	if d := sim.Options.Iterations; d > 3000 {
+	if d := iterations; d > 3000 {
 		log.Printf("running %d iterations took %s", d, time.Since(t0))
 	}
 
 	return result
 }
 
+// Iterations between checks of SimOptions.TargetDpsStderr. This matches the
+// parallel block size, so parallel and sequential sims stop after the same
+// number of iterations.
+const precisionCheckInterval = parallelBlockSize
+
+// Returns whether the sim can stop early after the given number of iterations,
+// because the raid DPS is precise enough.
+func (sim *Simulation) reachedTargetPrecision(completedIterations int32) bool {
+	if sim.Options.TargetDpsStderr <= 0 || completedIterations <= 1 || (completedIterations-1)%precisionCheckInterval != 0 {
+		return false
+	}
+	return sim.Raid.dpsMetrics.stderr() < sim.Options.TargetDpsStderr
+}
+
 // RunOnce is the main event loop. It will run the simulation for number of seconds.
 func (sim *Simulation) runOnce() {
 	sim.reset()
@@ -375,6 +530,11 @@ func (sim *Simulation) reset() {
 		sim.Duration += time.Duration(sim.RandomFloat("sim duration")*float64(variation)) - sim.DurationVariation
 	}
 
+	for _, pa := range sim.pendingActions {
+		if pa.pooled {
+			sim.releasePooledAction(pa)
+		}
+	}
 	sim.pendingActions = sim.pendingActions[:0]
 	sim.pendingActions = append(sim.pendingActions, sentinelPendingAction)
 
@@ -401,6 +561,10 @@ func (sim *Simulation) reset() {
 	sim.tasks = sim.tasks[:0]
 	sim.minTaskTime = NeverExpires
 
+	if sim.timelineBucketSize != 0 {
+		sim.resetTimeline()
+	}
+
 	sim.Environment.reset(sim)
 
 	sim.initManaTickAction()
@@ -445,6 +609,10 @@ func (sim *Simulation) Cleanup() {
 		}
 	}
 
+	if sim.timelineBucketSize != 0 {
+		sim.finishTimeline()
+	}
+
 	sim.Raid.doneIteration(sim)
 	sim.Encounter.doneIteration(sim)
 
@@ -486,6 +654,9 @@ func (sim *Simulation) Step() bool {
 
 	sim.pendingActions = sim.pendingActions[:last]
 	if pa.cancelled {
+		if pa.pooled {
+			sim.releasePooledAction(pa)
+		}
 		return false
 	}
 
@@ -499,9 +670,15 @@ func (sim *Simulation) Step() bool {
 	pa.consumed = true
 
 	if pa.cancelled {
+		if pa.pooled {
+			sim.releasePooledAction(pa)
+		}
 		return false
 	}
 	pa.OnAction(sim)
+	if pa.pooled && pa.cancelled {
+		sim.releasePooledAction(pa)
+	}
 	return false
 }
 
@@ -529,6 +706,10 @@ func (sim *Simulation) advanceTasks() {
 
 // Advance moves time forward counting down auras, CDs, mana regen, etc
 func (sim *Simulation) advance(nextTime time.Duration) {
+	if sim.timelineBucketSize != 0 && nextTime > sim.timelineBucketEnd {
+		sim.advanceTimeline(nextTime)
+	}
+
 	sim.CurrentTime = nextTime
 
 	// this is a loop to handle duplicate ExecuteProportions, e.g. if they're all set to 100%, you reach
@@ -0,0 +1,283 @@
+package core
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Number of iterations in each block. Workers are reset between blocks, and
+// blocks are merged in order, so the results only depend on the block size and
+// not on the number of workers.
+const parallelBlockSize = 100
+
+// Sets the number of workers of each sim in request which doesn't set one, so
+// that e.g. the CLI and web server use all cores by default.
+func SetDefaultNumThreads(request googleProto.Message, numThreads int32) {
+	var raidSimRequests []*proto.RaidSimRequest
+	switch request := request.(type) {
+	case *proto.RaidSimRequest:
+		raidSimRequests = append(raidSimRequests, request)
+	case *proto.StatWeightsRequest:
+		if request.SimOptions != nil && request.SimOptions.NumThreads == 0 {
+			request.SimOptions.NumThreads = numThreads
+		}
+	case *proto.BuffAttributionRequest:
+		raidSimRequests = append(raidSimRequests, request.RaidSimRequest)
+	case *proto.CompareSimsRequest:
+		raidSimRequests = append(raidSimRequests, request.RequestA, request.RequestB)
+	case *proto.BulkSimRequest:
+		raidSimRequests = append(raidSimRequests, request.BaseSettings)
+	}
+	for _, rsr := range raidSimRequests {
+		if simOptions := rsr.GetSimOptions(); simOptions != nil && simOptions.NumThreads == 0 {
+			simOptions.NumThreads = numThreads
+		}
+	}
+}
+
+// Returns whether iterations after the first are run in blocks on workers.
+// Debug and interactive sims run all iterations on this sim instead, so they
+// can be logged and stepped through.
+func (sim *Simulation) runsInBlocks() bool {
+	return sim.newWorker != nil && !sim.Options.Debug && !sim.Options.Interactive
+}
+
+// Runs iterations [1, Options.Iterations) in blocks on Options.NumThreads worker
+// Simulations, merging their metrics into this sim. Without Options.NumThreads,
+// like in the wasm sim, the blocks run on a single worker on this goroutine, so
+// the results are the same as with any number of threads. Returns the total duration
+// of those iterations, and the total number of iterations including the first,
+// which is less than Options.Iterations if the sim stopped early.
+//
+// Must be called after the first iteration has been run on this sim.
+func (sim *Simulation) runIterationsInBlocks() (time.Duration, int32) {
+	numIterations := int(sim.Options.Iterations) - 1
+	if numIterations <= 0 {
+		return 0, sim.Options.Iterations
+	}
+	numBlocks := (numIterations + parallelBlockSize - 1) / parallelBlockSize
+	if sim.Options.NumThreads <= 0 {
+		return sim.runBlocksSequentially(numBlocks, numIterations)
+	}
+	numWorkers := min(int(sim.Options.NumThreads), numBlocks)
+
+	// Each worker is built once, then picks up blocks in increasing order, and
+	// waits for its block to be merged before starting the next one.
+	type blockResult struct {
+		worker   *Simulation
+		duration time.Duration
+		merged   chan struct{}
+	}
+	blocks := make([]chan *blockResult, numBlocks)
+	for i := range blocks {
+		blocks[i] = make(chan *blockResult, 1)
+	}
+	var nextBlock atomic.Int32
+	var completedIterations atomic.Int32
+	errs := make(chan string, numWorkers)
+	quit := make(chan struct{})
+	defer close(quit)
+
+	for w := 0; w < numWorkers; w++ {
+		go func() {
+			defer func() {
+				if err := recover(); err != nil {
+					errs <- fmt.Sprintf("%v\nStack Trace:\n%s", err, debug.Stack())
+				}
+			}()
+
+			var worker *Simulation
+			for {
+				block := int(nextBlock.Add(1) - 1)
+				if block >= numBlocks {
+					return
+				}
+
+				if worker == nil {
+					worker = sim.newBlockWorker()
+				} else {
+					worker.resetAggregates()
+				}
+				result := &blockResult{worker: worker, merged: make(chan struct{})}
+				result.duration = worker.runBlock(block, numIterations, &completedIterations)
+				blocks[block] <- result
+
+				select {
+				case <-result.merged:
+				case <-quit:
+					return
+				}
+			}
+		}()
+	}
+
+	var totalDuration time.Duration
+	ticker := time.NewTicker(time.Millisecond * 100)
+	defer ticker.Stop()
+	for block, blockChan := range blocks {
+		var result *blockResult
+		for result == nil {
+			select {
+			case result = <-blockChan:
+			case err := <-errs:
+				panic(err)
+			case <-ticker.C:
+				sim.reportBlockProgress(1 + completedIterations.Load())
+			}
+		}
+
+		sim.mergeAggregates(result.worker)
+		totalDuration += result.duration
+		close(result.merged)
+
+		if completed := blockEnd(block, numIterations); sim.reachedTargetPrecision(completed) {
+			return totalDuration, completed
+		}
+	}
+
+	return totalDuration, sim.Options.Iterations
+}
+
+func (sim *Simulation) runBlocksSequentially(numBlocks int, numIterations int) (time.Duration, int32) {
+	var totalDuration time.Duration
+	var completedIterations atomic.Int32
+	worker := sim.newBlockWorker()
+	for block := 0; block < numBlocks; block++ {
+		if block > 0 {
+			worker.resetAggregates()
+		}
+		totalDuration += worker.runBlock(block, numIterations, &completedIterations)
+		sim.mergeAggregates(worker)
+
+		completed := blockEnd(block, numIterations)
+		if sim.reachedTargetPrecision(completed) {
+			return totalDuration, completed
+		}
+		sim.reportBlockProgress(completed)
+	}
+	return totalDuration, sim.Options.Iterations
+}
+
+// Runs the iterations of the block on this worker, and returns their total duration.
+func (sim *Simulation) runBlock(block int, numIterations int, completedIterations *atomic.Int32) time.Duration {
+	var duration time.Duration
+	start := 1 + block*parallelBlockSize
+	for i := start; i < int(blockEnd(block, numIterations)); i++ {
+		sim.reseedRands(int64(i))
+		sim.runOnce()
+		duration += sim.iterationDuration()
+		completedIterations.Add(1)
+	}
+	return duration
+}
+
+// Returns the number of iterations completed after the block, including the first.
+func blockEnd(block int, numIterations int) int32 {
+	return int32(min(1+(block+1)*parallelBlockSize, 1+numIterations))
+}
+
+func (sim *Simulation) reportBlockProgress(completedIterations int32) {
+	if sim.ProgressReport == nil {
+		return
+	}
+	metrics := sim.Raid.GetMetrics()
+	sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: completedIterations, Dps: metrics.Dps.Avg, Hps: metrics.Hps.Avg})
+	runtime.Gosched() // ensure that reporting threads are given time to report, mostly only important in wasm (only 1 thread)
+}
+
+// Returns a new worker for running blocks of iterations.
+//
+// Some effects are only initialized during the first iteration, so the worker
+// first runs a throwaway iteration to match the state of this sim after its
+// first iteration. After that, each iteration only depends on its seed, so a
+// block gives the same results whichever blocks the worker ran before it.
+func (sim *Simulation) newBlockWorker() *Simulation {
+	worker := sim.newWorker()
+	worker.reseedRands(0)
+	worker.runOnce()
+	worker.resetAggregates()
+	worker.copyDurationEstimate(sim)
+	return worker
+}
+
+// Workers start after the first iteration, so they need the fight duration that
+// this sim estimated from it.
+func (sim *Simulation) copyDurationEstimate(from *Simulation) {
+	sim.BaseDuration = from.BaseDuration
+	sim.Encounter.DurationIsEstimate = from.Encounter.DurationIsEstimate
+	if from.Encounter.DurationIsEstimate && from.CurrentTime != 0 {
+		sim.BaseDuration = from.CurrentTime
+		sim.Encounter.DurationIsEstimate = false
+	}
+}
+
+func (sim *Simulation) iterationDuration() time.Duration {
+	if sim.Encounter.EndFightAtHealth != 0 {
+		return sim.CurrentTime
+	}
+	return sim.Duration
+}
+
+// Adds the aggregate metrics of other, which must be built from the same request.
+func (sim *Simulation) mergeAggregates(other *Simulation) {
+	sim.Raid.dpsMetrics.mergeAggregates(&other.Raid.dpsMetrics)
+	sim.Raid.hpsMetrics.mergeAggregates(&other.Raid.hpsMetrics)
+	for i, party := range sim.Raid.Parties {
+		party.dpsMetrics.mergeAggregates(&other.Raid.Parties[i].dpsMetrics)
+		party.hpsMetrics.mergeAggregates(&other.Raid.Parties[i].hpsMetrics)
+	}
+
+	for i, unit := range sim.AllUnits {
+		otherUnit := other.AllUnits[i]
+		unit.Metrics.mergeAggregates(&otherUnit.Metrics)
+
+		for j, otherAura := range otherUnit.auras {
+			var aura *Aura
+			if j < len(unit.auras) && unit.auras[j].Label == otherAura.Label {
+				aura = unit.auras[j]
+			} else {
+				aura = unit.workerAura(otherAura)
+			}
+			aura.metrics.mergeAggregates(&otherAura.metrics)
+		}
+	}
+}
+
+// Returns the aura of this unit with the label of the worker's aura. Auras can be
+// registered lazily, so if a worker registered one that this unit didn't, its
+// metrics are kept in workerAuras.
+func (at *auraTracker) workerAura(otherAura *Aura) *Aura {
+	if aura := at.GetAura(otherAura.Label); aura != nil {
+		return aura
+	}
+	for _, aura := range at.workerAuras {
+		if aura.Label == otherAura.Label {
+			return aura
+		}
+	}
+	aura := &Aura{Label: otherAura.Label, metrics: AuraMetrics{ID: otherAura.metrics.ID}}
+	at.workerAuras = append(at.workerAuras, aura)
+	return aura
+}
+
+func (sim *Simulation) resetAggregates() {
+	sim.Raid.dpsMetrics.resetAggregates()
+	sim.Raid.hpsMetrics.resetAggregates()
+	for _, party := range sim.Raid.Parties {
+		party.dpsMetrics.resetAggregates()
+		party.hpsMetrics.resetAggregates()
+	}
+
+	for _, unit := range sim.AllUnits {
+		unit.Metrics.resetAggregates()
+		for _, aura := range unit.auras {
+			aura.metrics.resetAggregates()
+		}
+	}
+}
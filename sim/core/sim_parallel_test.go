@@ -0,0 +1,120 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func TestDistributionMetricsMerge(t *testing.T) {
+	values := []float64{3, 1, 4, 1, 5, 9, 2, 6}
+
+	expected := NewDistributionMetrics()
+	for _, v := range values {
+		expected.add(v)
+	}
+
+	sim := &Simulation{Environment: &Environment{}, Options: &proto.SimOptions{}, rand: NewSplitMix(0), Duration: time.Second}
+	merged := NewDistributionMetrics()
+	for _, block := range [][]float64{values[:3], values[3:]} {
+		other := NewDistributionMetrics()
+		for _, v := range block {
+			other.Total = v
+			other.doneIteration(sim)
+		}
+		merged.mergeAggregates(&other)
+	}
+
+	if merged.aggregator != expected.aggregator || merged.max != 9 || merged.min != 1 || merged.hist[0] != 5 || merged.hist[10] != 3 {
+		t.Fatalf("Unexpected merged metrics %+v", merged)
+	}
+}
+
+func TestParallelIterationsMatchAcrossWorkerCounts(t *testing.T) {
+	rsr := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 350, RandomSeed: 100})
+
+	var expected *proto.RaidSimResult
+	for _, numThreads := range []int32{0, 1, 2, 4} {
+		rsr.SimOptions.NumThreads = numThreads
+		result := RunRaidSim(rsr)
+		if result.ErrorResult != "" {
+			t.Fatal(result.ErrorResult)
+		}
+		if result.RaidMetrics.Dps.Avg <= 0 {
+			t.Fatalf("Expected the caster to deal damage with %d workers", numThreads)
+		}
+		if expected == nil {
+			expected = result
+		} else if !googleProto.Equal(expected, result) {
+			t.Fatalf("Results with %d workers differ from running sequentially", numThreads)
+		}
+	}
+}
+
+func TestTargetDpsStderrStopsEarly(t *testing.T) {
+	// The stderr of the caster's DPS is about 0.94 after the first check, and
+	// 0.65 after the second.
+	const targetStderr = 0.7
+	rsr := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 1000, RandomSeed: 100, TargetDpsStderr: targetStderr})
+
+	var expected *proto.RaidSimResult
+	for _, numThreads := range []int32{0, 1, 3} {
+		rsr.SimOptions.NumThreads = numThreads
+		result := RunRaidSim(rsr)
+		if result.ErrorResult != "" {
+			t.Fatal(result.ErrorResult)
+		}
+		if result.Iterations != 1+2*precisionCheckInterval || result.DpsStderr >= targetStderr {
+			t.Fatalf("Expected the sim to stop after %d iterations, ran %d with stderr %f", 1+2*precisionCheckInterval, result.Iterations, result.DpsStderr)
+		}
+		if expected == nil {
+			expected = result
+		} else if !googleProto.Equal(expected, result) {
+			t.Fatalf("Results with %d workers differ from running sequentially", numThreads)
+		}
+	}
+}
+
+func TestMergeWorkerAuraMetrics(t *testing.T) {
+	rsr := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 1, RandomSeed: 100})
+	sim := NewSim(rsr)
+	worker := NewSim(rsr)
+
+	// Registered lazily by the worker, so the main sim has no matching aura.
+	lazyID := ActionID{SpellID: 12345}
+	workerUnit := worker.AllUnits[0]
+	workerUnit.auras = append(workerUnit.auras, &Aura{Label: "Lazy", metrics: AuraMetrics{ID: lazyID}})
+	lazyAura := workerUnit.auras[len(workerUnit.auras)-1]
+	lazyAura.metrics.Procs = 2
+	lazyAura.metrics.doneIteration()
+
+	sim.mergeAggregates(worker)
+	sim.mergeAggregates(worker)
+
+	idx := slices.IndexFunc(sim.AllUnits[0].auraTracker.GetMetricsProto(), func(metrics *proto.AuraMetrics) bool {
+		return googleProto.Equal(metrics.Id, lazyID.ToProto())
+	})
+	if idx < 0 {
+		t.Fatal("Expected metrics for the aura registered by the worker")
+	}
+	if procs := sim.AllUnits[0].auraTracker.GetMetricsProto()[idx].ProcsAvg; procs != 2 {
+		t.Fatalf("Expected 2 procs per iteration of the worker aura, got %f", procs)
+	}
+}
+
+func TestSetDefaultNumThreads(t *testing.T) {
+	unset := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 1})
+	explicit := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 1, NumThreads: 2})
+	request := &proto.CompareSimsRequest{RequestA: unset, RequestB: explicit}
+
+	SetDefaultNumThreads(request, 8)
+	if unset.SimOptions.NumThreads != 8 {
+		t.Fatalf("Expected the default of 8 workers, got %d", unset.SimOptions.NumThreads)
+	}
+	if explicit.SimOptions.NumThreads != 2 {
+		t.Fatalf("Expected the request's own 2 workers to be kept, got %d", explicit.SimOptions.NumThreads)
+	}
+}
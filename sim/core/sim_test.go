@@ -0,0 +1,80 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// A sim of the fake caster which already ran an iteration, so that its auras
+// and spells are initialized.
+func newBenchmarkSim() *Simulation {
+	sim := NewSim(fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 1, RandomSeed: 1}))
+	sim.runOnce()
+	return sim
+}
+
+func BenchmarkIteration(b *testing.B) {
+	sim := newBenchmarkSim()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sim.reseedRands(int64(i))
+		sim.runOnce()
+	}
+}
+
+// Each op is one Step(), including a share of the resets between iterations.
+func BenchmarkStep(b *testing.B) {
+	sim := newBenchmarkSim()
+	sim.reset()
+	sim.PrePull()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if sim.Step() {
+			sim.Cleanup()
+			sim.reset()
+			sim.PrePull()
+		}
+	}
+}
+
+func BenchmarkAdvance(b *testing.B) {
+	sim := newBenchmarkSim()
+	sim.reset()
+	sim.PrePull()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if sim.CurrentTime >= sim.Duration {
+			sim.Cleanup()
+			sim.reset()
+			sim.PrePull()
+		}
+		sim.advance(sim.CurrentTime + time.Millisecond*10)
+	}
+}
+
+// Adds actions at random times, keeping 16 of them queued.
+func BenchmarkPendingActions(b *testing.B) {
+	sim := newBenchmarkSim()
+	sim.pendingActions = []*PendingAction{sentinelPendingAction}
+	rand := NewSplitMix(1)
+	onAction := func(sim *Simulation) {}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pa := sim.newPooledAction()
+		pa.NextActionAt = time.Duration(rand.NextFloat64() * float64(time.Minute))
+		pa.OnAction = onAction
+		sim.AddPendingAction(pa)
+
+		if last := len(sim.pendingActions) - 1; last > 16 {
+			next := sim.pendingActions[last]
+			sim.pendingActions = sim.pendingActions[:last]
+			sim.releasePooledAction(next)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package simcache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// An LRU cache of serialized results, keyed by request hash, which can also be
+// backed by a directory on disk.
+//
+// Entries are namespaced by a version string, so results from an older build of
+// the sim are never returned. A Cache is safe for concurrent use.
+type Cache struct {
+	version    string
+	maxEntries int
+	dir        string
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // Most recently used first.
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type cacheEntry struct {
+	key   string
+	value []byte
+}
+
+// Returns a new cache which keeps up to maxEntries results in memory. If dir is
+// set, results are also written there and read back when they aren't in memory.
+func New(version string, maxEntries int, dir string) *Cache {
+	return &Cache{
+		version:    version,
+		maxEntries: maxEntries,
+		dir:        dir,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Returns the cached result for key, if there is one.
+func (cache *Cache) Get(key string) ([]byte, bool) {
+	if cache == nil || key == "" {
+		return nil, false
+	}
+	key = cache.versionedKey(key)
+
+	cache.mu.Lock()
+	if element, ok := cache.entries[key]; ok {
+		cache.lru.MoveToFront(element)
+		value := element.Value.(*cacheEntry).value
+		cache.mu.Unlock()
+		cache.hits.Add(1)
+		return value, true
+	}
+	cache.mu.Unlock()
+
+	if cache.dir != "" {
+		if value, err := os.ReadFile(filepath.Join(cache.dir, key)); err == nil {
+			cache.add(key, value)
+			cache.hits.Add(1)
+			return value, true
+		}
+	}
+
+	cache.misses.Add(1)
+	return nil, false
+}
+
+// Stores the result for key. The value must not be modified afterwards.
+func (cache *Cache) Put(key string, value []byte) error {
+	if cache == nil || key == "" {
+		return nil
+	}
+	key = cache.versionedKey(key)
+	cache.add(key, value)
+
+	if cache.dir != "" {
+		if err := os.MkdirAll(cache.dir, 0755); err != nil {
+			return err
+		}
+		// Write to a temporary file first, so concurrent readers never see a partial result.
+		tmpFile, err := os.CreateTemp(cache.dir, key+".tmp*")
+		if err != nil {
+			return err
+		}
+		_, err = tmpFile.Write(value)
+		if closeErr := tmpFile.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(tmpFile.Name(), filepath.Join(cache.dir, key))
+		}
+		if err != nil {
+			os.Remove(tmpFile.Name())
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the number of cache hits and misses so far.
+func (cache *Cache) Stats() (hits int64, misses int64) {
+	if cache == nil {
+		return 0, 0
+	}
+	return cache.hits.Load(), cache.misses.Load()
+}
+
+func (cache *Cache) add(key string, value []byte) {
+	if cache.maxEntries <= 0 {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if element, ok := cache.entries[key]; ok {
+		element.Value.(*cacheEntry).value = value
+		cache.lru.MoveToFront(element)
+		return
+	}
+	cache.entries[key] = cache.lru.PushFront(&cacheEntry{key: key, value: value})
+	for cache.lru.Len() > cache.maxEntries {
+		oldest := cache.lru.Back()
+		cache.lru.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// Combines the version with the key, which also makes it safe to use as a file name.
+func (cache *Cache) versionedKey(key string) string {
+	hash := sha256.Sum256([]byte(cache.version + "\x00" + key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,95 @@
+// Package simcache caches sim results by a hash of the request, so that identical
+// requests don't need to be simmed again.
+//
+// Only requests with a fixed random seed are cached, because results for a random
+// seed are expected to differ between runs.
+package simcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Returns a hash of a sim request, or "" if the results of the request shouldn't
+// be cached.
+//
+// The hash is exact: every field which is set is part of it, including debug and
+// timeline options, since they change what the result contains. The only
+// differences it ignores are an empty message in place of an unset one, and the
+// number of parallel workers. Scalar fields set to their default are already
+// the same as unset ones in proto3.
+//
+// The item database sent along with each player is part of the hash, since
+// its items may differ from the sim's own. Requests of different types never
+// share a hash.
+func RequestHash(request googleProto.Message) string {
+	request = googleProto.Clone(request)
+
+	var simOptions *proto.SimOptions
+	switch request := request.(type) {
+	case *proto.RaidSimRequest:
+		simOptions = request.SimOptions
+	case *proto.StatWeightsRequest:
+		simOptions = request.SimOptions
+	case *proto.BulkSimRequest:
+		simOptions = request.BaseSettings.GetSimOptions()
+	}
+	if simOptions.GetRandomSeed() == 0 {
+		return ""
+	}
+
+	// Any number of workers gives the same results, see SimOptions.num_threads.
+	simOptions.NumThreads = 0
+	clearEmptyMessages(request.ProtoReflect(), true)
+
+	data, err := googleProto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return ""
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(request.ProtoReflect().Descriptor().FullName()))
+	hash.Write([]byte{0})
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Clears the message fields of m which are set to an empty message, at any
+// depth. Fields of a oneof are kept, since they select e.g. the player's spec,
+// and so are the fields of the request itself if keepPresence is set, since a
+// request without a raid or encounter is invalid.
+func clearEmptyMessages(m protoreflect.Message, keepPresence bool) {
+	var emptyFields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			if fd.Message() != nil {
+				list := v.List()
+				for i := 0; i < list.Len(); i++ {
+					clearEmptyMessages(list.Get(i).Message(), false)
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					clearEmptyMessages(value.Message(), false)
+					return true
+				})
+			}
+		case fd.Message() != nil:
+			// Bulk sims nest a RaidSimRequest, whose fields are checked the same way.
+			clearEmptyMessages(v.Message(), fd.Message().FullName() == "proto.RaidSimRequest")
+			if !keepPresence && fd.ContainingOneof() == nil && googleProto.Size(v.Message().Interface()) == 0 {
+				emptyFields = append(emptyFields, fd)
+			}
+		}
+		return true
+	})
+	for _, fd := range emptyFields {
+		m.Clear(fd)
+	}
+}
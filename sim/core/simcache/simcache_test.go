@@ -0,0 +1,118 @@
+package simcache
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func newRequest(seed int64) *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{Players: []*proto.Player{{Name: "Player", Class: proto.Class_ClassMage}}}},
+		},
+		Encounter:  &proto.Encounter{Duration: 120},
+		SimOptions: &proto.SimOptions{Iterations: 1000, RandomSeed: seed},
+	}
+}
+
+func TestRequestHash(t *testing.T) {
+	hash := RequestHash(newRequest(1))
+	if hash == "" {
+		t.Fatalf("Expected a hash for a request with a fixed seed")
+	}
+
+	withDatabase := newRequest(1)
+	withDatabase.Raid.Parties[0].Players[0].Database = &proto.SimDatabase{Items: []*proto.SimItem{{Id: 1234}}}
+	if RequestHash(withDatabase) == hash {
+		t.Fatalf("Player database can override items, so it should affect the hash")
+	}
+	if withDatabase.Raid.Parties[0].Players[0].Database == nil {
+		t.Fatalf("Hashing should not modify the request")
+	}
+
+	if RequestHash(newRequest(2)) == hash {
+		t.Fatalf("Requests with different seeds should have different hashes")
+	}
+	if RequestHash(newRequest(0)) != "" {
+		t.Fatalf("Requests with a random seed should not be cached")
+	}
+	if RequestHash(&proto.BulkSimRequest{BaseSettings: newRequest(1)}) == hash {
+		t.Fatalf("Requests of different types should have different hashes")
+	}
+}
+
+func TestRequestHashIgnoredDifferences(t *testing.T) {
+	hash := RequestHash(newRequest(1))
+
+	equivalent := newRequest(1)
+	equivalent.Raid.Parties[0].Players[0].Consumes = &proto.Consumes{}
+	equivalent.Raid.Parties[0].Players[0].Equipment = &proto.EquipmentSpec{}
+	equivalent.Encounter.Targets = []*proto.Target{}
+	if RequestHash(equivalent) != hash {
+		t.Fatalf("Empty messages should hash the same as unset ones")
+	}
+	if equivalent.Raid.Parties[0].Players[0].Consumes == nil {
+		t.Fatalf("Hashing should not modify the request")
+	}
+
+	parallel := newRequest(1)
+	parallel.SimOptions.NumThreads = 2
+	moreParallel := newRequest(1)
+	moreParallel.SimOptions.NumThreads = 8
+	if RequestHash(parallel) != RequestHash(moreParallel) || RequestHash(parallel) != hash {
+		t.Fatalf("Requests with different numbers of workers should hash the same")
+	}
+
+	withDebug := newRequest(1)
+	withDebug.SimOptions.Debug = true
+	if RequestHash(withDebug) == hash {
+		t.Fatalf("Debug logs are part of the result, so debug options should affect the hash")
+	}
+
+	withSpec := newRequest(1)
+	withSpec.Raid.Parties[0].Players[0].Spec = &proto.Player_Mage{Mage: &proto.Mage{}}
+	if RequestHash(withSpec) == hash {
+		t.Fatalf("An empty spec still selects the spec, so it should affect the hash")
+	}
+
+	withoutEncounter := newRequest(1)
+	withoutEncounter.Encounter = nil
+	withEmptyEncounter := newRequest(1)
+	withEmptyEncounter.Encounter = &proto.Encounter{}
+	if RequestHash(withoutEncounter) == RequestHash(withEmptyEncounter) {
+		t.Fatalf("A request without an encounter is invalid, so it should not hash the same as an empty encounter")
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache := New("v1", 2, "")
+	cache.Put("a", []byte("1"))
+	cache.Put("b", []byte("2"))
+	cache.Get("a")
+	cache.Put("c", []byte("3"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Fatalf("Least recently used entry should have been evicted")
+	}
+	if value, ok := cache.Get("a"); !ok || string(value) != "1" {
+		t.Fatalf("Expected a cached value for a")
+	}
+	if hits, misses := cache.Stats(); hits != 2 || misses != 1 {
+		t.Fatalf("Unexpected stats: %d hits, %d misses", hits, misses)
+	}
+}
+
+func TestCacheDirectory(t *testing.T) {
+	dir := t.TempDir()
+	if err := New("v1", 0, dir).Put("a", []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, ok := New("v1", 0, dir).Get("a"); !ok || string(value) != "1" {
+		t.Fatalf("Expected the value to be read from disk")
+	}
+	if _, ok := New("v2", 0, dir).Get("a"); ok {
+		t.Fatalf("Results from other versions should not be returned")
+	}
+}
@@ -16,14 +16,20 @@ type SpellConfig struct {
 	// See definition of Spell (below) for comments on these.
 	ActionID
 	// Used to identify spells with multiple ranks that need to be referenced
-	SpellCode     int32
-	SpellSchool   SpellSchool
-	DefenseType   DefenseType
-	ProcMask      ProcMask
-	Flags         SpellFlag
-	CastType      proto.CastType
-	MissileSpeed  float64
-	BaseCost      float64
+	SpellCode    int32
+	SpellSchool  SpellSchool
+	DefenseType  DefenseType
+	ProcMask     ProcMask
+	Flags        SpellFlag
+	CastType     proto.CastType
+	MissileSpeed float64
+	BaseCost     float64
+
+	// AoE area, see Spell.AoeTargets().
+	AoeRadius float64
+	AoeArc    float64
+	AoeOrigin AoeOrigin
+
 	MetricSplits  int
 	Rank          int
 	RequiredLevel int
@@ -93,6 +99,14 @@ type Spell struct {
 	// Example: https://wow.tools/dbc/?dbc=spellmisc&build=3.4.0.44996
 	MissileSpeed float64
 
+	// Radius in yards of the area hit by an AoE spell, or 0 to hit all enemies.
+	AoeRadius float64
+	// Angle in degrees of a cone in front of the caster, for AoeOriginCaster
+	// spells. 0 hits the full circle.
+	AoeArc     float64
+	AoeOrigin  AoeOrigin
+	aoeTargets []*Unit
+
 	Rank          int
 	RequiredLevel int
 
@@ -217,6 +231,9 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 		Flags:        config.Flags,
 		CastType:     config.CastType,
 		MissileSpeed: config.MissileSpeed,
+		AoeRadius:    config.AoeRadius,
+		AoeArc:       config.AoeArc,
+		AoeOrigin:    config.AoeOrigin,
 
 		SpellSchool:       config.SpellSchool,
 		SchoolIndex:       config.SpellSchool.GetSchoolIndex(),
@@ -585,10 +602,14 @@ func (spell *Spell) CastTime() time.Duration {
 }
 
 func (spell *Spell) TravelTime() time.Duration {
+	return spell.TravelTimeTo(spell.Unit.CurrentTarget)
+}
+
+func (spell *Spell) TravelTimeTo(target *Unit) time.Duration {
 	if spell.MissileSpeed == 0 {
 		return 0
 	} else {
-		return time.Duration(float64(time.Second) * spell.Unit.DistanceFromTarget / spell.MissileSpeed)
+		return time.Duration(float64(time.Second) * spell.Unit.DistanceTo(target) / spell.MissileSpeed)
 	}
 }
 
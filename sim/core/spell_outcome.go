@@ -59,7 +59,7 @@ func (dot *Dot) OutcomeTickPhysicalCrit(sim *Simulation, result *SpellResult, at
 func (dot *Dot) OutcomeSnapshotCrit(sim *Simulation, result *SpellResult, attackTable *AttackTable) {
 	isPartialResist := result.DidResist()
 
-	if sim.RandomFloat("Snapshot Crit Roll") < dot.SnapshotCritChance {
+	if sim.UnitRandomFloat(dot.Spell.Unit, "Snapshot Crit Roll") < dot.SnapshotCritChance {
 		result.Outcome = OutcomeCrit
 		result.Damage *= dot.Spell.CritMultiplier(attackTable)
 		dot.Spell.SpellMetrics[result.Target.UnitIndex].CritTicks++
@@ -79,7 +79,7 @@ func (dot *Dot) OutcomeMagicHitAndSnapshotCrit(sim *Simulation, result *SpellRes
 	if dot.Spell.MagicHitCheck(sim, attackTable) {
 		isPartialResist := result.DidResist()
 
-		if sim.RandomFloat("Snapshot Crit Roll") < dot.SnapshotCritChance {
+		if sim.UnitRandomFloat(dot.Spell.Unit, "Snapshot Crit Roll") < dot.SnapshotCritChance {
 			result.Outcome = OutcomeCrit
 			result.Damage *= dot.Spell.CritMultiplier(attackTable)
 			dot.Spell.SpellMetrics[result.Target.UnitIndex].CritTicks++
@@ -253,8 +253,8 @@ func (spell *Spell) OutcomeMeleeWhiteNoHitCounter(sim *Simulation, result *Spell
 }
 func (spell *Spell) outcomeMeleeWhite(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
 	unit := spell.Unit
-	roll := sim.RandomFloat("White Hit Table")
-	glanceRoll := sim.RandomFloat("White Hit Glancing Penalty")
+	roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
+	glanceRoll := sim.UnitRandomFloat(spell.Unit, "White Hit Glancing Penalty")
 	chance := 0.0
 
 	if unit.PseudoStats.InFrontOfTarget {
@@ -284,7 +284,7 @@ func (spell *Spell) OutcomeMeleeSpecialHitNoHitCounter(sim *Simulation, result *
 }
 func (spell *Spell) outcomeMeleeSpecialHit(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
 	unit := spell.Unit
-	roll := sim.RandomFloat("White Hit Table")
+	roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 	chance := 0.0
 
 	if unit.PseudoStats.InFrontOfTarget {
@@ -309,7 +309,7 @@ func (spell *Spell) OutcomeMeleeSpecialHitAndCritNoHitCounter(sim *Simulation, r
 }
 func (spell *Spell) outcomeMeleeSpecialHitAndCrit(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
 	unit := spell.Unit
-	roll := sim.RandomFloat("White Hit Table")
+	roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 	chance := 0.0
 
 	if unit.PseudoStats.InFrontOfTarget {
@@ -343,7 +343,7 @@ func (spell *Spell) OutcomeMeleeWeaponSpecialHitAndCritNoHitCounter(sim *Simulat
 // Like OutcomeMeleeSpecialHitAndCrit, but blocks prevent crits (all weapon damage based attacks).
 func (spell *Spell) outcomeMeleeWeaponSpecialHitAndCrit(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
 	if spell.Unit.PseudoStats.InFrontOfTarget {
-		roll := sim.RandomFloat("White Hit Table")
+		roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 		chance := 0.0
 
 		if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance, countHits) &&
@@ -379,7 +379,7 @@ func (spell *Spell) OutcomeMeleeWeaponSpecialNoCritNoHitCounter(sim *Simulation,
 }
 func (spell *Spell) outcomeMeleeWeaponSpecialNoCrit(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
 	unit := spell.Unit
-	roll := sim.RandomFloat("White Hit Table")
+	roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 	chance := 0.0
 
 	if unit.PseudoStats.InFrontOfTarget {
@@ -417,7 +417,7 @@ func (spell *Spell) OutcomeMeleeSpecialNoDodgeParryNoHitCounter(sim *Simulation,
 	spell.outcomeMeleeSpecialNoDodgeParry(sim, result, attackTable, false)
 }
 func (spell *Spell) outcomeMeleeSpecialNoDodgeParry(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
-	roll := sim.RandomFloat("White Hit Table")
+	roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 	chance := 0.0
 
 	if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance, countHits) &&
@@ -447,7 +447,7 @@ func (spell *Spell) OutcomeMeleeSpecialNoBlockDodgeParryNoHitCounter(sim *Simula
 	spell.outcomeMeleeSpecialNoBlockDodgeParry(sim, result, attackTable, false)
 }
 func (spell *Spell) outcomeMeleeSpecialNoBlockDodgeParry(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
-	roll := sim.RandomFloat("White Hit Table")
+	roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 	chance := 0.0
 
 	if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance, countHits) &&
@@ -476,7 +476,7 @@ func (spell *Spell) OutcomeMeleeSpecialNoBlockDodgeParryNoCritNoHitCounter(sim *
 	spell.outcomeMeleeSpecialNoBlockDodgeParryNoCrit(sim, result, attackTable, false)
 }
 func (spell *Spell) outcomeMeleeSpecialNoBlockDodgeParryNoCrit(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
-	roll := sim.RandomFloat("White Hit Table")
+	roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 	chance := 0.0
 
 	if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance, countHits) {
@@ -516,7 +516,7 @@ func (spell *Spell) OutcomeRangedHitNoHitCounter(sim *Simulation, result *SpellR
 	spell.outcomeRangedHit(sim, result, attackTable, false)
 }
 func (spell *Spell) outcomeRangedHit(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
-	roll := sim.RandomFloat("White Hit Table")
+	roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 	chance := 0.0
 
 	if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance, countHits) {
@@ -531,7 +531,7 @@ func (spell *Spell) OutcomeRangedHitAndCritNoHitCounter(sim *Simulation, result
 	spell.outcomeRangedHitAndCrit(sim, result, attackTable, false)
 }
 func (spell *Spell) outcomeRangedHitAndCrit(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
-	roll := sim.RandomFloat("White Hit Table")
+	roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 	chance := 0.0
 
 	if spell.Unit.PseudoStats.InFrontOfTarget {
@@ -559,7 +559,7 @@ func (dot *Dot) OutcomeRangedHitAndCritSnapshotNoHitCounter(sim *Simulation, res
 	dot.outcomeRangedHitAndCritSnapshot(sim, result, attackTable, false)
 }
 func (dot *Dot) outcomeRangedHitAndCritSnapshot(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
-	roll := sim.RandomFloat("White Hit Table")
+	roll := sim.UnitRandomFloat(dot.Spell.Unit, "White Hit Table")
 	chance := 0.0
 
 	if dot.Spell.Unit.PseudoStats.InFrontOfTarget {
@@ -587,7 +587,7 @@ func (spell *Spell) OutcomeRangedHitAndCritNoBlockNoHitCounter(sim *Simulation,
 	spell.outcomeRangedHitAndCritNoBlock(sim, result, attackTable, false)
 }
 func (spell *Spell) outcomeRangedHitAndCritNoBlock(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
-	roll := sim.RandomFloat("White Hit Table")
+	roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 	chance := 0.0
 
 	if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance, countHits) &&
@@ -605,7 +605,7 @@ func (spell *Spell) OutcomeRangedCritOnlyNoHitCounter(sim *Simulation, result *S
 func (spell *Spell) outcomeRangedCritOnly(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
 	// Block already checks for this, but we can skip the RNG roll which is expensive.
 	if spell.Unit.PseudoStats.InFrontOfTarget {
-		roll := sim.RandomFloat("White Hit Table")
+		roll := sim.UnitRandomFloat(spell.Unit, "White Hit Table")
 		chance := 0.0
 
 		if result.applyAttackTableCritSeparateRoll(sim, spell, attackTable, countHits) {
@@ -629,7 +629,7 @@ func (spell *Spell) OutcomeEnemyMeleeWhiteNoHitCounter(sim *Simulation, result *
 	spell.outcomeEnemyMeleeWhite(sim, result, attackTable, false)
 }
 func (spell *Spell) outcomeEnemyMeleeWhite(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
-	roll := sim.RandomFloat("Enemy White Hit Table")
+	roll := sim.UnitRandomFloat(spell.Unit, "Enemy White Hit Table")
 	chance := 0.0
 
 	didHit := !result.applyEnemyAttackTableMiss(spell, attackTable, roll, &chance, countHits)
@@ -646,7 +646,7 @@ func (spell *Spell) outcomeEnemyMeleeWhite(sim *Simulation, result *SpellResult,
 }
 
 func (spell *Spell) fixedCritCheck(sim *Simulation, critChance float64) bool {
-	return sim.RandomFloat("Fixed Crit Roll") < critChance
+	return sim.UnitRandomFloat(spell.Unit, "Fixed Crit Roll") < critChance
 }
 
 func (result *SpellResult) applyAttackTableMiss(spell *Spell, attackTable *AttackTable, roll float64, chance *float64, countHits bool) bool {
@@ -786,7 +786,7 @@ func (result *SpellResult) applyAttackTableCritSeparateRoll(sim *Simulation, spe
 	return false
 }
 func (result *SpellResult) applyAttackTableCritSeparateRollSnapshot(sim *Simulation, dot *Dot, attackTable *AttackTable, countHits bool) bool {
-	if sim.RandomFloat("Physical Crit Roll") < dot.SnapshotCritChance {
+	if sim.UnitRandomFloat(dot.Spell.Unit, "Physical Crit Roll") < dot.SnapshotCritChance {
 		isPartialResist := result.DidResist()
 		result.Outcome = OutcomeCrit
 		result.Damage *= dot.Spell.CritMultiplier(attackTable)
@@ -37,7 +37,7 @@ func (spell *Spell) ResistanceMultiplier(sim *Simulation, isPeriodic bool, attac
 		return 1, OutcomeEmpty
 	}
 
-	resistanceRoll := sim.RandomFloat("Partial Resist")
+	resistanceRoll := sim.UnitRandomFloat(spell.Unit, "Partial Resist")
 
 	threshold00, threshold25, threshold50 := attackTable.GetPartialResistThresholds(spell, spell.Flags.Matches(SpellFlagPureDot))
 	//if sim.Log != nil {
@@ -121,7 +121,7 @@ func (spell *Spell) PhysicalCritChance(attackTable *AttackTable) float64 {
 	return critRating/(CritRatingPerCritChance*100) - attackTable.MeleeCritSuppression
 }
 func (spell *Spell) PhysicalCritCheck(sim *Simulation, attackTable *AttackTable) bool {
-	return sim.RandomFloat("Physical Crit Roll") < spell.PhysicalCritChance(attackTable)
+	return sim.UnitRandomFloat(spell.Unit, "Physical Crit Roll") < spell.PhysicalCritChance(attackTable)
 }
 
 // The combined bonus damage (aka spell power) for this spell's school(s).
@@ -200,7 +200,7 @@ func (spell *Spell) SpellChanceToMiss(attackTable *AttackTable) float64 {
 	return max(0.01, missChance)
 }
 func (spell *Spell) MagicHitCheck(sim *Simulation, attackTable *AttackTable) bool {
-	return sim.Proc(1.0-spell.SpellChanceToMiss(attackTable), "Magical Hit Roll")
+	return sim.UnitProc(spell.Unit, 1.0-spell.SpellChanceToMiss(attackTable), "Magical Hit Roll")
 }
 
 func (spell *Spell) spellCritRating(_ *Unit) float64 {
@@ -216,7 +216,7 @@ func (spell *Spell) SpellCritChance(target *Unit) float64 {
 }
 func (spell *Spell) MagicCritCheck(sim *Simulation, target *Unit) bool {
 	critChance := spell.SpellCritChance(target)
-	return sim.RandomFloat("Magical Crit Roll") < critChance
+	return sim.UnitRandomFloat(spell.Unit, "Magical Crit Roll") < critChance
 }
 
 func (spell *Spell) HealingPower(target *Unit) float64 {
@@ -233,7 +233,7 @@ func (spell *Spell) HealingCritChance() float64 {
 
 func (spell *Spell) HealingCritCheck(sim *Simulation) bool {
 	critChance := spell.HealingCritChance()
-	return sim.RandomFloat("Healing Crit Roll") < critChance
+	return sim.UnitRandomFloat(spell.Unit, "Healing Crit Roll") < critChance
 }
 
 func (spell *Spell) ApplyPostOutcomeDamageModifiers(sim *Simulation, result *SpellResult) {
@@ -576,9 +576,10 @@ func (dot *Dot) CalcAndDealPeriodicSnapshotHealing(sim *Simulation, target *Unit
 	return result
 }
 
-func (spell *Spell) WaitTravelTime(sim *Simulation, callback func(*Simulation)) {
+// Calls callback once this spell's projectile reaches target.
+func (spell *Spell) WaitTravelTime(sim *Simulation, target *Unit, callback func(*Simulation)) {
 	StartDelayedAction(sim, DelayedActionOptions{
-		DoAt:     sim.CurrentTime + spell.TravelTime(),
+		DoAt:     sim.CurrentTime + spell.TravelTimeTo(target),
 		OnAction: callback,
 	})
 }
@@ -149,6 +149,11 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 		return &StatWeightsResult{}
 	}
 
+	// The baseline may have stopped early, and the other sims need to run the same
+	// iterations to line up with it.
+	simOptions.Iterations = baselineResult.Iterations
+	simOptions.TargetDpsStderr = 0
+
 	var waitGroup sync.WaitGroup
 
 	// Do half the iterations with a positive, and half with a negative value for better accuracy.
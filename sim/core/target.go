@@ -30,15 +30,15 @@ type Encounter struct {
 }
 
 func NewEncounter(options *proto.Encounter) Encounter {
-	options.ExecuteProportion_25 = max(options.ExecuteProportion_25, options.ExecuteProportion_20)
-	options.ExecuteProportion_35 = max(options.ExecuteProportion_35, options.ExecuteProportion_25)
+	executeProportion_25 := max(options.ExecuteProportion_25, options.ExecuteProportion_20)
+	executeProportion_35 := max(options.ExecuteProportion_35, executeProportion_25)
 
 	encounter := Encounter{
 		Duration:             DurationFromSeconds(options.Duration),
 		DurationVariation:    DurationFromSeconds(options.DurationVariation),
 		ExecuteProportion_20: max(options.ExecuteProportion_20, 0),
-		ExecuteProportion_25: max(options.ExecuteProportion_25, 0),
-		ExecuteProportion_35: max(options.ExecuteProportion_35, 0),
+		ExecuteProportion_25: max(executeProportion_25, 0),
+		ExecuteProportion_35: max(executeProportion_35, 0),
 		Targets:              []*Target{},
 	}
 	// If UseHealth is set, we use the sum of targets health.
@@ -128,6 +128,8 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 			PseudoStats: stats.NewPseudoStats(),
 			Metrics:     NewUnitMetrics(),
 
+			StartPosition: PositionFromProto(options.Position),
+
 			StatDependencyManager: stats.NewStatDependencyManager(),
 		},
 	}
@@ -176,6 +178,9 @@ func (target *Target) GetMetricsProto() *proto.UnitMetrics {
 	metrics.Name = target.Label
 	metrics.UnitIndex = target.UnitIndex
 	metrics.Auras = target.auraTracker.GetMetricsProto()
+	if target.Metrics.timeline != nil {
+		metrics.Timeline = target.Metrics.timeline.ToProto(&target.Unit)
+	}
 	return metrics
 }
 
@@ -332,7 +332,7 @@ func (generator *ItemsTestGenerator) GetTest(testIdx int) (string, *proto.Comput
 	label := ""
 
 	playerCopy := googleProto.Clone(generator.Player).(*proto.Player)
-	equipment := ProtoToEquipment(playerCopy.Equipment)
+	equipment := NewDatabase(playerCopy.Database).ProtoToEquipment(playerCopy.Equipment)
 	if testIdx < len(generator.items) {
 		testItem := generator.items[testIdx]
 		equipment.EquipItem(generator.items[testIdx])
@@ -425,21 +425,85 @@ type CharacterSuiteConfig struct {
 	Cooldowns *proto.Cooldowns
 }
 
+// Fills in the default level and phase.
+func (config CharacterSuiteConfig) withDefaults() CharacterSuiteConfig {
+	config.Level = max(config.Level, 25)
+	if config.Phase == 0 {
+		switch config.Level {
+		case 25:
+			config.Phase = 1
+		case 40:
+			config.Phase = 2
+		case 50:
+			config.Phase = 3
+		case 60:
+			panic("You must provide a Phase for level 60 tests")
+		}
+	}
+	return config
+}
+
+func (config CharacterSuiteConfig) defaultPlayer() *proto.Player {
+	return WithSpec(
+		&proto.Player{
+			Class:         config.Class,
+			Level:         config.Level,
+			Race:          config.Race,
+			Equipment:     config.GearSet.GearSet,
+			Consumes:      config.Consumes.Consumes,
+			Buffs:         config.Buffs.Player,
+			TalentsString: config.Talents,
+			Profession1:   proto.Profession_Engineering,
+			Rotation:      config.Rotation.Rotation,
+
+			InFrontOfTarget:    config.InFrontOfTarget,
+			DistanceFromTarget: 5,
+			ReactionTimeMs:     150,
+			ChannelClipDelayMs: 50,
+		},
+		config.SpecOptions.SpecOptions)
+}
+
+func (config CharacterSuiteConfig) defaultRaid(defaultPlayer *proto.Player) *proto.Raid {
+	defaultRaid := SinglePlayerRaidProto(defaultPlayer, config.Buffs.Party, config.Buffs.Raid, config.Buffs.Debuffs)
+	if config.IsTank {
+		defaultRaid.Tanks = append(defaultRaid.Tanks, &proto.UnitReference{Type: proto.UnitReference_Player, Index: 0})
+	}
+	if config.IsHealer {
+		defaultRaid.TargetDummies = 1
+	}
+	return defaultRaid
+}
+
+// Returns the validation warnings for the config's default rotation, such as
+// APL lint warnings, each prefixed with its place in the rotation.
+func (config CharacterSuiteConfig) RotationWarnings() []string {
+	config = config.withDefaults()
+	result := ComputeStats(&proto.ComputeStatsRequest{
+		Raid: config.defaultRaid(config.defaultPlayer()),
+	})
+	if result.ErrorResult != "" {
+		return []string{result.ErrorResult}
+	}
+
+	var warnings []string
+	rotationStats := result.RaidStats.Parties[0].Players[0].RotationStats
+	for i, action := range rotationStats.PrepullActions {
+		for _, warning := range action.Warnings {
+			warnings = append(warnings, fmt.Sprintf("Prepull action %d: %s", i+1, warning))
+		}
+	}
+	for i, action := range rotationStats.PriorityList {
+		for _, warning := range action.Warnings {
+			warnings = append(warnings, fmt.Sprintf("Priority list entry %d: %s", i+1, warning))
+		}
+	}
+	return warnings
+}
+
 func FullCharacterTestSuiteGenerator(configs []CharacterSuiteConfig) []TestGenerator {
 	return MapSlice(configs, func(config CharacterSuiteConfig) TestGenerator {
-		config.Level = max(config.Level, 25)
-		if config.Phase == 0 {
-			switch config.Level {
-			case 25:
-				config.Phase = 1
-			case 40:
-				config.Phase = 2
-			case 50:
-				config.Phase = 3
-			case 60:
-				panic("You must provide a Phase for level 60 tests")
-			}
-		}
+		config = config.withDefaults()
 
 		allRaces := append(config.OtherRaces, config.Race)
 		allGearSets := append(config.OtherGearSets, config.GearSet)
@@ -451,32 +515,8 @@ func FullCharacterTestSuiteGenerator(configs []CharacterSuiteConfig) []TestGener
 		allRotations := append(config.OtherRotations, config.Rotation)
 		allConsumeOptions := append(config.OtherConsumes, config.Consumes)
 
-		defaultPlayer := WithSpec(
-			&proto.Player{
-				Class:         config.Class,
-				Level:         config.Level,
-				Race:          config.Race,
-				Equipment:     config.GearSet.GearSet,
-				Consumes:      config.Consumes.Consumes,
-				Buffs:         config.Buffs.Player,
-				TalentsString: config.Talents,
-				Profession1:   proto.Profession_Engineering,
-				Rotation:      config.Rotation.Rotation,
-
-				InFrontOfTarget:    config.InFrontOfTarget,
-				DistanceFromTarget: 5,
-				ReactionTimeMs:     150,
-				ChannelClipDelayMs: 50,
-			},
-			config.SpecOptions.SpecOptions)
-
-		defaultRaid := SinglePlayerRaidProto(defaultPlayer, config.Buffs.Party, config.Buffs.Raid, config.Buffs.Debuffs)
-		if config.IsTank {
-			defaultRaid.Tanks = append(defaultRaid.Tanks, &proto.UnitReference{Type: proto.UnitReference_Player, Index: 0})
-		}
-		if config.IsHealer {
-			defaultRaid.TargetDummies = 1
-		}
+		defaultPlayer := config.defaultPlayer()
+		defaultRaid := config.defaultRaid(defaultPlayer)
 
 		// Ensure we don't generate tests where the agent equips items above its level
 		// This previously caused bugs with effects with a specified minimum level above the agent's level
@@ -38,6 +38,9 @@ func (testSuite *IndividualTestSuite) TestCharacterStats(testName string, csr *p
 	testSuite.testNames = append(testSuite.testNames, testName)
 
 	result := ComputeStats(csr)
+	if result.ErrorResult != "" {
+		panic("computing stats failed: " + result.ErrorResult)
+	}
 	finalStats := stats.FromFloatArray(result.RaidStats.Parties[0].Players[0].FinalStats.Stats)
 
 	testSuite.testResults.CharacterStatsResults[testName] = &proto.CharacterStatsTestResult{
@@ -560,6 +560,36 @@ func RaidBenchmark(b *testing.B, rsr *proto.RaidSimRequest) {
 	}
 }
 
+// Benchmarks the event loop with the default settings of each config. Each op is
+// one iteration, so ns/op and allocs/op measure the cost of a single iteration;
+// building the sim is not included.
+func RunCharacterBenchmarks(b *testing.B, configs []CharacterSuiteConfig) {
+	for _, config := range configs {
+		config = config.withDefaults()
+		rsr := &proto.RaidSimRequest{
+			Raid:      config.defaultRaid(config.defaultPlayer()),
+			Encounter: MakeSingleTargetEncounter(config.Level, 0),
+			SimOptions: &proto.SimOptions{
+				Iterations: 1,
+				RandomSeed: 101,
+			},
+		}
+
+		b.Run(makeGeneratorName(config.SpecOptions.Label, config.Phase, config.Level), func(b *testing.B) {
+			sim := NewSim(rsr)
+			// The first iteration allocates state which is reused afterwards.
+			sim.runOnce()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sim.reseedRands(int64(i + 1))
+				sim.runOnce()
+			}
+		})
+	}
+}
+
 func GetAplRotation(dir string, file string) RotationCombo {
 	filePath := dir + "/" + file + ".apl.json"
 	data, err := os.ReadFile(filePath)
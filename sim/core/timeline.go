@@ -0,0 +1,301 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Resources tracked in timelines, if the unit has them.
+var timelineResources = []struct {
+	Type   proto.ResourceType
+	has    func(*Unit) bool
+	amount func(*Unit) float64
+}{
+	{proto.ResourceType_ResourceTypeMana, (*Unit).HasManaBar, (*Unit).CurrentMana},
+	{proto.ResourceType_ResourceTypeRage, (*Unit).HasRageBar, (*Unit).CurrentRage},
+	{proto.ResourceType_ResourceTypeEnergy, (*Unit).HasEnergyBar, (*Unit).CurrentEnergy},
+	{proto.ResourceType_ResourceTypeFocus, (*Unit).HasFocusBar, (*Unit).CurrentFocus},
+}
+
+// Metrics for each time bucket of the encounter, summed across iterations.
+// Only collected if SimOptions.TimelineBucketSeconds is set.
+type timelineMetrics struct {
+	bucketSize time.Duration
+
+	// Pets whose damage is included in this unit's DPS, as in the dps metrics.
+	pets []*Unit
+
+	// Values at the end of the previous bucket in the current iteration, which
+	// are subtracted to get the values for each bucket.
+	lastDamage      float64
+	lastAuraUptimes []time.Duration
+	lastDotUptime   time.Duration
+
+	// Aggregate values, indexed by bucket.
+	iterations []int32
+	seconds    []float64 // Total length of each bucket, which is shorter than the bucket size at the end of a fight.
+	damage     []float64
+	resources  [][]float64
+	auras      []*auraTimeline
+	dotUptime  []float64
+
+	// Timeline of each of the unit's auras, indexed like unit.auras.
+	unitAuras []*auraTimeline
+}
+
+// Uptime of an aura in each bucket. Auras can be registered lazily, so workers
+// may have them in a different order, and timelines are merged by aura label.
+type auraTimeline struct {
+	label  string
+	id     ActionID
+	uptime []float64
+}
+
+func newTimelineMetrics(bucketSize time.Duration) *timelineMetrics {
+	return &timelineMetrics{
+		bucketSize: bucketSize,
+		resources:  make([][]float64, len(timelineResources)),
+	}
+}
+
+func (timeline *timelineMetrics) reset() {
+	timeline.lastDamage = 0
+	clear(timeline.lastAuraUptimes)
+	timeline.lastDotUptime = 0
+}
+
+// Records the bucket with the given index, which ended at endTime.
+func (timeline *timelineMetrics) sample(unit *Unit, bucket int, endTime time.Duration, length time.Duration) {
+	if bucket >= len(timeline.iterations) {
+		timeline.grow(bucket + 1)
+	}
+	timeline.iterations[bucket]++
+	timeline.seconds[bucket] += length.Seconds()
+
+	damage := unit.damageDealt()
+	for _, pet := range timeline.pets {
+		damage += pet.damageDealt()
+	}
+	timeline.damage[bucket] += damage - timeline.lastDamage
+	timeline.lastDamage = damage
+
+	for i, resource := range timelineResources {
+		if resource.has(unit) {
+			timeline.resources[i][bucket] += resource.amount(unit)
+		}
+	}
+
+	for len(timeline.unitAuras) < len(unit.auras) {
+		aura := unit.auras[len(timeline.unitAuras)]
+		timeline.unitAuras = append(timeline.unitAuras, timeline.auraTimeline(aura.Label, aura.ActionID))
+		timeline.lastAuraUptimes = append(timeline.lastAuraUptimes, 0)
+	}
+	for i, aura := range unit.auras {
+		if aura.ActionID.IsEmptyAction() {
+			continue
+		}
+		uptime := aura.uptimeAt(endTime)
+		timeline.unitAuras[i].uptime[bucket] += (uptime - timeline.lastAuraUptimes[i]).Seconds()
+		timeline.lastAuraUptimes[i] = uptime
+	}
+
+	var dotUptime time.Duration
+	for _, spell := range unit.Spellbook {
+		for _, dot := range spell.dots {
+			if dot != nil {
+				dotUptime += dot.uptimeAt(endTime)
+			}
+		}
+		if spell.aoeDot != nil {
+			dotUptime += spell.aoeDot.uptimeAt(endTime)
+		}
+	}
+	timeline.dotUptime[bucket] += (dotUptime - timeline.lastDotUptime).Seconds()
+	timeline.lastDotUptime = dotUptime
+}
+
+// Returns the timeline of the aura with the given label, adding it if needed.
+func (timeline *timelineMetrics) auraTimeline(label string, id ActionID) *auraTimeline {
+	for _, aura := range timeline.auras {
+		if aura.label == label {
+			return aura
+		}
+	}
+	aura := &auraTimeline{label: label, id: id, uptime: make([]float64, len(timeline.iterations))}
+	timeline.auras = append(timeline.auras, aura)
+	return aura
+}
+
+func (timeline *timelineMetrics) grow(numBuckets int) {
+	extend := func(values []float64) []float64 {
+		return append(values, make([]float64, numBuckets-len(values))...)
+	}
+	timeline.iterations = append(timeline.iterations, make([]int32, numBuckets-len(timeline.iterations))...)
+	timeline.seconds = extend(timeline.seconds)
+	timeline.damage = extend(timeline.damage)
+	for i := range timeline.resources {
+		timeline.resources[i] = extend(timeline.resources[i])
+	}
+	for _, aura := range timeline.auras {
+		aura.uptime = extend(aura.uptime)
+	}
+	timeline.dotUptime = extend(timeline.dotUptime)
+}
+
+func (timeline *timelineMetrics) mergeAggregates(other *timelineMetrics) {
+	if len(other.iterations) > len(timeline.iterations) {
+		timeline.grow(len(other.iterations))
+	}
+
+	for bucket := range other.iterations {
+		timeline.iterations[bucket] += other.iterations[bucket]
+		timeline.seconds[bucket] += other.seconds[bucket]
+		timeline.damage[bucket] += other.damage[bucket]
+		for i := range timeline.resources {
+			timeline.resources[i][bucket] += other.resources[i][bucket]
+		}
+		timeline.dotUptime[bucket] += other.dotUptime[bucket]
+	}
+
+	for _, otherAura := range other.auras {
+		aura := timeline.auraTimeline(otherAura.label, otherAura.id)
+		for bucket, uptime := range otherAura.uptime {
+			aura.uptime[bucket] += uptime
+		}
+	}
+}
+
+func (timeline *timelineMetrics) resetAggregates() {
+	pets := timeline.pets
+	*timeline = *newTimelineMetrics(timeline.bucketSize)
+	timeline.pets = pets
+}
+
+func (timeline *timelineMetrics) ToProto(unit *Unit) *proto.TimelineMetrics {
+	bySeconds := func(values []float64) []float64 {
+		averages := make([]float64, len(values))
+		for bucket, value := range values {
+			if timeline.seconds[bucket] > 0 {
+				averages[bucket] = value / timeline.seconds[bucket]
+			}
+		}
+		return averages
+	}
+
+	protoTimeline := &proto.TimelineMetrics{
+		BucketSeconds: timeline.bucketSize.Seconds(),
+		Dps:           bySeconds(timeline.damage),
+		ActiveDots:    bySeconds(timeline.dotUptime),
+	}
+
+	for i, resource := range timelineResources {
+		if !resource.has(unit) {
+			continue
+		}
+		amounts := make([]float64, len(timeline.iterations))
+		for bucket, total := range timeline.resources[i] {
+			if timeline.iterations[bucket] > 0 {
+				amounts[bucket] = total / float64(timeline.iterations[bucket])
+			}
+		}
+		protoTimeline.Resources = append(protoTimeline.Resources, &proto.ResourceTimeline{
+			Type:   resource.Type,
+			Amount: amounts,
+		})
+	}
+
+	for _, aura := range timeline.auras {
+		if aura.id.IsEmptyAction() {
+			continue
+		}
+		// Skip auras which were never active, to keep the results small.
+		if !slices.ContainsFunc(aura.uptime, func(uptime float64) bool { return uptime > 0 }) {
+			continue
+		}
+		protoTimeline.Auras = append(protoTimeline.Auras, &proto.AuraTimeline{
+			Id:     aura.id.ToProto(),
+			Uptime: bySeconds(aura.uptime),
+		})
+	}
+
+	return protoTimeline
+}
+
+// Returns the total time this aura has been active in the current iteration, as
+// of time t. Auras may expire lazily, so this doesn't count time past the
+// aura's expiration.
+func (aura *Aura) uptimeAt(t time.Duration) time.Duration {
+	uptime := aura.metrics.Uptime
+	if aura.active {
+		uptime += max(0, min(t, aura.expires)-max(aura.startTime, 0))
+	}
+	return uptime
+}
+
+// Returns the damage dealt by this unit to its opponents so far in the current
+// iteration. Spell metrics are only added to the unit metrics at the end of the
+// iteration, so this sums them directly.
+func (unit *Unit) damageDealt() float64 {
+	var damage float64
+	for _, spell := range unit.Spellbook {
+		for i, spellMetrics := range spell.SpellMetrics {
+			if unit.IsOpponent(unit.Env.AllUnits[i]) {
+				damage += spellMetrics.TotalDamage
+			}
+		}
+	}
+	return damage
+}
+
+// Enables timeline metrics for all units, if requested by the sim options.
+func (sim *Simulation) initTimeline() {
+	if sim.Options.TimelineBucketSeconds <= 0 {
+		return
+	}
+	sim.timelineBucketSize = DurationFromSeconds(sim.Options.TimelineBucketSeconds)
+	for _, unit := range sim.AllUnits {
+		unit.Metrics.timeline = newTimelineMetrics(sim.timelineBucketSize)
+	}
+	for _, party := range sim.Raid.Parties {
+		for _, player := range party.Players {
+			character := player.GetCharacter()
+			for _, pet := range character.Pets {
+				character.Metrics.timeline.pets = append(character.Metrics.timeline.pets, &pet.Unit)
+			}
+		}
+	}
+}
+
+func (sim *Simulation) resetTimeline() {
+	sim.timelineBucket = 0
+	sim.timelineBucketEnd = sim.timelineBucketSize
+}
+
+// Records all buckets which end before t. Events at the end of a bucket, like
+// the last ones of the fight, still count towards it.
+func (sim *Simulation) advanceTimeline(t time.Duration) {
+	for sim.timelineBucketEnd < t {
+		sim.sampleTimeline(sim.timelineBucketEnd)
+	}
+}
+
+// Records the current bucket as ending at endTime, and starts the next one.
+func (sim *Simulation) sampleTimeline(endTime time.Duration) {
+	startTime := sim.timelineBucketEnd - sim.timelineBucketSize
+	for _, unit := range sim.AllUnits {
+		unit.Metrics.timeline.sample(unit, sim.timelineBucket, endTime, endTime-startTime)
+	}
+	sim.timelineBucket++
+	sim.timelineBucketEnd += sim.timelineBucketSize
+}
+
+// Records the remaining buckets at the end of an iteration, the last of which may
+// be partial.
+func (sim *Simulation) finishTimeline() {
+	sim.advanceTimeline(sim.Duration)
+	if startTime := sim.timelineBucketEnd - sim.timelineBucketSize; sim.Duration > startTime {
+		sim.sampleTimeline(sim.Duration)
+	}
+}
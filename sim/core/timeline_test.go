@@ -0,0 +1,82 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestTimelineMatchesDps(t *testing.T) {
+	rsr := fakeCasterRaidSimRequest(&proto.SimOptions{Iterations: 100, RandomSeed: 100})
+	// Every bucket is then a full 2 seconds in every iteration.
+	rsr.Encounter.DurationVariation = 0
+
+	expected := RunRaidSim(rsr).RaidMetrics.Parties[0].Players[0]
+
+	rsr.SimOptions.TimelineBucketSeconds = 2
+	result := RunRaidSim(rsr)
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+	player := result.RaidMetrics.Parties[0].Players[0]
+	if player.Dps.Avg <= 0 {
+		t.Fatalf("Expected the caster to deal damage")
+	}
+	if player.Dps.Avg != expected.Dps.Avg {
+		t.Fatalf("Timelines changed the results: %f, expected %f", player.Dps.Avg, expected.Dps.Avg)
+	}
+
+	timeline := player.Timeline
+	if timeline == nil || len(timeline.Dps) != 30 {
+		t.Fatalf("Expected a timeline with 30 buckets")
+	}
+	var bucketDamage float64
+	for _, dps := range timeline.Dps {
+		bucketDamage += dps * timeline.BucketSeconds
+	}
+
+	var totalDamage float64
+	for _, action := range player.Actions {
+		for _, target := range action.Targets {
+			totalDamage += target.Damage
+		}
+	}
+	totalDamage /= float64(result.Iterations)
+
+	if math.Abs(bucketDamage-totalDamage) > 1e-6*totalDamage {
+		t.Fatalf("Timeline damage %f does not match the damage of all actions %f", bucketDamage, totalDamage)
+	}
+}
+
+func TestTimelineMergesAurasByLabel(t *testing.T) {
+	unit := &Unit{}
+	timeline := newTimelineMetrics(time.Second)
+	timeline.auraTimeline("A", ActionID{SpellID: 1})
+	timeline.auraTimeline("B", ActionID{SpellID: 2})
+	timeline.grow(1)
+	timeline.iterations[0], timeline.seconds[0] = 1, 1
+	timeline.auras[0].uptime[0], timeline.auras[1].uptime[0] = 1, 0.5
+
+	// The worker registered the auras in a different order, and one more.
+	worker := newTimelineMetrics(time.Second)
+	worker.auraTimeline("C", ActionID{SpellID: 3})
+	worker.auraTimeline("B", ActionID{SpellID: 2})
+	worker.auraTimeline("A", ActionID{SpellID: 1})
+	worker.grow(1)
+	worker.iterations[0], worker.seconds[0] = 1, 1
+	worker.auras[0].uptime[0], worker.auras[1].uptime[0], worker.auras[2].uptime[0] = 0.5, 0.5, 0
+
+	timeline.mergeAggregates(worker)
+
+	uptimes := make(map[int32]float64)
+	for _, aura := range timeline.ToProto(unit).Auras {
+		uptimes[aura.Id.GetSpellId()] = aura.Uptime[0]
+	}
+	for spellID, expected := range map[int32]float64{1: 0.5, 2: 0.5, 3: 0.25} {
+		if uptimes[spellID] != expected {
+			t.Fatalf("Expected an uptime of %f for aura %d, got %f", expected, spellID, uptimes[spellID])
+		}
+	}
+}
@@ -56,14 +56,23 @@ type Unit struct {
 	// Amount of time following a post-GCD channel tick, to when the next action can be performed.
 	ChannelClipDelay time.Duration
 
+	// Imperfections of the human agent, applied by the APL rotation.
+	Skill PlayerSkill
+
 	// How far this unit is from its target(s). Measured in yards, this is used
 	// for calculating spell travel time for certain spells.
 	StartDistanceFromTarget float64
 	DistanceFromTarget      float64
-	Moving                  bool
-	moveAura                *Aura
-	moveSpell               *Spell
-	MoveSpeed               float64
+
+	// Position of this unit, in yards. Only used if Env.UsePositions is set.
+	StartPosition    Vector2
+	Position         Vector2
+	hasStartPosition bool
+
+	Moving    bool
+	moveAura  *Aura
+	moveSpell *Spell
+	MoveSpeed float64
 
 	// Environment in which this Unit exists. This will be nil until after the
 	// construction phase.
@@ -456,6 +465,11 @@ func (unit *Unit) MoveTo(moveRange float64, sim *Simulation) {
 	moveTicks := math.Abs(moveDistance)
 	moveInterval := moveDistance / float64(moveTicks)
 
+	var awayFromTarget Vector2
+	if unit.Env.UsePositions {
+		awayFromTarget = unit.awayFromTarget()
+	}
+
 	unit.moveSpell.Cast(sim, unit.CurrentTarget)
 
 	sim.AddPendingAction(NewPeriodicAction(sim, PeriodicActionOptions{
@@ -465,6 +479,10 @@ func (unit *Unit) MoveTo(moveRange float64, sim *Simulation) {
 
 		OnAction: func(sim *Simulation) {
 			unit.DistanceFromTarget += moveInterval
+			unit.Metrics.YardsMoved += math.Abs(moveInterval)
+			if unit.Env.UsePositions {
+				unit.Position = unit.CurrentTarget.Position.Add(awayFromTarget.Scale(unit.DistanceFromTarget))
+			}
 			unit.moveAura.SetStacks(sim, int32(unit.DistanceFromTarget))
 
 			if unit.DistanceFromTarget == moveRange {
@@ -543,6 +561,7 @@ func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	}
 
 	unit.DistanceFromTarget = unit.StartDistanceFromTarget
+	unit.Position = unit.StartPosition
 
 	unit.manaBar.reset()
 	unit.focusBar.reset(sim)
@@ -0,0 +1,331 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Collects the problems found in a request, so they can all be reported at
+// once instead of panicking on the first one partway through building the sim.
+type requestValidator struct {
+	result *proto.ValidationResult
+}
+
+func newRequestValidator() *requestValidator {
+	return &requestValidator{
+		result: &proto.ValidationResult{},
+	}
+}
+
+func (v *requestValidator) addError(issueType proto.ValidationIssueType, path string, format string, args ...any) {
+	v.result.Errors = append(v.result.Errors, &proto.ValidationIssue{
+		Type:    issueType,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *requestValidator) addWarning(issueType proto.ValidationIssueType, path string, format string, args ...any) {
+	v.result.Warnings = append(v.result.Warnings, &proto.ValidationIssue{
+		Type:    issueType,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func validateRaidSimRequest(rsr *proto.RaidSimRequest) *proto.ValidationResult {
+	v := newRequestValidator()
+	if rsr.Raid == nil {
+		v.addError(proto.ValidationIssueType_ValidationMissingField, "raid", "No raid was provided.")
+	} else {
+		v.validateRaid("raid", rsr.Raid)
+	}
+	if rsr.Encounter == nil {
+		v.addError(proto.ValidationIssueType_ValidationMissingField, "encounter", "No encounter was provided.")
+	}
+	if rsr.SimOptions == nil {
+		v.addError(proto.ValidationIssueType_ValidationMissingField, "sim_options", "No sim options were provided.")
+	} else if rsr.SimOptions.Iterations < 0 {
+		v.addError(proto.ValidationIssueType_ValidationInvalidValue, "sim_options.iterations", "Iterations must not be negative, got %d.", rsr.SimOptions.Iterations)
+	}
+	return v.result
+}
+
+func validateComputeStatsRequest(csr *proto.ComputeStatsRequest) *proto.ValidationResult {
+	v := newRequestValidator()
+	if csr.Raid == nil {
+		v.addError(proto.ValidationIssueType_ValidationMissingField, "raid", "No raid was provided.")
+	} else {
+		v.validateRaid("raid", csr.Raid)
+	}
+	return v.result
+}
+
+// Formats the errors for an error_result field.
+func validationErrorString(result *proto.ValidationResult) string {
+	lines := make([]string, 0, len(result.Errors)+1)
+	lines = append(lines, "Invalid request:")
+	for _, issue := range result.Errors {
+		lines = append(lines, fmt.Sprintf("%s: %s", issue.Path, issue.Message))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (v *requestValidator) validateRaid(path string, raid *proto.Raid) {
+	// Same as NewRaid, which ignores the parties past NumActiveParties.
+	numParties := int(raid.NumActiveParties)
+	if numParties == 0 {
+		numParties = len(raid.Parties)
+	}
+
+	for partyIndex, party := range raid.Parties {
+		if party == nil || partyIndex >= numParties {
+			continue
+		}
+		for playerIndex, player := range party.Players {
+			if player != nil && player.Class != proto.Class_ClassUnknown {
+				v.validatePlayer(fmt.Sprintf("%s.parties[%d].players[%d]", path, partyIndex, playerIndex), player)
+			}
+		}
+	}
+}
+
+func (v *requestValidator) validatePlayer(path string, player *proto.Player) {
+	// Items, enchants, runes and suffixes may come from the player's own database.
+	database := NewDatabase(player.Database)
+	if player.Database != nil {
+		v.validateDatabase(path+".database", player.Database)
+	}
+
+	if player.Spec == nil {
+		v.addError(proto.ValidationIssueType_ValidationMissingField, path+".spec", "No spec was provided.")
+	}
+	if player.Level < 0 || player.Level > CharacterMaxLevel {
+		v.addError(proto.ValidationIssueType_ValidationInvalidValue, path+".level", "Level must be between 1 and %d, got %d.", CharacterMaxLevel, player.Level)
+	}
+
+	if player.Equipment != nil {
+		v.validateEquipment(path+".equipment", player, database)
+	}
+}
+
+func (v *requestValidator) validateDatabase(path string, simDB *proto.SimDatabase) {
+	for i, item := range simDB.Items {
+		procNames := make(map[string]bool)
+		for j, proc := range item.Procs {
+			procPath := fmt.Sprintf("%s.items[%d].procs[%d]", path, i, j)
+			// Procs without a name use the item's, and each one makes auras named after it.
+			name := proc.Name
+			if name == "" {
+				name = item.Name
+			}
+			if procNames[name] {
+				v.addError(proto.ValidationIssueType_ValidationInvalidValue, procPath+".name", "Procs of item %d need different names, %q is used twice.", item.Id, name)
+			}
+			procNames[name] = true
+			v.validateProcEffect(procPath, proc)
+		}
+	}
+}
+
+// Returns an error describing each problem with a proc, like the validation
+// of requests does for the procs of their items.
+func ValidateProcEffect(proc *proto.ProcEffect) error {
+	v := newRequestValidator()
+	v.validateProcEffect("proc", proc)
+	if len(v.result.Errors) == 0 {
+		return nil
+	}
+	messages := MapSlice(v.result.Errors, func(issue *proto.ValidationIssue) string {
+		return fmt.Sprintf("%s: %s", issue.Path, issue.Message)
+	})
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+func (v *requestValidator) validateProcEffect(path string, proc *proto.ProcEffect) {
+	isUse := proc.Trigger == proto.ProcTrigger_ProcTriggerUse || proc.Trigger == proto.ProcTrigger_ProcTriggerDefensiveUse
+	hasStats := slices.ContainsFunc(proc.Stats, func(value float64) bool { return value != 0 })
+	hasDamage := proc.DamageMax > 0
+
+	if proc.Trigger == proto.ProcTrigger_ProcTriggerUnknown {
+		v.addError(proto.ValidationIssueType_ValidationMissingField, path+".trigger", "No trigger was provided.")
+	}
+	if isUse {
+		if proc.CooldownSeconds <= 0 {
+			v.addError(proto.ValidationIssueType_ValidationInvalidValue, path+".cooldown_seconds", "Cooldown must be positive, got %g.", proc.CooldownSeconds)
+		}
+	} else if proc.Ppm < 0 || proc.ProcChance < 0 || proc.ProcChance > 1 {
+		v.addError(proto.ValidationIssueType_ValidationInvalidValue, path+".proc_chance", "Proc chance must be between 0 and 1 and PPM must not be negative, got %g and %g.", proc.ProcChance, proc.Ppm)
+	} else if proc.Ppm == 0 && proc.ProcChance == 0 {
+		v.addError(proto.ValidationIssueType_ValidationMissingField, path+".proc_chance", "No proc chance or PPM was provided.")
+	}
+
+	if hasStats == hasDamage {
+		v.addError(proto.ValidationIssueType_ValidationInvalidValue, path+".stats", "A proc must either give stats or deal damage.")
+	}
+	if hasStats && proc.DurationSeconds <= 0 {
+		v.addError(proto.ValidationIssueType_ValidationInvalidValue, path+".duration_seconds", "Duration must be positive, got %g.", proc.DurationSeconds)
+	}
+	if proc.MaxStacks < 0 || (proc.MaxStacks > 1 && !hasStats) {
+		v.addError(proto.ValidationIssueType_ValidationInvalidValue, path+".max_stacks", "Only procs which give stats can stack, got %d stacks.", proc.MaxStacks)
+	}
+	if hasDamage {
+		if isUse {
+			v.addError(proto.ValidationIssueType_ValidationInvalidValue, path+".trigger", "Damage can't be dealt on use, only by procs.")
+		}
+		if proc.DamageMin < 0 || proc.DamageMin > proc.DamageMax {
+			v.addError(proto.ValidationIssueType_ValidationInvalidValue, path+".damage_min", "Damage must be between 0 and damage_max, got %g to %g.", proc.DamageMin, proc.DamageMax)
+		}
+	}
+}
+
+func (v *requestValidator) validateEquipment(path string, player *proto.Player, database *Database) {
+	items := player.Equipment.Items
+	if numSlots := len(EquipmentSpec{}); len(items) > numSlots {
+		v.addError(proto.ValidationIssueType_ValidationInvalidValue, path+".items", "There are only %d item slots, got %d items.", numSlots, len(items))
+		items = items[:numSlots]
+	}
+
+	var equipped Equipment
+	uniqueItemPaths := make(map[int32]string)
+	for i, itemSpec := range items {
+		if itemSpec == nil || itemSpec.Id == 0 {
+			continue
+		}
+		slot := proto.ItemSlot(i)
+		itemPath := fmt.Sprintf("%s.items[%d]", path, i)
+
+		item, ok := database.LookupItem(itemSpec.Id)
+		if !ok {
+			v.addError(proto.ValidationIssueType_ValidationUnknownItem, itemPath+".id", "No item with id %d.", itemSpec.Id)
+			continue
+		}
+		equipped[slot] = item
+
+		// The sim equips items by their type, so they still end up in a valid slot.
+		if !slices.Contains(eligibleSlotsForItem(item), slot) {
+			v.addWarning(proto.ValidationIssueType_ValidationWrongSlot, itemPath+".id", "%s can't be equipped in the %s slot, so it's equipped based on its type.", item.Name, slotName(slot))
+		}
+		if len(item.ClassAllowlist) > 0 && !slices.Contains(item.ClassAllowlist, player.Class) {
+			v.addError(proto.ValidationIssueType_ValidationClassRestricted, itemPath+".id", "%s can't be used by %s.", item.Name, className(player.Class))
+		}
+		if item.RequiresLevel > player.Level {
+			v.addError(proto.ValidationIssueType_ValidationLevelRestricted, itemPath+".id", "%s requires level %d.", item.Name, item.RequiresLevel)
+		}
+		if item.Unique {
+			if otherPath, ok := uniqueItemPaths[item.ID]; ok {
+				v.addWarning(proto.ValidationIssueType_ValidationDuplicateUnique, itemPath+".id", "%s is unique, but is also equipped at %s.", item.Name, otherPath)
+			} else {
+				uniqueItemPaths[item.ID] = itemPath
+			}
+		}
+
+		if itemSpec.RandomSuffix != 0 {
+			if _, ok := database.LookupRandomSuffix(itemSpec.RandomSuffix); !ok {
+				v.addError(proto.ValidationIssueType_ValidationUnknownRandomSuffix, itemPath+".random_suffix", "No random suffix with id %d.", itemSpec.RandomSuffix)
+			}
+		}
+		if itemSpec.Enchant != 0 {
+			v.validateEnchant(itemPath+".enchant", player, database, item, itemSpec.Enchant)
+		}
+		if itemSpec.Rune != 0 {
+			v.validateRune(itemPath+".rune", player, database, item, itemSpec.Rune)
+		}
+	}
+
+	mainHand, offHand := equipped.MainHand(), equipped.OffHand()
+	if mainHand.HandType == proto.HandType_HandTypeTwoHand && offHand.ID != 0 {
+		v.addError(proto.ValidationIssueType_ValidationInvalidWeaponCombo, fmt.Sprintf("%s.items[%d].id", path, proto.ItemSlot_ItemSlotOffHand),
+			"%s can't be equipped together with the two-hander %s.", offHand.Name, mainHand.Name)
+	}
+}
+
+func (v *requestValidator) validateEnchant(path string, player *proto.Player, database *Database, item Item, effectID int32) {
+	variants := database.lookupEnchantVariants(effectID)
+	if len(variants) == 0 {
+		v.addWarning(proto.ValidationIssueType_ValidationUnknownEnchant, path, "No enchant with id %d, so it is ignored.", effectID)
+		return
+	}
+	// Enchants from older databases don't say which items they apply to.
+	if slices.ContainsFunc(variants, func(enchant Enchant) bool { return enchant.Type == proto.ItemType_ItemTypeUnknown }) {
+		return
+	}
+
+	index := slices.IndexFunc(variants, func(enchant Enchant) bool { return enchantAppliesToItem(enchant, item) })
+	if index == -1 {
+		v.addError(proto.ValidationIssueType_ValidationIneligibleEnchant, path, "Enchant %d can't be applied to %s.", effectID, item.Name)
+		return
+	}
+
+	enchant := variants[index]
+	if len(enchant.ClassAllowlist) > 0 && !slices.Contains(enchant.ClassAllowlist, player.Class) {
+		v.addError(proto.ValidationIssueType_ValidationClassRestricted, path, "Enchant %d can't be used by %s.", effectID, className(player.Class))
+	}
+	if enchant.RequiresLevel > player.Level {
+		v.addError(proto.ValidationIssueType_ValidationLevelRestricted, path, "Enchant %d requires level %d.", effectID, enchant.RequiresLevel)
+	}
+}
+
+// See enchantAppliesToItem in proto_utils/utils.ts.
+func enchantAppliesToItem(enchant Enchant, item Item) bool {
+	var enchantSlots []proto.ItemSlot
+	for _, itemType := range append([]proto.ItemType{enchant.Type}, enchant.ExtraTypes...) {
+		if itemType == proto.ItemType_ItemTypeWeapon {
+			enchantSlots = append(enchantSlots, proto.ItemSlot_ItemSlotMainHand, proto.ItemSlot_ItemSlotOffHand)
+		} else {
+			enchantSlots = append(enchantSlots, itemTypeToSlotsMap[itemType]...)
+		}
+	}
+	itemSlots := eligibleSlotsForItem(item)
+	if !slices.ContainsFunc(itemSlots, func(slot proto.ItemSlot) bool { return slices.Contains(enchantSlots, slot) }) {
+		return false
+	}
+
+	if enchant.EnchantType == proto.EnchantType_EnchantTypeTwoHand && item.HandType != proto.HandType_HandTypeTwoHand {
+		return false
+	}
+	if (enchant.EnchantType == proto.EnchantType_EnchantTypeShield) != (item.WeaponType == proto.WeaponType_WeaponTypeShield) {
+		return false
+	}
+	if enchant.EnchantType == proto.EnchantType_EnchantTypeStaff && item.WeaponType != proto.WeaponType_WeaponTypeStaff {
+		return false
+	}
+	if item.WeaponType == proto.WeaponType_WeaponTypeOffHand {
+		return false
+	}
+	if slices.Contains(itemSlots, proto.ItemSlot_ItemSlotRanged) {
+		return slices.Contains([]proto.RangedWeaponType{proto.RangedWeaponType_RangedWeaponTypeBow, proto.RangedWeaponType_RangedWeaponTypeCrossbow, proto.RangedWeaponType_RangedWeaponTypeGun}, item.RangedWeaponType)
+	}
+	return true
+}
+
+func (v *requestValidator) validateRune(path string, player *proto.Player, database *Database, item Item, runeID int32) {
+	rune, ok := database.LookupRune(runeID)
+	if !ok {
+		v.addWarning(proto.ValidationIssueType_ValidationUnknownRune, path, "No rune with id %d, so it is ignored.", runeID)
+		return
+	}
+
+	// Like items in the wrong slot, the sim still applies these.
+	if rune.Type != item.Type {
+		v.addWarning(proto.ValidationIssueType_ValidationIneligibleRune, path, "%s can't be engraved on %s, so it's applied anyway.", rune.Name, item.Name)
+	}
+	if len(rune.ClassAllowlist) > 0 && !slices.Contains(rune.ClassAllowlist, player.Class) {
+		v.addError(proto.ValidationIssueType_ValidationClassRestricted, path, "%s can't be used by %s.", rune.Name, className(player.Class))
+	}
+	if rune.RequiresLevel > player.Level {
+		v.addError(proto.ValidationIssueType_ValidationLevelRestricted, path, "%s requires level %d.", rune.Name, rune.RequiresLevel)
+	}
+}
+
+func slotName(slot proto.ItemSlot) string {
+	return strings.TrimPrefix(slot.String(), "ItemSlot")
+}
+
+func className(class proto.Class) string {
+	return strings.TrimPrefix(class.String(), "Class")
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestValidateRaidSimRequest(t *testing.T) {
+	const (
+		twoHander     = 990101
+		shield        = 990102
+		warlockHelm   = 990103
+		uniqueRing    = 990104
+		highLevelBelt = 990105
+		cloakEnchant  = 990106
+		unknownRune   = 990107
+		ringRune      = 990108
+		warriorRune   = 990109
+		highLevelRune = 990110
+	)
+
+	equipment := createEquipmentFromItems(
+		&itemWithSlot{Item: &proto.ItemSpec{Id: warlockHelm, Enchant: cloakEnchant, Rune: highLevelRune}, Slot: proto.ItemSlot_ItemSlotHead},
+		&itemWithSlot{Item: &proto.ItemSpec{Id: highLevelBelt, Enchant: 999999}, Slot: proto.ItemSlot_ItemSlotWaist},
+		&itemWithSlot{Item: &proto.ItemSpec{Id: uniqueRing, Rune: ringRune}, Slot: proto.ItemSlot_ItemSlotFinger1},
+		&itemWithSlot{Item: &proto.ItemSpec{Id: uniqueRing, Rune: warriorRune}, Slot: proto.ItemSlot_ItemSlotFinger2},
+		&itemWithSlot{Item: &proto.ItemSpec{Id: twoHander, Rune: ringRune}, Slot: proto.ItemSlot_ItemSlotMainHand},
+		&itemWithSlot{Item: &proto.ItemSpec{Id: shield, Rune: unknownRune}, Slot: proto.ItemSlot_ItemSlotOffHand},
+	)
+
+	validation := ValidateRaidSimRequest(&proto.RaidSimRequest{
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassMage,
+			Level:     40,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_Mage{},
+			Equipment: equipment,
+			Database: &proto.SimDatabase{
+				Items: []*proto.SimItem{
+					{Id: twoHander, Name: "Staff Of Testing", Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeStaff, HandType: proto.HandType_HandTypeTwoHand},
+					{Id: shield, Name: "Shield Of Testing", Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeShield, HandType: proto.HandType_HandTypeOffHand},
+					{Id: warlockHelm, Name: "Warlock Helm Of Testing", Type: proto.ItemType_ItemTypeHead, ClassAllowlist: []proto.Class{proto.Class_ClassWarlock}},
+					{Id: uniqueRing, Name: "Ring Of Testing", Type: proto.ItemType_ItemTypeFinger, Unique: true, Procs: []*proto.ProcEffect{{ProcChance: 0.5, Stats: []float64{5}}}},
+					{Id: highLevelBelt, Name: "Belt Of Testing", Type: proto.ItemType_ItemTypeWaist, RequiresLevel: 50},
+				},
+				Enchants: []*proto.SimEnchant{
+					{EffectId: cloakEnchant, Type: proto.ItemType_ItemTypeBack},
+				},
+				Runes: []*proto.SimRune{
+					{Id: ringRune, Name: "Ring Rune Of Testing", Type: proto.ItemType_ItemTypeFinger},
+					{Id: warriorRune, Name: "Warrior Rune Of Testing", Type: proto.ItemType_ItemTypeFinger, ClassAllowlist: []proto.Class{proto.Class_ClassWarrior}},
+					{Id: highLevelRune, Name: "Helm Rune Of Testing", Type: proto.ItemType_ItemTypeHead, RequiresLevel: 50},
+				},
+			},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{},
+	})
+
+	format := func(issues []*proto.ValidationIssue) []string {
+		formatted := MapSlice(issues, func(issue *proto.ValidationIssue) string {
+			return fmt.Sprintf("%s %s", issue.Type, issue.Path)
+		})
+		slices.Sort(formatted)
+		return formatted
+	}
+
+	expectedErrors := []string{
+		"ValidationClassRestricted raid.parties[0].players[0].equipment.items[0].id",
+		"ValidationClassRestricted raid.parties[0].players[0].equipment.items[11].rune",
+		"ValidationIneligibleEnchant raid.parties[0].players[0].equipment.items[0].enchant",
+		"ValidationInvalidValue raid.parties[0].players[0].database.items[3].procs[0].duration_seconds",
+		"ValidationInvalidWeaponCombo raid.parties[0].players[0].equipment.items[15].id",
+		"ValidationLevelRestricted raid.parties[0].players[0].equipment.items[0].rune",
+		"ValidationLevelRestricted raid.parties[0].players[0].equipment.items[7].id",
+		"ValidationMissingField raid.parties[0].players[0].database.items[3].procs[0].trigger",
+		"ValidationMissingField sim_options",
+	}
+	if errors := format(validation.Errors); !slices.Equal(errors, expectedErrors) {
+		t.Errorf("Expected errors:\n%v\ngot:\n%v", expectedErrors, errors)
+	}
+
+	expectedWarnings := []string{
+		"ValidationDuplicateUnique raid.parties[0].players[0].equipment.items[11].id",
+		"ValidationIneligibleRune raid.parties[0].players[0].equipment.items[14].rune",
+		"ValidationUnknownEnchant raid.parties[0].players[0].equipment.items[7].enchant",
+		"ValidationUnknownRune raid.parties[0].players[0].equipment.items[15].rune",
+	}
+	if warnings := format(validation.Warnings); !slices.Equal(warnings, expectedWarnings) {
+		t.Errorf("Expected warnings:\n%v\ngot:\n%v", expectedWarnings, warnings)
+	}
+}
+
+func TestRunRaidSimReportsValidationErrors(t *testing.T) {
+	result := RunRaidSim(&proto.RaidSimRequest{
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Class:     proto.Class_ClassMage,
+			Level:     40,
+			Spec:      &proto.Player_Mage{},
+			Equipment: createEquipmentFromItems(&itemWithSlot{Item: &proto.ItemSpec{Id: 990199}, Slot: proto.ItemSlot_ItemSlotHead}),
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter:  &proto.Encounter{},
+		SimOptions: &proto.SimOptions{Iterations: 1},
+	})
+
+	if result.ErrorResult == "" || len(result.Validation.GetErrors()) != 1 {
+		t.Fatalf("Expected a validation error, got %v", result)
+	}
+	if issue := result.Validation.Errors[0]; issue.Type != proto.ValidationIssueType_ValidationUnknownItem {
+		t.Fatalf("Expected an unknown item error, got %v", issue)
+	}
+}
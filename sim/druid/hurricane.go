@@ -42,11 +42,15 @@ func (druid *Druid) registerHurricaneSpell() {
 		}
 
 		damage := rank.damage + float64(min(druid.Level, rank.scaleLevel)-rank.level)*rank.scale
+		// Hurricane keeps hitting the area it was cast on.
+		var aoeCenter core.Vector2
+
 		spell := druid.RegisterSpell(Humanoid|Moonkin, core.SpellConfig{
 			ActionID:    core.ActionID{SpellID: rank.spellID},
 			SpellSchool: core.SpellSchoolNature,
 			ProcMask:    core.ProcMaskSpellDamage,
 			Flags:       SpellFlagOmen | core.SpellFlagChanneled | core.SpellFlagBinary | core.SpellFlagAPL,
+			AoeRadius:   10,
 
 			RequiredLevel: int(rank.level),
 			Rank:          i + 1,
@@ -79,13 +83,14 @@ func (druid *Druid) registerHurricaneSpell() {
 					dot.Snapshot(target, damage, isRollover)
 				},
 				OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-					for _, aoeTarget := range sim.Encounter.TargetUnits {
+					for _, aoeTarget := range dot.Spell.AoeTargetsAt(aoeCenter) {
 						dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 					}
 				},
 			},
 
-			ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				aoeCenter = spell.AoeCenter(target)
 				druid.AutoAttacks.CancelAutoSwing(sim)
 				spell.AOEDot().Apply(sim)
 			},
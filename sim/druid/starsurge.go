@@ -92,7 +92,7 @@ func (druid *Druid) applyStarsurge() {
 			// Aura applies on cast
 			starfireDamageAura.Activate(sim)
 
-			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 				spell.DealDamage(sim, result)
 			})
 		},
@@ -79,7 +79,7 @@ func (druid *Druid) newWrathSpellConfig(rank int) core.SpellConfig {
 				druid.NaturesGraceProcAura.Activate(sim)
 			}
 
-			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 				spell.DealDamage(sim, result)
 			})
 		},
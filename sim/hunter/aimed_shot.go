@@ -69,7 +69,7 @@ func (hunter *Hunter) getAimedShotConfig(rank int, timer *core.Timer) core.Spell
 				baseDamage *= 1.20
 			}
 			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeRangedHitAndCrit)
-			spell.WaitTravelTime(sim, func(s *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(s *core.Simulation) {
 				spell.DealDamage(sim, result)
 			})
 		},
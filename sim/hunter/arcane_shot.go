@@ -52,7 +52,7 @@ func (hunter *Hunter) getArcaneShotConfig(rank int, timer *core.Timer) core.Spel
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeRangedHitAndCrit)
 
-			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 				spell.DealDamage(sim, result)
 			})
 		},
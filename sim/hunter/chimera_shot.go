@@ -55,7 +55,7 @@ func (hunter *Hunter) registerChimeraShotSpell() {
 
 			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeRangedHitAndCrit)
 
-			spell.WaitTravelTime(sim, func(s *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(s *core.Simulation) {
 				spell.DealDamage(sim, result)
 
 				if result.Landed() {
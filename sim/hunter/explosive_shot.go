@@ -70,7 +70,7 @@ func (hunter *Hunter) registerExplosiveShotSpell() {
 			baseDamage := sim.Roll(baseLowDamage, baseHighDamage) + 0.039*spell.RangedAttackPower(target, false)
 			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeRangedHitAndCrit)
 
-			spell.WaitTravelTime(sim, func(s *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(s *core.Simulation) {
 				spell.DealDamage(sim, result)
 
 				if result.Landed() {
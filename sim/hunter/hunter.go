@@ -326,7 +326,7 @@ func NewHunter(character *core.Character, options *proto.Player) *Hunter {
 			hunter.AmmoDamageBonus
 		result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeRangedHitAndCrit)
 
-		spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+		spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 			spell.DealDamage(sim, result)
 		})
 	}
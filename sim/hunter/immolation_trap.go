@@ -68,7 +68,7 @@ func (hunter *Hunter) getImmolationTrapConfig(rank int, timer *core.Timer) core.
 			spell.Unit.AddStatDynamic(sim, stats.SpellHit, spellHit*-1)
 			result := spell.CalcOutcome(sim, target, spell.OutcomeMagicHitNoHitCounter)
 			spell.Unit.AddStatDynamic(sim, stats.SpellHit, spellHit)
-			spell.WaitTravelTime(sim, func(s *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(s *core.Simulation) {
 				spell.DealOutcome(sim, result)
 				if result.Landed() {
 					spell.Dot(target).Apply(sim)
@@ -138,6 +138,7 @@ func (hunter *Hunter) NewHunterPet() *HunterPet {
 	}
 
 	hp.Pet.MobType = petConfig.MobType
+	hp.Pet.SupportsAPLRotation = true
 
 	hp.EnableAutoAttacks(hp, core.AutoAttackOptions{
 		MainHand: core.Weapon{
@@ -196,8 +197,16 @@ func (hp *HunterPet) Initialize() {
 	})
 }
 
-func (hp *HunterPet) Reset(_ *core.Simulation) {
+func (hp *HunterPet) Reset(sim *core.Simulation) {
 	hp.uptimePercent = min(1, max(0, hp.hunterOwner.Options.PetUptime))
+
+	// ExecuteCustomRotation handles the pet's uptime, which APL rotations replace.
+	if hp.UsesAPLRotation() && hp.uptimePercent < 1 {
+		core.StartDelayedAction(sim, core.DelayedActionOptions{
+			DoAt:     core.DurationFromSeconds(sim.Duration.Seconds() * hp.uptimePercent),
+			OnAction: hp.Disable,
+		})
+	}
 }
 
 func (hp *HunterPet) ExecuteCustomRotation(sim *core.Simulation) {
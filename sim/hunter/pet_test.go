@@ -0,0 +1,91 @@
+package hunter
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// A pet rotation casting Claw once the cat has the focus for it, while the
+// owner's mana percent compares to ownerManaPercent.
+func clawRotation(op proto.APLValueCompare_ComparisonOperator, ownerManaPercent string) *proto.APLRotation {
+	compare := func(op proto.APLValueCompare_ComparisonOperator, lhs *proto.APLValue, rhs string) *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+			Op:  op,
+			Lhs: lhs,
+			Rhs: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: rhs}}},
+		}}}
+	}
+
+	return &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PriorityList: []*proto.APLListItem{{Action: &proto.APLAction{
+			Condition: &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: []*proto.APLValue{
+				compare(proto.APLValueCompare_OpGe, &proto.APLValue{Value: &proto.APLValue_CurrentFocus{CurrentFocus: &proto.APLValueCurrentFocus{}}}, "25"),
+				compare(op, &proto.APLValue{Value: &proto.APLValue_CurrentManaPercent{CurrentManaPercent: &proto.APLValueCurrentManaPercent{
+					SourceUnit: &proto.UnitReference{Type: proto.UnitReference_Owner},
+				}}}, ownerManaPercent),
+			}}}},
+			Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: core.ActionID{SpellID: 3009}.ToProto()}},
+		}}},
+	}
+}
+
+func TestPetRotationUsesOwnerAndFocus(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		op        proto.APLValueCompare_ComparisonOperator
+		wantClaws bool
+	}{
+		{name: "OwnerHasMana", op: proto.APLValueCompare_OpGt, wantClaws: true},
+		{name: "OwnerOutOfMana", op: proto.APLValueCompare_OpLe, wantClaws: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rsr := &proto.RaidSimRequest{
+				Raid: core.SinglePlayerRaidProto(&proto.Player{
+					Name:         "Hunter",
+					Class:        proto.Class_ClassHunter,
+					Race:         proto.Race_RaceOrc,
+					Level:        60,
+					Equipment:    &proto.EquipmentSpec{},
+					Consumes:     &proto.Consumes{},
+					Buffs:        &proto.IndividualBuffs{},
+					Spec:         Phase2PlayerOptions,
+					Rotation:     &proto.APLRotation{Type: proto.APLRotation_TypeAPL},
+					PetRotations: []*proto.PetRotation{{PetName: "Cat", Rotation: clawRotation(tc.op, "0")}},
+				}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+				Encounter: &proto.Encounter{
+					Targets:  []*proto.Target{{Name: "target", Level: 63, MobType: proto.MobType_MobTypeBeast}},
+					Duration: 60,
+				},
+				SimOptions: &proto.SimOptions{Iterations: 10, RandomSeed: 1},
+			}
+
+			result := core.RunRaidSim(rsr)
+			if result.ErrorResult != "" {
+				t.Fatal(result.ErrorResult)
+			}
+
+			pets := result.RaidMetrics.Parties[0].Players[0].Pets
+			if len(pets) != 1 {
+				t.Fatalf("Expected the hunter to have 1 pet, got %d", len(pets))
+			}
+			var claws int32
+			for _, action := range pets[0].Actions {
+				if core.ProtoToActionID(action.Id).SpellID == 3009 {
+					for _, target := range action.Targets {
+						claws += target.Casts
+					}
+				}
+			}
+			if (claws > 0) != tc.wantClaws {
+				t.Fatalf("Expected Claw casts: %t, got %d casts", tc.wantClaws, claws)
+			}
+			// Claw costs 25 focus, so the cat can't cast it more often than its focus regen allows.
+			if maxClaws := int32(10 * (100 + 60*core.BaseFocusPerTick) / 25); claws > maxClaws {
+				t.Fatalf("Expected at most %d Claw casts over the iterations, got %d", maxClaws, claws)
+			}
+		})
+	}
+}
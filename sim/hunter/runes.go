@@ -276,7 +276,7 @@ func (hunter *Hunter) applyLockAndLoad() {
 		Label: "Lock And Load Trigger",
 		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
 			if spell.Flags.Matches(SpellFlagTrap) {
-				spell.WaitTravelTime(sim, func(s *core.Simulation) {
+				spell.WaitTravelTime(sim, hunter.CurrentTarget, func(s *core.Simulation) {
 					// if icd.IsReady(sim) {
 					// 	icd.Use(sim)
 					hunter.LockAndLoadAura.Activate(sim)
@@ -64,7 +64,7 @@ func (hunter *Hunter) getSerpentStingConfig(rank int) core.SpellConfig {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			result := spell.CalcOutcome(sim, target, spell.OutcomeRangedHitNoHitCounter)
 
-			spell.WaitTravelTime(sim, func(s *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(s *core.Simulation) {
 				spell.DealOutcome(sim, result)
 
 				if result.Landed() {
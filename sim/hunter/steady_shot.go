@@ -52,7 +52,7 @@ func (hunter *Hunter) registerSteadyShotSpell() {
 				hunter.AmmoDamageBonus
 
 			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeRangedHitAndCrit)
-			spell.WaitTravelTime(sim, func(s *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(s *core.Simulation) {
 				spell.DealDamage(sim, result)
 			})
 		},
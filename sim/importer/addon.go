@@ -0,0 +1,103 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// The JSON written by the WoWSims Exporter in-game addon. See
+// IndividualAddonImporter in ui/core/components/importers.tsx.
+type addonExport struct {
+	Name        string            `json:"name"`
+	Class       string            `json:"class"`
+	Race        string            `json:"race"`
+	Level       int32             `json:"level"`
+	Talents     string            `json:"talents"`
+	Professions []addonProfession `json:"professions"`
+	Gear        struct {
+		Items []*addonItem `json:"items"`
+	} `json:"gear"`
+}
+
+type addonProfession struct {
+	Name  string `json:"name"`
+	Level int32  `json:"level"`
+}
+
+type addonItem struct {
+	ID           int32 `json:"id"`
+	Enchant      int32 `json:"enchant"`
+	Rune         int32 `json:"rune"`
+	RandomSuffix int32 `json:"randomSuffix"`
+}
+
+// Imports the export string of the in-game addon.
+func FromAddonExport(data []byte) (*Result, error) {
+	var export addonExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid addon export: %w", err)
+	}
+
+	class, ok := ClassFromName(export.Class)
+	if !ok {
+		return nil, fmt.Errorf("unknown class %q", export.Class)
+	}
+	race, ok := RaceFromName(export.Race)
+	if !ok {
+		return nil, fmt.Errorf("unknown race %q", export.Race)
+	}
+	level := export.Level
+	if level == 0 {
+		level = core.CharacterMaxLevel
+	}
+
+	player := &proto.Player{
+		Name:          export.Name,
+		Class:         class,
+		Race:          race,
+		Level:         level,
+		TalentsString: export.Talents,
+		Equipment:     newEquipment(),
+	}
+
+	// Items are listed by slot, with null for empty slots.
+	if len(export.Gear.Items) > len(player.Equipment.Items) {
+		return nil, fmt.Errorf("expected at most %d items, got %d", len(player.Equipment.Items), len(export.Gear.Items))
+	}
+	for i, item := range export.Gear.Items {
+		if item != nil {
+			player.Equipment.Items[i] = &proto.ItemSpec{
+				Id:           item.ID,
+				Enchant:      item.Enchant,
+				Rune:         item.Rune,
+				RandomSuffix: item.RandomSuffix,
+			}
+		}
+	}
+
+	result := &Result{Player: player}
+	for i, profession := range export.Professions {
+		value, ok := ProfessionFromName(profession.Name)
+		if !ok {
+			// Gathering professions like Fishing have no enum value, and don't matter for the sim.
+			continue
+		}
+		switch {
+		case player.Profession1 == proto.Profession_ProfessionUnknown:
+			player.Profession1 = value
+		case player.Profession2 == proto.Profession_ProfessionUnknown:
+			player.Profession2 = value
+		default:
+			return nil, fmt.Errorf("too many professions, starting with %q", export.Professions[i].Name)
+		}
+	}
+	if export.Talents == "" {
+		result.Missing = append(result.Missing, "talents")
+	}
+
+	result.checkEquipment()
+	return result, nil
+}
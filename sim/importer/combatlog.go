@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/sod/sim/combatlog"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Maps the inventory slots of COMBATANT_INFO lines and Wowhead gear planner
+// links, starting at 1, to the sim's item slots. The shirt and tabard slots have no effect in the sim.
+var inventorySlotToItemSlot = map[int]proto.ItemSlot{
+	1:  proto.ItemSlot_ItemSlotHead,
+	2:  proto.ItemSlot_ItemSlotNeck,
+	3:  proto.ItemSlot_ItemSlotShoulder,
+	5:  proto.ItemSlot_ItemSlotChest,
+	6:  proto.ItemSlot_ItemSlotWaist,
+	7:  proto.ItemSlot_ItemSlotLegs,
+	8:  proto.ItemSlot_ItemSlotFeet,
+	9:  proto.ItemSlot_ItemSlotWrist,
+	10: proto.ItemSlot_ItemSlotHands,
+	11: proto.ItemSlot_ItemSlotFinger1,
+	12: proto.ItemSlot_ItemSlotFinger2,
+	13: proto.ItemSlot_ItemSlotTrinket1,
+	14: proto.ItemSlot_ItemSlotTrinket2,
+	15: proto.ItemSlot_ItemSlotBack,
+	16: proto.ItemSlot_ItemSlotMainHand,
+	17: proto.ItemSlot_ItemSlotOffHand,
+	18: proto.ItemSlot_ItemSlotRanged,
+}
+
+// Imports a player's gear from the last COMBATANT_INFO line logged for them.
+// These are only written when an encounter starts, and with advanced combat
+// logging enabled. If playerName is empty, the log must only have one player
+// with gear in it.
+//
+// The log doesn't say what class, race or level the player is, or which talents
+// and professions they have, so those need to be filled in separately.
+func FromCombatLog(r io.Reader, playerName string) (*Result, error) {
+	names := make(map[string]string)
+	infos := make(map[string]*combatlog.CombatantInfo)
+	err := combatlog.ForEachLine(r, func(line *combatlog.Line) error {
+		if line.Event == combatlog.EventCombatantInfo {
+			info, err := combatlog.ParseCombatantInfo(line)
+			if err != nil {
+				return err
+			}
+			infos[info.GUID] = info
+		} else if line.HasUnits() {
+			for _, unit := range []combatlog.Unit{line.Source(), line.Dest()} {
+				if unit.IsPlayer() {
+					names[unit.GUID] = unit.Name
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := findCombatant(infos, names, playerName)
+	if err != nil {
+		return nil, err
+	}
+
+	player := &proto.Player{
+		Name:      strings.SplitN(names[info.GUID], "-", 2)[0],
+		Equipment: newEquipment(),
+	}
+	result := &Result{
+		Player:  player,
+		Missing: []string{"class", "race", "level", "talents", "professions"},
+	}
+	for i, item := range info.Items {
+		slot, ok := inventorySlotToItemSlot[i+1]
+		if !ok || item.ID == 0 {
+			continue
+		}
+
+		itemSpec := &proto.ItemSpec{Id: item.ID}
+		for j, enchant := range item.Enchants {
+			switch {
+			case enchant == 0:
+			case j == 0:
+				itemSpec.Enchant = enchant
+			case j == 1:
+				// Temporary enchants like weapon oils are consumes in the sim.
+			default:
+				// Engravings are logged as enchantments, whose IDs the database only
+				// has if they match the rune's.
+				if _, ok := core.RunesByID[enchant]; ok {
+					itemSpec.Rune = enchant
+				} else {
+					result.Unmapped = append(result.Unmapped, Unmapped{Slot: slot, Kind: UnmappedRune, ID: enchant})
+				}
+			}
+		}
+		player.Equipment.Items[slot] = itemSpec
+	}
+
+	result.checkEquipment()
+	return result, nil
+}
+
+func findCombatant(infos map[string]*combatlog.CombatantInfo, names map[string]string, playerName string) (*combatlog.CombatantInfo, error) {
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("no %s lines in the combat log, make sure advanced combat logging is enabled", combatlog.EventCombatantInfo)
+	}
+
+	var found []*combatlog.CombatantInfo
+	for guid, info := range infos {
+		name := names[guid]
+		shortName, _, _ := strings.Cut(name, "-")
+		if playerName == "" || strings.EqualFold(name, playerName) || strings.EqualFold(shortName, playerName) {
+			found = append(found, info)
+		}
+	}
+
+	if len(found) == 1 {
+		return found[0], nil
+	}
+
+	available := make([]string, 0, len(infos))
+	for guid := range infos {
+		if name := names[guid]; name != "" {
+			available = append(available, name)
+		} else {
+			available = append(available, guid)
+		}
+	}
+	slices.Sort(available)
+	if len(found) == 0 {
+		return nil, fmt.Errorf("no gear logged for %q, players with gear: %s", playerName, strings.Join(available, ", "))
+	}
+	return nil, fmt.Errorf("more than one player matches %q, pick one of: %s", playerName, strings.Join(available, ", "))
+}
@@ -0,0 +1,108 @@
+// Package importer turns character exports from outside the sim into players,
+// checking every ID against the sim's database. It works fully offline.
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+type UnmappedKind string
+
+const (
+	UnmappedItem         UnmappedKind = "item"
+	UnmappedEnchant      UnmappedKind = "enchant"
+	UnmappedEnchantSpell UnmappedKind = "enchant spell"
+	UnmappedRune         UnmappedKind = "rune"
+	UnmappedRandomSuffix UnmappedKind = "random suffix"
+)
+
+// An ID from the export which the sim's database doesn't know about. These are
+// left out of the imported player.
+type Unmapped struct {
+	Slot proto.ItemSlot
+	Kind UnmappedKind
+	ID   int32
+}
+
+func (u Unmapped) String() string {
+	return fmt.Sprintf("%s: unknown %s %d", strings.TrimPrefix(u.Slot.String(), "ItemSlot"), u.Kind, u.ID)
+}
+
+type Result struct {
+	Player   *proto.Player
+	Unmapped []Unmapped
+
+	// Parts of the character which the export doesn't include, and so weren't imported.
+	Missing []string
+}
+
+// Removes the IDs which aren't in the database from the player's equipment,
+// and records them as unmapped.
+func (result *Result) checkEquipment() {
+	for i, item := range result.Player.Equipment.Items {
+		if item == nil || item.Id == 0 {
+			continue
+		}
+		slot := proto.ItemSlot(i)
+
+		if _, ok := core.ItemsByID[item.Id]; !ok {
+			result.Unmapped = append(result.Unmapped, Unmapped{Slot: slot, Kind: UnmappedItem, ID: item.Id})
+			result.Player.Equipment.Items[i] = &proto.ItemSpec{}
+			continue
+		}
+		if _, ok := core.EnchantsByEffectID[item.Enchant]; item.Enchant != 0 && !ok {
+			result.Unmapped = append(result.Unmapped, Unmapped{Slot: slot, Kind: UnmappedEnchant, ID: item.Enchant})
+			item.Enchant = 0
+		}
+		if _, ok := core.RunesByID[item.Rune]; item.Rune != 0 && !ok {
+			result.Unmapped = append(result.Unmapped, Unmapped{Slot: slot, Kind: UnmappedRune, ID: item.Rune})
+			item.Rune = 0
+		}
+		if _, ok := core.RandomSuffixesByID[item.RandomSuffix]; item.RandomSuffix != 0 && !ok {
+			result.Unmapped = append(result.Unmapped, Unmapped{Slot: slot, Kind: UnmappedRandomSuffix, ID: item.RandomSuffix})
+			item.RandomSuffix = 0
+		}
+	}
+}
+
+func newEquipment() *proto.EquipmentSpec {
+	equipment := &proto.EquipmentSpec{Items: make([]*proto.ItemSpec, len(proto.ItemSlot_name))}
+	for i := range equipment.Items {
+		equipment.Items[i] = &proto.ItemSpec{}
+	}
+	return equipment
+}
+
+// Matches names like "Night Elf", "nightelf" or "NIGHTELF" against the enum
+// names, without their prefix.
+func enumFromName(name string, prefix string, names map[int32]string) (int32, bool) {
+	normalized := strings.ToLower(strings.ReplaceAll(name, " ", ""))
+	if normalized == "" {
+		return 0, false
+	}
+	for value, enumName := range names {
+		if value != 0 && strings.ToLower(strings.TrimPrefix(enumName, prefix)) == normalized {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+func ClassFromName(name string) (proto.Class, bool) {
+	class, ok := enumFromName(name, "Class", proto.Class_name)
+	return proto.Class(class), ok
+}
+
+func RaceFromName(name string) (proto.Race, bool) {
+	race, ok := enumFromName(name, "Race", proto.Race_name)
+	return proto.Race(race), ok
+}
+
+func ProfessionFromName(name string) (proto.Profession, bool) {
+	profession, ok := enumFromName(name, "", proto.Profession_name)
+	return proto.Profession(profession), ok
+}
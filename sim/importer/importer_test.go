@@ -0,0 +1,241 @@
+package importer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+const (
+	testHelm    = 990301
+	testWand    = 990302
+	testEnchant = 990303
+	testRune    = 990304
+)
+
+func init() {
+	core.ItemsByID[testHelm] = core.Item{ID: testHelm, Type: proto.ItemType_ItemTypeHead}
+	core.ItemsByID[testWand] = core.Item{ID: testWand, Type: proto.ItemType_ItemTypeRanged}
+	core.EnchantsByEffectID[testEnchant] = core.Enchant{EffectID: testEnchant}
+	core.RunesByID[testRune] = core.Rune{ID: testRune, Type: proto.ItemType_ItemTypeHead}
+}
+
+func formatUnmapped(unmapped []Unmapped) []string {
+	return core.MapSlice(unmapped, func(u Unmapped) string { return u.String() })
+}
+
+func TestFromAddonExport(t *testing.T) {
+	export := `{
+		"name": "Caster",
+		"class": "mage",
+		"race": "Night Elf",
+		"level": 50,
+		"talents": "05-0512-",
+		"professions": [{"name": "Tailoring", "level": 300}, {"name": "Fishing", "level": 150}, {"name": "Enchanting", "level": 300}],
+		"gear": {
+			"version": "1.0",
+			"items": [
+				{"id": 990301, "enchant": 990303, "rune": 990304},
+				null,
+				{"id": 990399},
+				null, null, null, null, null, null, null, null, null, null, null, null, null,
+				{"id": 990302, "enchant": 990398, "rune": 990397}
+			]
+		}
+	}`
+
+	result, err := FromAddonExport([]byte(export))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	player := result.Player
+	if player.Class != proto.Class_ClassMage || player.Race != proto.Race_RaceNightElf || player.Level != 50 || player.TalentsString != "05-0512-" {
+		t.Errorf("Unexpected character %v", player)
+	}
+	if player.Profession1 != proto.Profession_Tailoring || player.Profession2 != proto.Profession_Enchanting {
+		t.Errorf("Expected Tailoring and Enchanting, got %s and %s", player.Profession1, player.Profession2)
+	}
+
+	items := player.Equipment.Items
+	if head := items[proto.ItemSlot_ItemSlotHead]; head.Id != testHelm || head.Enchant != testEnchant || head.Rune != testRune {
+		t.Errorf("Unexpected head %v", head)
+	}
+	if shoulder := items[proto.ItemSlot_ItemSlotShoulder]; shoulder.Id != 0 {
+		t.Errorf("Expected the unknown shoulder to be left out, got %v", shoulder)
+	}
+	if ranged := items[proto.ItemSlot_ItemSlotRanged]; ranged.Id != testWand || ranged.Enchant != 0 || ranged.Rune != 0 {
+		t.Errorf("Expected the wand without its unknown enchant and rune, got %v", ranged)
+	}
+
+	expectedUnmapped := []string{
+		"Shoulder: unknown item 990399",
+		"Ranged: unknown enchant 990398",
+		"Ranged: unknown rune 990397",
+	}
+	if unmapped := formatUnmapped(result.Unmapped); !slices.Equal(unmapped, expectedUnmapped) {
+		t.Errorf("Expected unmapped:\n%v\ngot:\n%v", expectedUnmapped, unmapped)
+	}
+}
+
+func TestFromAddonExportUnknownClass(t *testing.T) {
+	if _, err := FromAddonExport([]byte(`{"class": "deathknight", "race": "human"}`)); err == nil {
+		t.Error("Expected an error for an unknown class")
+	}
+}
+
+const testCombatLog = `3/14 20:15:31.000  COMBAT_LOG_VERSION,9,ADVANCED_LOG_ENABLED,1,BUILD_VERSION,1.15.2,PROJECT_ID,2
+3/14 20:15:31.100  ENCOUNTER_START,2935,"Mekgineer Thermaplugg",1,5,90
+3/14 20:15:31.200  COMBATANT_INFO,Player-5826-0000000A,0,37,109,95,47,0,0,0,4.48,4.48,2.00,0,0,0,0,0,0,0,0,0,0,3213,0,(),(0,0,0,0),[(990399,45,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),())],[]
+3/14 20:15:31.300  COMBATANT_INFO,Player-5826-0000000B,0,37,109,95,47,0,0,0,4.48,4.48,2.00,0,0,0,0,0,0,0,0,0,0,3213,0,(),(0,0,0,0),[(990301,45,(990303,0,990304),(),()),(0,0,(),(),()),(0,0,(),(),()),(4334,1,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(0,0,(),(),()),(990302,45,(990398,2628,7024),(),())],[]
+3/14 20:15:32.000  SPELL_CAST_SUCCESS,Player-5826-0000000A,"Tank-Wild Growth",0x511,0x0,0000000000000000,nil,0x80000000,0x80000000,355,"Taunt",0x1
+3/14 20:15:33.000  SPELL_CAST_SUCCESS,Player-5826-0000000B,"Caster-Wild Growth",0x511,0x0,0000000000000000,nil,0x80000000,0x80000000,400640,"Living Bomb",0x4
+`
+
+func TestFromCombatLog(t *testing.T) {
+	result, err := FromCombatLog(strings.NewReader(testCombatLog), "caster")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	player := result.Player
+	if player.Name != "Caster" {
+		t.Errorf("Expected Caster, got %s", player.Name)
+	}
+	items := player.Equipment.Items
+	if head := items[proto.ItemSlot_ItemSlotHead]; head.Id != testHelm || head.Enchant != testEnchant || head.Rune != testRune {
+		t.Errorf("Unexpected head %v", head)
+	}
+	if chest := items[proto.ItemSlot_ItemSlotChest]; chest.Id != 0 {
+		t.Errorf("Expected the shirt to be skipped, got %v in the chest slot", chest)
+	}
+	if ranged := items[proto.ItemSlot_ItemSlotRanged]; ranged.Id != testWand || ranged.Enchant != 0 {
+		t.Errorf("Unexpected ranged %v", ranged)
+	}
+
+	expectedUnmapped := []string{
+		"Ranged: unknown rune 7024",
+		"Ranged: unknown enchant 990398",
+	}
+	if unmapped := formatUnmapped(result.Unmapped); !slices.Equal(unmapped, expectedUnmapped) {
+		t.Errorf("Expected unmapped:\n%v\ngot:\n%v", expectedUnmapped, unmapped)
+	}
+	if !slices.Contains(result.Missing, "class") {
+		t.Errorf("Expected class to be missing, got %v", result.Missing)
+	}
+}
+
+func TestFromCombatLogNeedsPlayerName(t *testing.T) {
+	_, err := FromCombatLog(strings.NewReader(testCombatLog), "")
+	if err == nil || !strings.Contains(err.Error(), "Caster-Wild Growth, Tank-Wild Growth") {
+		t.Errorf("Expected an error listing the players, got %v", err)
+	}
+}
+
+func TestWowheadLink(t *testing.T) {
+	spells := NewEnchantSpells([]*proto.UIEnchant{
+		{EffectId: testEnchant, SpellId: 990305, Type: proto.ItemType_ItemTypeHead},
+	})
+	player := &proto.Player{
+		Class:         proto.Class_ClassMage,
+		Race:          proto.Race_RaceNightElf,
+		Level:         50,
+		TalentsString: "05-0512-",
+		Equipment:     newEquipment(),
+	}
+	player.Equipment.Items[proto.ItemSlot_ItemSlotHead] = &proto.ItemSpec{Id: testHelm, Enchant: testEnchant, Rune: testRune}
+	player.Equipment.Items[proto.ItemSlot_ItemSlotRanged] = &proto.ItemSpec{Id: testWand}
+
+	link, err := ToWowheadLink(player, spells)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(link, "https://www.wowhead.com/classic/gear-planner/mage/night-elf/") {
+		t.Errorf("Unexpected link %s", link)
+	}
+
+	result, err := FromWowheadLink(link, spells)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !goproto.Equal(result.Player, player) {
+		t.Errorf("Expected %v, got %v", player, result.Player)
+	}
+	if len(result.Unmapped) > 0 || len(result.Missing) > 0 {
+		t.Errorf("Expected everything to be imported, got unmapped %v and missing %v", result.Unmapped, result.Missing)
+	}
+
+	// Without the enchant's spell, only the enchant is left out.
+	result, err = FromWowheadLink(link, NewEnchantSpells(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head := result.Player.Equipment.Items[proto.ItemSlot_ItemSlotHead]; head.Id != testHelm || head.Enchant != 0 || head.Rune != testRune {
+		t.Errorf("Unexpected head %v", head)
+	}
+	if unmapped := formatUnmapped(result.Unmapped); !slices.Equal(unmapped, []string{"Head: unknown enchant spell 990305"}) {
+		t.Errorf("Unexpected unmapped %v", unmapped)
+	}
+}
+
+func TestFromWowheadLinkInvalid(t *testing.T) {
+	for _, link := range []string{
+		"https://www.wowhead.com/classic/gear-planner/mage/night-elf",
+		"https://www.wowhead.com/classic/gear-planner/deathknight/human/BgA8AA",
+		// Cut off in the middle of the talents.
+		"https://www.wowhead.com/classic/gear-planner/mage/human/BgA8BQ",
+	} {
+		if _, err := FromWowheadLink(link, NewEnchantSpells(nil)); err == nil {
+			t.Errorf("Expected an error for %s", link)
+		}
+	}
+}
+
+func TestSimLink(t *testing.T) {
+	settings := &proto.IndividualSimSettings{
+		Player: &proto.Player{
+			Class:     proto.Class_ClassMage,
+			Equipment: newEquipment(),
+			Spec:      &proto.Player_Mage{Mage: &proto.Mage{}},
+		},
+	}
+	settings.Player.Equipment.Items[proto.ItemSlot_ItemSlotHead] = &proto.ItemSpec{Id: testHelm, Enchant: 990398}
+
+	link, err := EncodeSimLink(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(link, "https://wowsims.github.io/sod/mage/#") {
+		t.Errorf("Unexpected link %s", link)
+	}
+
+	decoded, err := DecodeSimLink(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !goproto.Equal(decoded, settings) {
+		t.Errorf("Expected %v, got %v", settings, decoded)
+	}
+
+	result, err := FromSimLink(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unmapped := formatUnmapped(result.Unmapped); !slices.Equal(unmapped, []string{"Head: unknown enchant 990398"}) {
+		t.Errorf("Unexpected unmapped %v", unmapped)
+	}
+
+	raidLink, err := EncodeSimLink(&proto.RaidSimSettings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raid, err := DecodeSimLink(raidLink); err != nil {
+		t.Fatal(err)
+	} else if _, ok := raid.(*proto.RaidSimSettings); !ok {
+		t.Errorf("Expected RaidSimSettings from %s, got %T", raidLink, raid)
+	}
+}
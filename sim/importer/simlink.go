@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+const simURL = "https://wowsims.github.io/sod/"
+
+var ErrInvalidSimLink = errors.New("invalid wowsims export link")
+
+// Decodes the settings of a link exported by the sim, which are zlib compressed
+// and base64 encoded after the '#'. Links to the raid sim give RaidSimSettings,
+// and others IndividualSimSettings.
+func DecodeSimLink(link string) (goproto.Message, error) {
+	parts := strings.Split(link, "#")
+	switch {
+	case len(parts) != 2:
+		return nil, ErrInvalidSimLink
+	case parts[1] == "":
+		return nil, ErrInvalidSimLink
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode proto from link: %w", err)
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create zlib reader: %w", err)
+	}
+	defer r.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, fmt.Errorf("reading zlib data failed: %w", err)
+	}
+
+	var settings goproto.Message
+	if strings.Contains(parts[0], "/raid/") {
+		settings = &proto.RaidSimSettings{}
+	} else {
+		settings = &proto.IndividualSimSettings{}
+	}
+
+	if err := goproto.Unmarshal(buf.Bytes(), settings); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal raw proto: %w", err)
+	}
+	return settings, nil
+}
+
+// Compresses and encodes settings the way the sim's links do, for the part
+// after the '#'.
+func EncodeSettings(settings goproto.Message) (string, error) {
+	data, err := goproto.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	writer := zlib.NewWriter(&buffer)
+	if _, err := writer.Write(data); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+// Encodes the settings as a link to the sim of the player's spec, or to the
+// raid sim for RaidSimSettings.
+func EncodeSimLink(settings goproto.Message) (string, error) {
+	var page string
+	switch settings := settings.(type) {
+	case *proto.IndividualSimSettings:
+		// The spec fields are named after the sim's directories, like "elemental_shaman".
+		player := settings.GetPlayer().ProtoReflect()
+		spec := player.WhichOneof(player.Descriptor().Oneofs().ByName("spec"))
+		if spec == nil {
+			return "", fmt.Errorf("the player needs a spec")
+		}
+		page = string(spec.Name())
+	case *proto.RaidSimSettings:
+		page = "raid"
+	default:
+		return "", fmt.Errorf("cannot make a link for %T", settings)
+	}
+
+	encoded, err := EncodeSettings(settings)
+	if err != nil {
+		return "", err
+	}
+	return simURL + page + "/#" + encoded, nil
+}
+
+// The settings of an individual sim for the request's first player.
+func SettingsFromRequest(request *proto.RaidSimRequest) *proto.IndividualSimSettings {
+	return &proto.IndividualSimSettings{
+		Settings: &proto.SimSettings{
+			Iterations: request.SimOptions.GetIterations(),
+		},
+		RaidBuffs:  request.Raid.Buffs,
+		Debuffs:    request.Raid.Debuffs,
+		Tanks:      request.Raid.Tanks,
+		PartyBuffs: request.Raid.Parties[0].Buffs,
+		Player:     request.Raid.Parties[0].Players[0],
+		Encounter:  request.Encounter,
+	}
+}
+
+// Imports the player of a link exported by an individual sim.
+func FromSimLink(link string) (*Result, error) {
+	settings, err := DecodeSimLink(link)
+	if err != nil {
+		return nil, err
+	}
+	individual, ok := settings.(*proto.IndividualSimSettings)
+	if !ok || individual.Player == nil {
+		return nil, fmt.Errorf("expected a link to an individual sim")
+	}
+
+	player := individual.Player
+	if player.Equipment == nil {
+		player.Equipment = newEquipment()
+	}
+	for len(player.Equipment.Items) < len(proto.ItemSlot_name) {
+		player.Equipment.Items = append(player.Equipment.Items, &proto.ItemSpec{})
+	}
+	for i, item := range player.Equipment.Items {
+		if item == nil {
+			player.Equipment.Items[i] = &proto.ItemSpec{}
+		}
+	}
+
+	result := &Result{Player: player}
+	result.checkEquipment()
+	return result, nil
+}
@@ -0,0 +1,272 @@
+package importer
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+const wowheadGearPlannerURL = "https://www.wowhead.com/classic/gear-planner/"
+
+var wowheadLinkRegex = regexp.MustCompile(`wowhead\.com/classic/gear-planner/([a-z\-]+)/([a-z\-]+)/([a-zA-Z0-9_\-]+)`)
+
+// Wowhead identifies enchants by the spell which applies them, while the sim
+// uses the enchant's effect ID. Neither is unique by itself, so spells are
+// matched with the enchant that fits the item.
+type EnchantSpells struct {
+	bySpellID  map[int32][]*proto.UIEnchant
+	byEffectID map[int32][]*proto.UIEnchant
+}
+
+func NewEnchantSpells(enchants []*proto.UIEnchant) *EnchantSpells {
+	spells := &EnchantSpells{
+		bySpellID:  make(map[int32][]*proto.UIEnchant),
+		byEffectID: make(map[int32][]*proto.UIEnchant),
+	}
+	for _, enchant := range enchants {
+		if enchant.SpellId == 0 {
+			continue
+		}
+		spells.bySpellID[enchant.SpellId] = append(spells.bySpellID[enchant.SpellId], enchant)
+		spells.byEffectID[enchant.EffectId] = append(spells.byEffectID[enchant.EffectId], enchant)
+	}
+	return spells
+}
+
+func enchantFits(enchant *proto.UIEnchant, itemType proto.ItemType) bool {
+	return enchant.Type == itemType || slices.Contains(enchant.ExtraTypes, itemType)
+}
+
+// Picks the enchant fitting the item type, or else the first one.
+func pickEnchant(enchants []*proto.UIEnchant, itemType proto.ItemType) (*proto.UIEnchant, bool) {
+	if len(enchants) == 0 {
+		return nil, false
+	}
+	if i := slices.IndexFunc(enchants, func(enchant *proto.UIEnchant) bool { return enchantFits(enchant, itemType) }); i != -1 {
+		return enchants[i], true
+	}
+	return enchants[0], true
+}
+
+func (spells *EnchantSpells) effectID(spellID int32, itemType proto.ItemType) (int32, bool) {
+	enchant, ok := pickEnchant(spells.bySpellID[spellID], itemType)
+	if !ok {
+		return 0, false
+	}
+	return enchant.EffectId, true
+}
+
+func (spells *EnchantSpells) spellID(effectID int32, itemType proto.ItemType) (int32, bool) {
+	enchant, ok := pickEnchant(spells.byEffectID[effectID], itemType)
+	if !ok {
+		return 0, false
+	}
+	return enchant.SpellId, true
+}
+
+// Wowhead doesn't document the binary format of its gear planner links. This
+// is the one written by IndividualWowheadGearPlannerExporter in
+// ui/core/components/exporters.tsx:
+//
+//	Byte 00: always 6
+//	Byte 01: aesthetics, like the body type
+//	Byte 02: 8-bit player level
+//	Byte 03: 8-bit length of the talents bytes
+//	Next N bytes: talents as a hex string, with 'f' instead of '-'
+//
+// followed by the items, each:
+//
+//	8-bit inventory slot, high bit set if the item is enchanted
+//	8-bit upper 3 bits for the gem count, and the item ID's bits above 16
+//	16-bit item ID
+//	if enchanted, the 24-bit spell ID of the enchant
+//	the 24-bit ID of each gem
+//
+// Classic has no gems, so the engraved rune's spell ID is stored as the item's
+// one gem instead.
+const (
+	wowheadEnchantedBit   = 0b10000000
+	wowheadSlotMask       = 0b00111111
+	wowheadGemCountShift  = 5
+	wowheadItemIDHighMask = 0b00011111
+)
+
+// Imports the class, race, level, talents and gear of a Wowhead gear planner
+// link, like https://www.wowhead.com/classic/gear-planner/mage/night-elf/XXXX.
+func FromWowheadLink(link string, spells *EnchantSpells) (*Result, error) {
+	match := wowheadLinkRegex.FindStringSubmatch(link)
+	if match == nil {
+		return nil, fmt.Errorf("invalid Wowhead gear planner link %q, must look like %sCLASS/RACE/XXXX", link, wowheadGearPlannerURL)
+	}
+
+	class, ok := ClassFromName(strings.ReplaceAll(match[1], "-", ""))
+	if !ok {
+		return nil, fmt.Errorf("unknown class %q", match[1])
+	}
+	race, ok := RaceFromName(strings.ReplaceAll(match[2], "-", ""))
+	if !ok {
+		return nil, fmt.Errorf("unknown race %q", match[2])
+	}
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(match[3], "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Wowhead gear planner data: %w", err)
+	}
+	if len(data) < 4 || len(data) < 4+int(data[3]) {
+		return nil, fmt.Errorf("Wowhead gear planner data is too short")
+	}
+
+	numTalentBytes := int(data[3])
+	talents := strings.Split(hex.EncodeToString(data[4:4+numTalentBytes]), "f")
+	if len(talents) > 3 {
+		talents = talents[:3]
+	}
+
+	player := &proto.Player{
+		Class:         class,
+		Race:          race,
+		Level:         int32(data[2]),
+		TalentsString: strings.Join(talents, "-"),
+		Equipment:     newEquipment(),
+	}
+	result := &Result{Player: player}
+	if numTalentBytes == 0 {
+		result.Missing = append(result.Missing, "talents")
+	}
+
+	gear := data[4+numTalentBytes:]
+	for cur := 0; cur < len(gear); {
+		if cur+4 > len(gear) {
+			return nil, fmt.Errorf("Wowhead gear planner item at byte %d is cut off", cur)
+		}
+		inventorySlot := int(gear[cur] & wowheadSlotMask)
+		isEnchanted := gear[cur]&wowheadEnchantedBit != 0
+		numGems := int(gear[cur+1] >> wowheadGemCountShift)
+		itemSpec := &proto.ItemSpec{
+			Id: int32(gear[cur+1]&wowheadItemIDHighMask)<<16 | int32(gear[cur+2])<<8 | int32(gear[cur+3]),
+		}
+		cur += 4
+
+		slot, hasSlot := inventorySlotToItemSlot[inventorySlot]
+		var itemType proto.ItemType
+		if item, ok := core.ItemsByID[itemSpec.Id]; ok {
+			itemType = item.Type
+		}
+
+		read24Bits := func(part string) (int32, error) {
+			if cur+3 > len(gear) {
+				return 0, fmt.Errorf("Wowhead gear planner %s at byte %d is cut off", part, cur)
+			}
+			id := int32(gear[cur])<<16 | int32(gear[cur+1])<<8 | int32(gear[cur+2])
+			cur += 3
+			return id, nil
+		}
+		if isEnchanted {
+			spellID, err := read24Bits("enchant")
+			if err != nil {
+				return nil, err
+			}
+			if effectID, ok := spells.effectID(spellID, itemType); ok {
+				itemSpec.Enchant = effectID
+			} else if hasSlot {
+				result.Unmapped = append(result.Unmapped, Unmapped{Slot: slot, Kind: UnmappedEnchantSpell, ID: spellID})
+			}
+		}
+		for i := 0; i < numGems; i++ {
+			gemID, err := read24Bits("gem")
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 {
+				itemSpec.Rune = gemID
+			}
+		}
+
+		// Skip the shirt and tabard.
+		if hasSlot {
+			player.Equipment.Items[slot] = itemSpec
+		}
+	}
+
+	result.checkEquipment()
+	return result, nil
+}
+
+// Exports the class, race, level, talents and gear of the player as a Wowhead
+// gear planner link.
+func ToWowheadLink(player *proto.Player, spells *EnchantSpells) (string, error) {
+	className := strings.TrimPrefix(player.Class.String(), "Class")
+	raceName := strings.TrimPrefix(player.Race.String(), "Race")
+	if player.Class == proto.Class_ClassUnknown || player.Race == proto.Race_RaceUnknown {
+		return "", fmt.Errorf("the player needs a class and race, got %s and %s", className, raceName)
+	}
+
+	level := player.Level
+	if level == 0 {
+		level = core.CharacterMaxLevel
+	}
+
+	var talentBytes []byte
+	if player.TalentsString != "" {
+		talents := strings.ReplaceAll(player.TalentsString, "-", "f") + "f"
+		if len(talents)%2 == 1 {
+			talents += "0"
+		}
+		var err error
+		if talentBytes, err = hex.DecodeString(talents); err != nil {
+			return "", fmt.Errorf("invalid talents %q", player.TalentsString)
+		}
+	}
+
+	data := []byte{6, 0, byte(level), byte(len(talentBytes))}
+	data = append(data, talentBytes...)
+
+	items := player.GetEquipment().GetItems()
+	for inventorySlot := 1; inventorySlot <= 19; inventorySlot++ {
+		slot, ok := inventorySlotToItemSlot[inventorySlot]
+		if !ok || int(slot) >= len(items) || items[slot].GetId() == 0 {
+			continue
+		}
+		itemSpec := items[slot]
+
+		slotByte := byte(inventorySlot)
+		if itemSpec.Enchant != 0 {
+			slotByte |= wowheadEnchantedBit
+		}
+		numGems := byte(0)
+		if itemSpec.Rune != 0 {
+			numGems = 1
+		}
+		data = append(data, slotByte, numGems<<wowheadGemCountShift|byte(itemSpec.Id>>16)&wowheadItemIDHighMask, byte(itemSpec.Id>>8), byte(itemSpec.Id))
+
+		if itemSpec.Enchant != 0 {
+			spellID, ok := spells.spellID(itemSpec.Enchant, core.ItemsByID[itemSpec.Id].Type)
+			if !ok {
+				return "", fmt.Errorf("%s: enchant %d has no spell ID", strings.TrimPrefix(slot.String(), "ItemSlot"), itemSpec.Enchant)
+			}
+			data = append(data, byte(spellID>>16), byte(spellID>>8), byte(spellID))
+		}
+		if itemSpec.Rune != 0 {
+			data = append(data, byte(itemSpec.Rune>>16), byte(itemSpec.Rune>>8), byte(itemSpec.Rune))
+		}
+	}
+
+	return fmt.Sprintf("%s%s/%s/%s", wowheadGearPlannerURL, wowheadName(className), wowheadName(raceName), base64.RawURLEncoding.EncodeToString(data)), nil
+}
+
+// Turns enum names like "NightElf" into "night-elf".
+func wowheadName(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			sb.WriteByte('-')
+		}
+		sb.WriteRune(r)
+	}
+	return strings.ToLower(sb.String())
+}
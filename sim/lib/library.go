@@ -3,9 +3,6 @@ package main
 // #include <stdlib.h>
 import "C"
 import (
-	"bytes"
-	"compress/zlib"
-	"encoding/base64"
 	"encoding/json"
 	"log"
 	"unsafe"
@@ -13,8 +10,8 @@ import (
 	"github.com/wowsims/sod/sim"
 	"github.com/wowsims/sod/sim/core"
 	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/importer"
 	"google.golang.org/protobuf/encoding/protojson"
-	goproto "google.golang.org/protobuf/proto"
 )
 
 var _default_rsr = proto.RaidSimRequest{
@@ -69,26 +66,10 @@ func encodeSettings(json *C.char) *C.char {
 	if err != nil {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
-	settings := &proto.IndividualSimSettings{
-		Settings: &proto.SimSettings{
-			Iterations: input.SimOptions.Iterations,
-		},
-		RaidBuffs:  input.Raid.Buffs,
-		Debuffs:    input.Raid.Debuffs,
-		Tanks:      input.Raid.Tanks,
-		PartyBuffs: input.Raid.Parties[0].Buffs,
-		Player:     input.Raid.Parties[0].Players[0],
-		Encounter:  input.Encounter,
-	}
-	var buffer bytes.Buffer
-	data, err := goproto.Marshal(settings)
+	out, err := importer.EncodeSettings(importer.SettingsFromRequest(input))
 	if err != nil {
 		panic(err)
 	}
-	writer := zlib.NewWriter(&buffer)
-	writer.Write(data)
-	writer.Close()
-	out := base64.StdEncoding.EncodeToString(buffer.Bytes())
 	return C.CString(string(out))
 }
 
@@ -118,13 +99,20 @@ func getDatabase(itemIds *int32, numItems int32, enchantIds *int32, numEnchants
 			WeaponSpeed:      item.SwingSpeed,
 			SetName:          item.SetName,
 			SetId:            item.SetID,
+			HasEffect:        item.HasEffect,
+			Unique:           item.Unique,
 		}
 	}
 	for i, enchantId := range eids {
 		enchant := core.EnchantsByEffectID[enchantId]
 		simDB.Enchants[i] = &proto.SimEnchant{
-			EffectId: enchant.EffectID,
-			Stats:    enchant.Stats[:],
+			EffectId:       enchant.EffectID,
+			Stats:          enchant.Stats[:],
+			Type:           enchant.Type,
+			ExtraTypes:     enchant.ExtraTypes,
+			EnchantType:    enchant.EnchantType,
+			ClassAllowlist: enchant.ClassAllowlist,
+			RequiresLevel:  enchant.RequiresLevel,
 		}
 	}
 	out, err := protojson.Marshal(simDB)
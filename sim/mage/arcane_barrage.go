@@ -48,7 +48,7 @@ func (mage *Mage) registerArcaneBarrageSpell() {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			result := spell.CalcDamage(sim, target, sim.Roll(baseDamageLow, baseDamageHigh), spell.OutcomeMagicHitAndCrit)
 
-			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 				spell.DealDamage(sim, result)
 			})
 		},
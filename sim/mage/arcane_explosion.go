@@ -39,6 +39,8 @@ func (mage *Mage) newArcaneExplosionSpellConfig(rank int) core.SpellConfig {
 		DefenseType: core.DefenseTypeMagic,
 		ProcMask:    core.ProcMaskSpellDamage,
 		Flags:       SpellFlagMage | core.SpellFlagAPL,
+		AoeRadius:   10,
+		AoeOrigin:   core.AoeOriginCaster,
 
 		RequiredLevel: level,
 		Rank:          rank,
@@ -57,7 +59,7 @@ func (mage *Mage) newArcaneExplosionSpellConfig(rank int) core.SpellConfig {
 		BonusCoefficient: spellCoeff,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				damage := sim.Roll(baseDamageLow, baseDamageHigh)
 				spell.CalcAndDealDamage(sim, aoeTarget, damage, spell.OutcomeMagicCrit)
 			}
@@ -127,7 +127,7 @@ func (mage *Mage) getArcaneMissilesTickSpell(rank int) *core.Spell {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			result := spell.CalcDamage(sim, target, baseTickDamage, spell.OutcomeMagicHitAndCrit)
 
-			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 				spell.DealDamage(sim, result)
 			})
 		},
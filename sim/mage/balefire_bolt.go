@@ -88,7 +88,7 @@ func (mage *Mage) registerBalefireBoltSpell() {
 			balefireAura.Activate(sim)
 			balefireAura.AddStack(sim)
 
-			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 				spell.DealDamage(sim, result)
 			})
 		},
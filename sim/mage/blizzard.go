@@ -37,6 +37,9 @@ func (mage *Mage) newBlizzardSpellConfig(rank int) core.SpellConfig {
 
 	spellCoeff := .042
 
+	// Blizzard keeps hitting the area it was cast on.
+	var aoeCenter core.Vector2
+
 	var improvedBlizzardProcApplication *core.Spell
 	if mage.Talents.ImprovedBlizzard > 0 {
 		impId := []int32{0, 11185, 12487, 12488}[mage.Talents.ImprovedBlizzard]
@@ -62,6 +65,7 @@ func (mage *Mage) newBlizzardSpellConfig(rank int) core.SpellConfig {
 		SpellSchool: core.SpellSchoolFrost,
 		ProcMask:    core.ProcMaskSpellDamage,
 		Flags:       SpellFlagMage | core.SpellFlagChanneled | core.SpellFlagAPL,
+		AoeRadius:   8,
 
 		RequiredLevel: level,
 		Rank:          rank,
@@ -87,7 +91,7 @@ func (mage *Mage) newBlizzardSpellConfig(rank int) core.SpellConfig {
 				dot.Snapshot(target, baseDamage, isRollover)
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range dot.Spell.AoeTargetsAt(aoeCenter) {
 					dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 
 					if improvedBlizzardProcApplication != nil {
@@ -101,6 +105,7 @@ func (mage *Mage) newBlizzardSpellConfig(rank int) core.SpellConfig {
 		ThreatMultiplier: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			aoeCenter = spell.AoeCenter(target)
 			spell.AOEDot().Apply(sim)
 		},
 	}
@@ -90,7 +90,7 @@ func (mage *Mage) newFireballSpellConfig(rank int) core.SpellConfig {
 			mage.BonusFireballDoTAmount = 0
 			baseDamage := sim.Roll(baseDamageLow, baseDamageHigh)
 			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
-			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 				spell.DealDamage(sim, result)
 
 				if result.Landed() {
@@ -44,12 +44,16 @@ func (mage *Mage) newFlamestrikeSpellConfig(rank int) core.SpellConfig {
 
 	castTime := time.Second * 3
 
+	// The burning ground keeps hitting the area Flamestrike was cast on.
+	var aoeCenter core.Vector2
+
 	return core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: spellId},
 		SpellSchool: core.SpellSchoolFire,
 		DefenseType: core.DefenseTypeMagic,
 		ProcMask:    core.ProcMaskSpellDamage,
 		Flags:       SpellFlagMage | core.SpellFlagAPL,
+		AoeRadius:   8,
 
 		RequiredLevel: level,
 		Rank:          rank,
@@ -82,14 +86,15 @@ func (mage *Mage) newFlamestrikeSpellConfig(rank int) core.SpellConfig {
 				dot.Snapshot(target, baseDotDamage, isRollover)
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range dot.Spell.AoeTargetsAt(aoeCenter) {
 					dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 				}
 			},
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			aoeCenter = spell.AoeCenter(target)
+			for _, aoeTarget := range spell.AoeTargetsAt(aoeCenter) {
 				baseDamage := sim.Roll(baseDamageLow, baseDamageHigh)
 				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicCrit)
 			}
@@ -92,6 +92,7 @@ func (orb *FrozenOrb) Initialize() {
 }
 
 func (orb *FrozenOrb) Reset(_ *core.Simulation) {
+	orb.TickCount = 0
 }
 
 func (orb *FrozenOrb) ExecuteCustomRotation(sim *core.Simulation) {
@@ -78,8 +78,8 @@ func (mage *Mage) registerLivingFlameSpell() {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			dot := spell.Dot(target)
 			// Ticks lost to travel time
-			dot.NumberOfTicks = ticks - int32(math.Floor(spell.TravelTime().Seconds()))
-			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+			dot.NumberOfTicks = ticks - int32(math.Floor(spell.TravelTimeTo(target).Seconds()))
+			spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 				dot.Apply(sim)
 			})
 		},
@@ -13,228 +13,246 @@ func init() {
 }
 
 func TestArcane(t *testing.T) {
-	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-		{
-			Class:      proto.Class_ClassMage,
-			Level:      25,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase1TalentsArcane,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p1_generic"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p1_arcane"),
-			Buffs:       core.FullBuffsPhase1,
-			Consumes:    Phase1Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Arcane", SpecOptions: PlayerOptionsArcane},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassMage,
-			Level:      40,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase2TalentsArcane,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p2_arcane"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p2_arcane"),
-			Buffs:       core.FullBuffsPhase2,
-			Consumes:    Phase2Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Arcane", SpecOptions: PlayerOptionsArcane},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassMage,
-			Phase:      4,
-			Level:      60,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase4TalentsArcane,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p4_arcane"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p4_arcane"),
-			Buffs:       core.FullBuffsPhase4,
-			Consumes:    Phase4Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Arcane", SpecOptions: PlayerOptionsArcane},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassMage,
-			Phase:      5,
-			Level:      60,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase5TalentsArcane,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p5_arcane"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p5_spellfrost"),
-			Buffs:       core.FullBuffsPhase5,
-			Consumes:    Phase5Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Arcane", SpecOptions: PlayerOptionsArcane},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-	}))
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(arcaneConfigs))
+}
+
+func BenchmarkArcane(b *testing.B) {
+	core.RunCharacterBenchmarks(b, arcaneConfigs)
+}
+
+var arcaneConfigs = []core.CharacterSuiteConfig{
+	{
+		Class:      proto.Class_ClassMage,
+		Level:      25,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase1TalentsArcane,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p1_generic"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p1_arcane"),
+		Buffs:       core.FullBuffsPhase1,
+		Consumes:    Phase1Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Arcane", SpecOptions: PlayerOptionsArcane},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassMage,
+		Level:      40,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase2TalentsArcane,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p2_arcane"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p2_arcane"),
+		Buffs:       core.FullBuffsPhase2,
+		Consumes:    Phase2Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Arcane", SpecOptions: PlayerOptionsArcane},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassMage,
+		Phase:      4,
+		Level:      60,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase4TalentsArcane,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p4_arcane"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p4_arcane"),
+		Buffs:       core.FullBuffsPhase4,
+		Consumes:    Phase4Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Arcane", SpecOptions: PlayerOptionsArcane},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassMage,
+		Phase:      5,
+		Level:      60,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase5TalentsArcane,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p5_arcane"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p5_spellfrost"),
+		Buffs:       core.FullBuffsPhase5,
+		Consumes:    Phase5Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Arcane", SpecOptions: PlayerOptionsArcane},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
 }
 
 func TestFire(t *testing.T) {
-	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-		{
-			Class:      proto.Class_ClassMage,
-			Level:      25,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase1TalentsFire,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p1_fire"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p1_fire"),
-			Buffs:       core.FullBuffsPhase1,
-			Consumes:    Phase1Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Fire", SpecOptions: PlayerOptionsFire},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassMage,
-			Level:      40,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase2TalentsFire,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p2_fire"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p2_fire"),
-			Buffs:       core.FullBuffsPhase2,
-			Consumes:    Phase2Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Fire", SpecOptions: PlayerOptionsFire},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassMage,
-			Level:      50,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase3TalentsFire,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p3_fire"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p3_fire"),
-			Buffs:       core.FullBuffsPhase3,
-			Consumes:    Phase3Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Fire", SpecOptions: PlayerOptionsFire},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassMage,
-			Phase:      4,
-			Level:      60,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase5TalentsFire,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p4_fire"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p4_fire"),
-			Buffs:       core.FullBuffsPhase4,
-			Consumes:    Phase4Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Fire", SpecOptions: PlayerOptionsFire},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassMage,
-			Phase:      5,
-			Level:      60,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase5TalentsFire,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p5_fire"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p5_fire"),
-			Buffs:       core.FullBuffsPhase5,
-			Consumes:    Phase5Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Fire", SpecOptions: PlayerOptionsFire},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-	}))
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(fireConfigs))
+}
+
+func BenchmarkFire(b *testing.B) {
+	core.RunCharacterBenchmarks(b, fireConfigs)
+}
+
+var fireConfigs = []core.CharacterSuiteConfig{
+	{
+		Class:      proto.Class_ClassMage,
+		Level:      25,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase1TalentsFire,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p1_fire"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p1_fire"),
+		Buffs:       core.FullBuffsPhase1,
+		Consumes:    Phase1Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Fire", SpecOptions: PlayerOptionsFire},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassMage,
+		Level:      40,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase2TalentsFire,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p2_fire"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p2_fire"),
+		Buffs:       core.FullBuffsPhase2,
+		Consumes:    Phase2Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Fire", SpecOptions: PlayerOptionsFire},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassMage,
+		Level:      50,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase3TalentsFire,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p3_fire"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p3_fire"),
+		Buffs:       core.FullBuffsPhase3,
+		Consumes:    Phase3Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Fire", SpecOptions: PlayerOptionsFire},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassMage,
+		Phase:      4,
+		Level:      60,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase5TalentsFire,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p4_fire"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p4_fire"),
+		Buffs:       core.FullBuffsPhase4,
+		Consumes:    Phase4Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Fire", SpecOptions: PlayerOptionsFire},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassMage,
+		Phase:      5,
+		Level:      60,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase5TalentsFire,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p5_fire"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p5_fire"),
+		Buffs:       core.FullBuffsPhase5,
+		Consumes:    Phase5Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Fire", SpecOptions: PlayerOptionsFire},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
 }
 
 func TestFrost(t *testing.T) {
-	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-		{
-			Class:      proto.Class_ClassMage,
-			Level:      50,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase3TalentsFrost,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p3_frost_ffb"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p3_frost"),
-			Buffs:       core.FullBuffsPhase3,
-			Consumes:    Phase3Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Frost", SpecOptions: PlayerOptionsFrost},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassMage,
-			Phase:      4,
-			Level:      60,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     Phase4TalentsFrost,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p4_frost"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p4_frost"),
-			Buffs:       core.FullBuffsPhase4,
-			Consumes:    Phase4Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Frost", SpecOptions: PlayerOptionsFrost},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassMage,
-			Phase:      5,
-			Level:      60,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceGnome},
-
-			Talents:     phase5talentsfrost,
-			GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p5_frost"),
-			Rotation:    core.GetAplRotation("../../ui/mage/apls", "p5_spellfrost"),
-			Buffs:       core.FullBuffsPhase5,
-			Consumes:    Phase5Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Frost", SpecOptions: PlayerOptionsFrost},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-	}))
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(frostConfigs))
+}
+
+func BenchmarkFrost(b *testing.B) {
+	core.RunCharacterBenchmarks(b, frostConfigs)
+}
+
+var frostConfigs = []core.CharacterSuiteConfig{
+	{
+		Class:      proto.Class_ClassMage,
+		Level:      50,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase3TalentsFrost,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p3_frost_ffb"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p3_frost"),
+		Buffs:       core.FullBuffsPhase3,
+		Consumes:    Phase3Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Frost", SpecOptions: PlayerOptionsFrost},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassMage,
+		Phase:      4,
+		Level:      60,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     Phase4TalentsFrost,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p4_frost"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p4_frost"),
+		Buffs:       core.FullBuffsPhase4,
+		Consumes:    Phase4Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Frost", SpecOptions: PlayerOptionsFrost},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassMage,
+		Phase:      5,
+		Level:      60,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceGnome},
+
+		Talents:     phase5talentsfrost,
+		GearSet:     core.GetGearSet("../../ui/mage/gear_sets", "p5_frost"),
+		Rotation:    core.GetAplRotation("../../ui/mage/apls", "p5_spellfrost"),
+		Buffs:       core.FullBuffsPhase5,
+		Consumes:    Phase5Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Frost", SpecOptions: PlayerOptionsFrost},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
 }
 
 var Phase1TalentsArcane = "22500502"
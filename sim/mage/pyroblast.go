@@ -100,7 +100,7 @@ func (mage *Mage) newPyroblastSpellConfig(rank int) core.SpellConfig {
 				mage.HotStreakAura.Deactivate(sim)
 			}
 
-			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 				spell.DealDamage(sim, result)
 
 				if result.Landed() {
@@ -56,13 +56,13 @@ func (paladin *Paladin) registerAvengersShield() {
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			apBonus := 0.091 * spell.MeleeAttackPower()
-			baseTravelTime := spell.TravelTime()
+			baseTravelTime := spell.TravelTimeTo(target)
 
 			if hasLibramOfAvenging {
 				// Libram of Avenging causes Avenger's Shield to be single target, but it
 				// hits the target twice. The second projectile fires after a fixed 1.5s delay.
 				firstHit := spell.CalcDamage(sim, target, sim.Roll(lowDamage, highDamage)+apBonus, spell.OutcomeRangedHitAndCrit)
-				spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+				spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 					spell.DealDamage(sim, firstHit)
 				})
 
@@ -76,16 +76,24 @@ func (paladin *Paladin) registerAvengersShield() {
 				})
 
 			} else {
-				interTargetTravelTime := int(float64(time.Second) * 3.0 / spell.MissileSpeed)
+				interTargetTravelTime := time.Duration(float64(time.Second) * 3.0 / spell.MissileSpeed)
+				delay := time.Duration(0)
+				var previousTarget *core.Unit
 				for i := 0; i < numTargets; i++ {
 					// Avenger's Shield bounces from target 1 > target 2 > target 3 at MissileSpeed.
-					// We approximate it by assuming targets are standing ~3 yds apart from each other.
-					// The damage for each target is therefore scheduled to arrive at:
+					// Without positions, we approximate it by assuming targets are standing ~3 yds
+					// apart from each other. The damage for each target is therefore scheduled to arrive at:
 					// T1 = (TravelTime from player; by default 5 yard max melee range)
 					// T2 = T1 + (3 yd TravelTime)
 					// T3 = T2 + (3 yd TravelTime)
+					if i > 0 {
+						if paladin.Env.UsePositions {
+							delay += time.Duration(float64(time.Second) * previousTarget.Position.DistanceTo(target.Position) / spell.MissileSpeed)
+						} else {
+							delay += interTargetTravelTime
+						}
+					}
 					baseDamage := sim.Roll(lowDamage, highDamage) + apBonus
-					delay := time.Duration(interTargetTravelTime * i)
 					nextTarget := target // create new ref for delayed action evaluation
 					result := spell.CalcDamage(sim, nextTarget, baseDamage, spell.OutcomeRangedHitAndCrit)
 
@@ -96,6 +104,7 @@ func (paladin *Paladin) registerAvengersShield() {
 
 						},
 					})
+					previousTarget = target
 					target = sim.NextTargetUnit(target)
 				}
 			}
@@ -41,12 +41,17 @@ func (paladin *Paladin) registerConsecration() {
 			break
 		}
 
+		// Consecration stays where it was cast, even if the paladin moves.
+		var aoeCenter core.Vector2
+
 		paladin.RegisterSpell(core.SpellConfig{
 			ActionID:    core.ActionID{SpellID: rank.spellID},
 			SpellSchool: core.SpellSchoolHoly,
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskSpellDamage,
 			Flags:       core.SpellFlagPureDot | core.SpellFlagAPL,
+			AoeRadius:   8,
+			AoeOrigin:   core.AoeOriginCaster,
 
 			RequiredLevel: int(rank.level),
 			Rank:          i + 1,
@@ -86,13 +91,14 @@ func (paladin *Paladin) registerConsecration() {
 					// Consecration can miss, showing up as either a resist in logs or a
 					// silent failure (missing damage tick).
 					outcomeApplier := core.Ternary(hasWrath, dot.OutcomeMagicHitAndSnapshotCrit, dot.Spell.OutcomeMagicHit)
-					for _, aoeTarget := range sim.Encounter.TargetUnits {
+					for _, aoeTarget := range dot.Spell.AoeTargetsAt(aoeCenter) {
 						dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, outcomeApplier)
 					}
 				},
 			},
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				aoeCenter = spell.AoeCenter(target)
 				spell.AOEDot().Apply(sim)
 			},
 		})
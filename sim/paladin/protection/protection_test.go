@@ -12,26 +12,32 @@ func init() {
 }
 
 func TestProtection(t *testing.T) {
-	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-		{
-			Class:      proto.Class_ClassPaladin,
-			Phase:      4,
-			Level:      60,
-			Race:       proto.Race_RaceHuman,
-			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
-
-			Talents:     Phase4ProtTalents,
-			GearSet:     core.GetGearSet("../../../ui/protection_paladin/gear_sets", "p4prot"),
-			Rotation:    core.GetAplRotation("../../../ui/protection_paladin/apls", "p4prot"),
-			Buffs:       core.FullBuffsPhase4,
-			Consumes:    Phase4Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "P4 Prot", SpecOptions: PlayerOptionsSealofMartyrdom},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-	}))
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(protectionConfigs))
+}
+
+func BenchmarkProtection(b *testing.B) {
+	core.RunCharacterBenchmarks(b, protectionConfigs)
+}
+
+var protectionConfigs = []core.CharacterSuiteConfig{
+	{
+		Class:      proto.Class_ClassPaladin,
+		Phase:      4,
+		Level:      60,
+		Race:       proto.Race_RaceHuman,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		Talents:     Phase4ProtTalents,
+		GearSet:     core.GetGearSet("../../../ui/protection_paladin/gear_sets", "p4prot"),
+		Rotation:    core.GetAplRotation("../../../ui/protection_paladin/apls", "p4prot"),
+		Buffs:       core.FullBuffsPhase4,
+		Consumes:    Phase4Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "P4 Prot", SpecOptions: PlayerOptionsSealofMartyrdom},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
 }
 
 var Phase4ProtTalents = "-053020335001551-0500535"
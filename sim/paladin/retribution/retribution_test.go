@@ -12,180 +12,208 @@ func init() {
 }
 
 func TestRetribution(t *testing.T) {
-	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-		{
-			Class:      proto.Class_ClassPaladin,
-			Level:      25,
-			Race:       proto.Race_RaceHuman,
-			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
-
-			Talents:     Phase1RetTalents,
-			GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p1ret"),
-			Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p1ret"),
-			Buffs:       core.FullBuffsPhase1,
-			Consumes:    Phase1Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "P1 Seal of Command Ret", SpecOptions: PlayerOptionsSealofCommand},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassPaladin,
-			Level:      40,
-			Race:       proto.Race_RaceHuman,
-			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
-
-			Talents:     Phase2RetTalents,
-			GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p2retsoc"),
-			Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p2ret"),
-			Buffs:       core.FullBuffsPhase2,
-			Consumes:    Phase2Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "P2 Seal of Command Ret", SpecOptions: PlayerOptionsSealofCommand},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassPaladin,
-			Level:      50,
-			Race:       proto.Race_RaceHuman,
-			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
-
-			Talents:     Phase3RetTalents,
-			GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p3retsom"),
-			Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p3ret"),
-			Buffs:       core.FullBuffsPhase3,
-			Consumes:    Phase3Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "P3 Seal of Martyrdom Ret", SpecOptions: PlayerOptionsSealofMartyrdom},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassPaladin,
-			Phase:      4,
-			Level:      60,
-			Race:       proto.Race_RaceHuman,
-			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
-
-			Talents:  Phase45RetTalents,
-			GearSet:  core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p4ret-twisting-6pcT1"),
-			Rotation: core.GetAplRotation("../../../ui/retribution_paladin/apls", "p4ret-twisting-6pcT1"),
-
-			OtherGearSets:  []core.GearSetCombo{core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p4rettwist")},
-			OtherRotations: []core.RotationCombo{core.GetAplRotation("../../../ui/retribution_paladin/apls", "p4ret")},
-			Buffs:          core.FullBuffsPhase5,
-			Consumes:       Phase4Consumes,
-			SpecOptions:    core.SpecOptionsCombo{Label: "P4 Seal of Martyrdom Ret", SpecOptions: PlayerOptionsSealofMartyrdom},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassPaladin,
-			Phase:      5,
-			Level:      60,
-			Race:       proto.Race_RaceHuman,
-			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
-
-			Talents:        Phase45RetTalents,
-			GearSet:        core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p5twisting"),
-			Rotation:       core.GetAplRotation("../../../ui/retribution_paladin/apls", "p5ret-twist-4DR-3.5-3.6"),
-			OtherRotations: []core.RotationCombo{core.GetAplRotation("../../../ui/retribution_paladin/apls", "p5ret-twist-4DR-3.7-4.0")},
-			Buffs:          core.FullBuffsPhase5,
-			Consumes:       Phase5Consumes,
-			SpecOptions:    core.SpecOptionsCombo{Label: "P5 Seal of Martyrdom Ret", SpecOptions: PlayerOptionsSealofMartyrdom},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-	}))
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(retributionConfigs))
+}
+
+func BenchmarkRetribution(b *testing.B) {
+	core.RunCharacterBenchmarks(b, retributionConfigs)
+}
+
+// The level 60 rotations guard rune spells with runeIsEquipped, which is always
+// true for the gear they were written for and shouldn't be reported.
+func TestRetributionRotationWarnings(t *testing.T) {
+	for _, config := range retributionConfigs[3:] {
+		if warnings := config.RotationWarnings(); len(warnings) > 0 {
+			t.Fatalf("Expected no warnings for %s, got %q", config.Rotation.Label, warnings)
+		}
+	}
+}
+
+var retributionConfigs = []core.CharacterSuiteConfig{
+	{
+		Class:      proto.Class_ClassPaladin,
+		Level:      25,
+		Race:       proto.Race_RaceHuman,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		Talents:     Phase1RetTalents,
+		GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p1ret"),
+		Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p1ret"),
+		Buffs:       core.FullBuffsPhase1,
+		Consumes:    Phase1Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "P1 Seal of Command Ret", SpecOptions: PlayerOptionsSealofCommand},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassPaladin,
+		Level:      40,
+		Race:       proto.Race_RaceHuman,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		Talents:     Phase2RetTalents,
+		GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p2retsoc"),
+		Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p2ret"),
+		Buffs:       core.FullBuffsPhase2,
+		Consumes:    Phase2Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "P2 Seal of Command Ret", SpecOptions: PlayerOptionsSealofCommand},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassPaladin,
+		Level:      50,
+		Race:       proto.Race_RaceHuman,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		Talents:     Phase3RetTalents,
+		GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p3retsom"),
+		Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p3ret"),
+		Buffs:       core.FullBuffsPhase3,
+		Consumes:    Phase3Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "P3 Seal of Martyrdom Ret", SpecOptions: PlayerOptionsSealofMartyrdom},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassPaladin,
+		Phase:      4,
+		Level:      60,
+		Race:       proto.Race_RaceHuman,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		Talents:  Phase45RetTalents,
+		GearSet:  core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p4ret-twisting-6pcT1"),
+		Rotation: core.GetAplRotation("../../../ui/retribution_paladin/apls", "p4ret-twisting-6pcT1"),
+
+		OtherGearSets:  []core.GearSetCombo{core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p4rettwist")},
+		OtherRotations: []core.RotationCombo{core.GetAplRotation("../../../ui/retribution_paladin/apls", "p4ret")},
+		Buffs:          core.FullBuffsPhase5,
+		Consumes:       Phase4Consumes,
+		SpecOptions:    core.SpecOptionsCombo{Label: "P4 Seal of Martyrdom Ret", SpecOptions: PlayerOptionsSealofMartyrdom},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassPaladin,
+		Phase:      5,
+		Level:      60,
+		Race:       proto.Race_RaceHuman,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		Talents:        Phase45RetTalents,
+		GearSet:        core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p5twisting"),
+		Rotation:       core.GetAplRotation("../../../ui/retribution_paladin/apls", "p5ret-twist-4DR-3.5-3.6"),
+		OtherRotations: []core.RotationCombo{core.GetAplRotation("../../../ui/retribution_paladin/apls", "p5ret-twist-4DR-3.7-4.0")},
+		Buffs:          core.FullBuffsPhase5,
+		Consumes:       Phase5Consumes,
+		SpecOptions:    core.SpecOptionsCombo{Label: "P5 Seal of Martyrdom Ret", SpecOptions: PlayerOptionsSealofMartyrdom},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
 }
 
 func TestExodin(t *testing.T) {
-	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-		{
-			Class:      proto.Class_ClassPaladin,
-			Phase:      4,
-			Level:      60,
-			Race:       proto.Race_RaceHuman,
-			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
-
-			Talents:     Phase45RetTalents,
-			GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p4ret-exodin-6pcT1"),
-			Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p4ret-exodin-6pcT1"),
-			Buffs:       core.FullBuffsPhase4,
-			Consumes:    Phase4Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "P4 Seal of Martyrdom Ret", SpecOptions: PlayerOptionsSealofMartyrdom},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassPaladin,
-			Phase:      5,
-			Level:      60,
-			Race:       proto.Race_RaceHuman,
-			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
-
-			Talents:     Phase45RetTalents,
-			GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p5exodin"),
-			Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p5ret-exodin-6CF2DR"),
-			Buffs:       core.FullBuffsPhase5,
-			Consumes:    Phase5Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "P5 Seal of Martyrdom Ret", SpecOptions: PlayerOptionsSealofMartyrdom},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-	}))
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(exodinConfigs))
+}
+
+func BenchmarkExodin(b *testing.B) {
+	core.RunCharacterBenchmarks(b, exodinConfigs)
+}
+
+var exodinConfigs = []core.CharacterSuiteConfig{
+	{
+		Class:      proto.Class_ClassPaladin,
+		Phase:      4,
+		Level:      60,
+		Race:       proto.Race_RaceHuman,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		Talents:     Phase45RetTalents,
+		GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p4ret-exodin-6pcT1"),
+		Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p4ret-exodin-6pcT1"),
+		Buffs:       core.FullBuffsPhase4,
+		Consumes:    Phase4Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "P4 Seal of Martyrdom Ret", SpecOptions: PlayerOptionsSealofMartyrdom},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassPaladin,
+		Phase:      5,
+		Level:      60,
+		Race:       proto.Race_RaceHuman,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		Talents:     Phase45RetTalents,
+		GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p5exodin"),
+		Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p5ret-exodin-6CF2DR"),
+		Buffs:       core.FullBuffsPhase5,
+		Consumes:    Phase5Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "P5 Seal of Martyrdom Ret", SpecOptions: PlayerOptionsSealofMartyrdom},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
 }
 
 func TestShockadin(t *testing.T) {
-	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-		{
-			Class:      proto.Class_ClassPaladin,
-			Level:      40,
-			Race:       proto.Race_RaceHuman,
-			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
-
-			Talents:     Phase2ShockadinTalents,
-			GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p2retsom"),
-			Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p2ret"),
-			Buffs:       core.FullBuffsPhase2,
-			Consumes:    Phase2Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "P2 Seal of Martyrdom Shockadin", SpecOptions: PlayerOptionsSealofMartyrdom},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-		{
-			Class:      proto.Class_ClassPaladin,
-			Phase:      5,
-			Level:      60,
-			Race:       proto.Race_RaceHuman,
-			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
-
-			Talents:     Phase45ShockadinTalents,
-			GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p5shockadin"),
-			Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p5Shockadin"),
-			Buffs:       core.FullBuffsPhase5,
-			Consumes:    Phase5Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "P5 Seal of Righteousness Shockadin", SpecOptions: PlayerOptionsSealofRighteousness},
-
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatSpellPower,
-			StatsToWeigh:    Stats,
-		},
-	}))
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(shockadinConfigs))
+}
+
+func BenchmarkShockadin(b *testing.B) {
+	core.RunCharacterBenchmarks(b, shockadinConfigs)
+}
+
+var shockadinConfigs = []core.CharacterSuiteConfig{
+	{
+		Class:      proto.Class_ClassPaladin,
+		Level:      40,
+		Race:       proto.Race_RaceHuman,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		Talents:     Phase2ShockadinTalents,
+		GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p2retsom"),
+		Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p2ret"),
+		Buffs:       core.FullBuffsPhase2,
+		Consumes:    Phase2Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "P2 Seal of Martyrdom Shockadin", SpecOptions: PlayerOptionsSealofMartyrdom},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassPaladin,
+		Phase:      5,
+		Level:      60,
+		Race:       proto.Race_RaceHuman,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		Talents:     Phase45ShockadinTalents,
+		GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p5shockadin"),
+		Rotation:    core.GetAplRotation("../../../ui/retribution_paladin/apls", "p5Shockadin"),
+		Buffs:       core.FullBuffsPhase5,
+		Consumes:    Phase5Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "P5 Seal of Righteousness Shockadin", SpecOptions: PlayerOptionsSealofRighteousness},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatSpellPower,
+		StatsToWeigh:    Stats,
+	},
 }
 
 var Phase1RetTalents = "--05230051"
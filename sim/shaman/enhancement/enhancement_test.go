@@ -13,122 +13,128 @@ func init() {
 }
 
 func TestEnhancement(t *testing.T) {
-	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-		{
-			Class:      proto.Class_ClassShaman,
-			Level:      25,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceOrc},
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(enhancementConfigs))
+}
+
+func BenchmarkEnhancement(b *testing.B) {
+	core.RunCharacterBenchmarks(b, enhancementConfigs)
+}
 
-			Talents:     Phase1Talents,
-			GearSet:     core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_1"),
-			Rotation:    core.GetAplRotation("../../../ui/enhancement_shaman/apls", "phase_1"),
-			Buffs:       core.FullBuffsPhase1,
-			Consumes:    Phase1Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Sync Auto", SpecOptions: PlayerOptionsSyncAuto},
-			OtherSpecOptions: []core.SpecOptionsCombo{
-				{Label: "Sync Delay OH", SpecOptions: PlayerOptionsSyncDelayOH},
-			},
+var enhancementConfigs = []core.CharacterSuiteConfig{
+	{
+		Class:      proto.Class_ClassShaman,
+		Level:      25,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceOrc},
 
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
+		Talents:     Phase1Talents,
+		GearSet:     core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_1"),
+		Rotation:    core.GetAplRotation("../../../ui/enhancement_shaman/apls", "phase_1"),
+		Buffs:       core.FullBuffsPhase1,
+		Consumes:    Phase1Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Sync Auto", SpecOptions: PlayerOptionsSyncAuto},
+		OtherSpecOptions: []core.SpecOptionsCombo{
+			{Label: "Sync Delay OH", SpecOptions: PlayerOptionsSyncDelayOH},
 		},
-		{
-			Class:      proto.Class_ClassShaman,
-			Level:      40,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceOrc},
 
-			Talents:  Phase2Talents,
-			GearSet:  core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_2"),
-			Rotation: core.GetAplRotation("../../../ui/enhancement_shaman/apls", "phase_2"),
-			Buffs:    core.FullBuffsPhase2,
-			Consumes: Phase2ConsumesWFWF,
-			OtherConsumes: []core.ConsumesCombo{
-				Phase2ConsumesWFFT,
-			},
-			SpecOptions: core.SpecOptionsCombo{Label: "Sync Auto", SpecOptions: PlayerOptionsSyncAuto},
-			OtherSpecOptions: []core.SpecOptionsCombo{
-				{Label: "Sync Delay OH", SpecOptions: PlayerOptionsSyncDelayOH},
-			},
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassShaman,
+		Level:      40,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceOrc},
 
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
+		Talents:  Phase2Talents,
+		GearSet:  core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_2"),
+		Rotation: core.GetAplRotation("../../../ui/enhancement_shaman/apls", "phase_2"),
+		Buffs:    core.FullBuffsPhase2,
+		Consumes: Phase2ConsumesWFWF,
+		OtherConsumes: []core.ConsumesCombo{
+			Phase2ConsumesWFFT,
+		},
+		SpecOptions: core.SpecOptionsCombo{Label: "Sync Auto", SpecOptions: PlayerOptionsSyncAuto},
+		OtherSpecOptions: []core.SpecOptionsCombo{
+			{Label: "Sync Delay OH", SpecOptions: PlayerOptionsSyncDelayOH},
 		},
-		{
-			Class:      proto.Class_ClassShaman,
-			Level:      50,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceOrc},
 
-			Talents:  Phase3Talents,
-			GearSet:  core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_3"),
-			Rotation: core.GetAplRotation("../../../ui/enhancement_shaman/apls", "phase_3"),
-			Buffs:    core.FullBuffsPhase3,
-			Consumes: Phase3ConsumesWFWF,
-			OtherConsumes: []core.ConsumesCombo{
-				Phase3ConsumesWFFT,
-			},
-			SpecOptions: core.SpecOptionsCombo{Label: "Sync Auto", SpecOptions: PlayerOptionsSyncAuto},
-			OtherSpecOptions: []core.SpecOptionsCombo{
-				{Label: "Sync Delay OH", SpecOptions: PlayerOptionsSyncDelayOH},
-			},
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassShaman,
+		Level:      50,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceOrc},
 
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
+		Talents:  Phase3Talents,
+		GearSet:  core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_3"),
+		Rotation: core.GetAplRotation("../../../ui/enhancement_shaman/apls", "phase_3"),
+		Buffs:    core.FullBuffsPhase3,
+		Consumes: Phase3ConsumesWFWF,
+		OtherConsumes: []core.ConsumesCombo{
+			Phase3ConsumesWFFT,
+		},
+		SpecOptions: core.SpecOptionsCombo{Label: "Sync Auto", SpecOptions: PlayerOptionsSyncAuto},
+		OtherSpecOptions: []core.SpecOptionsCombo{
+			{Label: "Sync Delay OH", SpecOptions: PlayerOptionsSyncDelayOH},
 		},
-		{
-			Class:      proto.Class_ClassShaman,
-			Phase:      4,
-			Level:      60,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceOrc},
 
-			Talents: Phase4Talents,
-			GearSet: core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_4_dw"),
-			OtherGearSets: []core.GearSetCombo{
-				core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_4_2h"),
-			},
-			Rotation:    core.GetAplRotation("../../../ui/enhancement_shaman/apls", "phase_4"),
-			Buffs:       core.FullBuffsPhase4,
-			Consumes:    Phase4ConsumesWFWF,
-			SpecOptions: core.SpecOptionsCombo{Label: "Sync Auto", SpecOptions: PlayerOptionsSyncAuto},
-			OtherSpecOptions: []core.SpecOptionsCombo{
-				{Label: "Sync Delay OH", SpecOptions: PlayerOptionsSyncDelayOH},
-			},
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassShaman,
+		Phase:      4,
+		Level:      60,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceOrc},
 
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
+		Talents: Phase4Talents,
+		GearSet: core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_4_dw"),
+		OtherGearSets: []core.GearSetCombo{
+			core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_4_2h"),
+		},
+		Rotation:    core.GetAplRotation("../../../ui/enhancement_shaman/apls", "phase_4"),
+		Buffs:       core.FullBuffsPhase4,
+		Consumes:    Phase4ConsumesWFWF,
+		SpecOptions: core.SpecOptionsCombo{Label: "Sync Auto", SpecOptions: PlayerOptionsSyncAuto},
+		OtherSpecOptions: []core.SpecOptionsCombo{
+			{Label: "Sync Delay OH", SpecOptions: PlayerOptionsSyncDelayOH},
 		},
-		{
-			Class:      proto.Class_ClassShaman,
-			Phase:      5,
-			Level:      60,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceOrc},
 
-			Talents: Phase4Talents,
-			GearSet: core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_5_dw"),
-			OtherGearSets: []core.GearSetCombo{
-				core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_5_2h"),
-			},
-			Rotation:    core.GetAplRotation("../../../ui/enhancement_shaman/apls", "phase_5"),
-			Buffs:       core.FullBuffsPhase5,
-			Consumes:    Phase4ConsumesWFWF,
-			SpecOptions: core.SpecOptionsCombo{Label: "Sync Auto", SpecOptions: PlayerOptionsSyncAuto},
-			OtherSpecOptions: []core.SpecOptionsCombo{
-				{Label: "Sync Delay OH", SpecOptions: PlayerOptionsSyncDelayOH},
-			},
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
+	{
+		Class:      proto.Class_ClassShaman,
+		Phase:      5,
+		Level:      60,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceOrc},
 
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
+		Talents: Phase4Talents,
+		GearSet: core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_5_dw"),
+		OtherGearSets: []core.GearSetCombo{
+			core.GetGearSet("../../../ui/enhancement_shaman/gear_sets", "phase_5_2h"),
 		},
-	}))
+		Rotation:    core.GetAplRotation("../../../ui/enhancement_shaman/apls", "phase_5"),
+		Buffs:       core.FullBuffsPhase5,
+		Consumes:    Phase4ConsumesWFWF,
+		SpecOptions: core.SpecOptionsCombo{Label: "Sync Auto", SpecOptions: PlayerOptionsSyncAuto},
+		OtherSpecOptions: []core.SpecOptionsCombo{
+			{Label: "Sync Delay OH", SpecOptions: PlayerOptionsSyncDelayOH},
+		},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
 }
 
 var Phase1Talents = "-5005202101"
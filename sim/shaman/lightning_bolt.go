@@ -65,7 +65,7 @@ func (shaman *Shaman) newLightningBoltSpellConfig(rank int, isOverload bool) cor
 		baseDamage := sim.Roll(baseDamageLow, baseDamageHigh)
 		result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
 
-		spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+		spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 			spell.DealDamage(sim, result)
 
 			if canOverload && sim.Proc(ShamanOverloadChance, "LB Overload") {
@@ -12,26 +12,32 @@ func init() {
 }
 
 func TestWardenShaman(t *testing.T) {
-	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-		{
-			Class:      proto.Class_ClassShaman,
-			Phase:      4,
-			Level:      60,
-			Race:       proto.Race_RaceTroll,
-			OtherRaces: []proto.Race{proto.Race_RaceOrc},
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(wardenShamanConfigs))
+}
+
+func BenchmarkWardenShaman(b *testing.B) {
+	core.RunCharacterBenchmarks(b, wardenShamanConfigs)
+}
 
-			Talents:     Phase4Talents,
-			GearSet:     core.GetGearSet("../../../ui/warden_shaman/gear_sets", "phase_4_enh_tank"),
-			Rotation:    core.GetAplRotation("../../../ui/warden_shaman/apls", "phase_4_enh_tank"),
-			Buffs:       core.FullBuffsPhase4,
-			Consumes:    Phase4Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsBasic},
+var wardenShamanConfigs = []core.CharacterSuiteConfig{
+	{
+		Class:      proto.Class_ClassShaman,
+		Phase:      4,
+		Level:      60,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceOrc},
 
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-	}))
+		Talents:     Phase4Talents,
+		GearSet:     core.GetGearSet("../../../ui/warden_shaman/gear_sets", "phase_4_enh_tank"),
+		Rotation:    core.GetAplRotation("../../../ui/warden_shaman/apls", "phase_4_enh_tank"),
+		Buffs:       core.FullBuffsPhase4,
+		Consumes:    Phase4Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsBasic},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
 }
 
 var PlayerOptionsBasic = &proto.Player_WardenShaman{
@@ -0,0 +1,87 @@
+package dps
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Warlock demons use an APL rotation, so the player can choose which abilities
+// they cast. This tree doesn't have Felhunter abilities, so the test uses the
+// Succubus' Lash of Pain instead.
+func TestPetRotationForDemons(t *testing.T) {
+	lashOfPain := core.ActionID{SpellID: 7816}
+
+	lashOfPainCasts := func(petRotations []*proto.PetRotation) int32 {
+		rsr := &proto.RaidSimRequest{
+			Raid: core.SinglePlayerRaidProto(&proto.Player{
+				Name:      "Warlock",
+				Class:     proto.Class_ClassWarlock,
+				Race:      proto.Race_RaceOrc,
+				Level:     40,
+				Equipment: &proto.EquipmentSpec{},
+				Consumes:  &proto.Consumes{},
+				Buffs:     &proto.IndividualBuffs{},
+				Spec: &proto.Player_Warlock{Warlock: &proto.Warlock{Options: &proto.WarlockOptions{
+					Armor:  proto.WarlockOptions_FelArmor,
+					Summon: proto.WarlockOptions_Succubus,
+				}}},
+				Rotation:     &proto.APLRotation{Type: proto.APLRotation_TypeAPL},
+				PetRotations: petRotations,
+			}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+			Encounter: &proto.Encounter{
+				Targets:  []*proto.Target{{Name: "target", Level: 43, MobType: proto.MobType_MobTypeDemon}},
+				Duration: 60,
+			},
+			SimOptions: &proto.SimOptions{Iterations: 1, RandomSeed: 1},
+		}
+
+		stats := core.ComputeStats(&proto.ComputeStatsRequest{Raid: rsr.Raid, Encounter: rsr.Encounter})
+		if stats.ErrorResult != "" {
+			t.Fatal(stats.ErrorResult)
+		}
+		if warnings := stats.RaidStats.Parties[0].Players[0].Warnings; len(warnings) > 0 {
+			t.Fatalf("Expected no warnings, got %q", warnings)
+		}
+
+		result := core.RunRaidSim(rsr)
+		if result.ErrorResult != "" {
+			t.Fatal(result.ErrorResult)
+		}
+		pets := result.RaidMetrics.Parties[0].Players[0].Pets
+		succubusIdx := slices.IndexFunc(pets, func(pet *proto.UnitMetrics) bool { return pet.Name == "Succubus" })
+		if succubusIdx < 0 {
+			t.Fatalf("Expected metrics for the Succubus")
+		}
+		if pets[succubusIdx].Dps.Avg <= 0 {
+			t.Fatalf("Expected the Succubus to deal damage")
+		}
+
+		casts := int32(0)
+		for _, action := range pets[succubusIdx].Actions {
+			if core.ProtoToActionID(action.Id) == lashOfPain {
+				for _, target := range action.Targets {
+					casts += target.Casts
+				}
+			}
+		}
+		return casts
+	}
+
+	if casts := lashOfPainCasts(nil); casts == 0 {
+		t.Fatalf("Expected the default rotation to cast Lash of Pain")
+	}
+
+	// Without Lash of Pain in its rotation the Succubus only auto attacks.
+	autoAttacksOnly := []*proto.PetRotation{{PetName: "Succubus", Rotation: &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PriorityList: []*proto.APLListItem{{Action: &proto.APLAction{
+			Action: &proto.APLAction_AutocastOtherCooldowns{AutocastOtherCooldowns: &proto.APLActionAutocastOtherCooldowns{}},
+		}}},
+	}}}
+	if casts := lashOfPainCasts(autoAttacksOnly); casts != 0 {
+		t.Fatalf("Expected no Lash of Pain casts without it in the rotation, got %d", casts)
+	}
+}
@@ -57,7 +57,7 @@ func (warlock *Warlock) registerIncinerateSpell() {
 			var baseDamage = sim.Roll(baseLowDamage, baseHighDamage)
 			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
 			warlock.IncinerateAura.Activate(sim)
-			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
+			spell.WaitTravelTime(sim, target, func(sim *core.Simulation) {
 				spell.DealDamage(sim, result)
 			})
 		},
@@ -2,7 +2,7 @@ package warlock
 
 import (
 	"math"
-	"time"
+	"strconv"
 
 	"github.com/wowsims/sod/sim/core"
 	"github.com/wowsims/sod/sim/core/proto"
@@ -23,8 +23,6 @@ type WarlockPet struct {
 	DanceOfTheWickedManaMetrics *core.ResourceMetrics
 	LifeTapManaMetrics          *core.ResourceMetrics
 	T1Tank4PManaMetrics         *core.ResourceMetrics // https://www.wowhead.com/classic/spell=457572/s03-item-t1-warlock-tank-4p-bonus
-
-	manaPooling bool
 }
 
 type PetConfig struct {
@@ -88,6 +86,7 @@ func (warlock *Warlock) makePet(cfg PetConfig, enabledOnStart bool) *WarlockPet
 		owner: warlock,
 	}
 
+	wp.SupportsAPLRotation = true
 	wp.EnableManaBarWithModifier(cfg.PowerModifier)
 
 	if cfg.Name == "Imp" {
@@ -129,6 +128,12 @@ func (warlock *Warlock) registerPetAbilities() {
 	}
 	warlock.Imp.registerImpFireboltSpell()
 	warlock.Succubus.registerSuccubusLashOfPainSpell()
+
+	for _, pet := range warlock.BasePets {
+		if pet.primaryAbility != nil {
+			pet.DefaultRotation = pet.defaultRotation()
+		}
+	}
 }
 
 func (wp *WarlockPet) GetPet() *core.Pet {
@@ -141,41 +146,60 @@ func (wp *WarlockPet) Initialize() {
 func (wp *WarlockPet) Reset(_ *core.Simulation) {
 }
 
-func (wp *WarlockPet) ExecuteCustomRotation(sim *core.Simulation) {
-	if !wp.IsEnabled() || wp.primaryAbility == nil {
-		return
+// Casts the primary ability whenever possible. Unless the player turned pet
+// management off, a demon which runs out of mana pools until it is nearly full
+// or has enough mana to keep casting until the end of the fight.
+func (wp *WarlockPet) defaultRotation() *proto.APLRotation {
+	spellID := wp.primaryAbility.ActionID.ToProto()
+	priorityList := []*proto.APLListItem{
+		{Action: &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: spellID}}}},
 	}
 
-	if wp.manaPooling {
-		maxPossibleCasts := sim.GetRemainingDuration().Seconds() / wp.primaryAbility.CurCast.CastTime.Seconds()
-
-		if wp.CurrentMana() > (maxPossibleCasts*wp.primaryAbility.CurCast.Cost)*0.75 {
-			wp.manaPooling = false
-			wp.WaitUntil(sim, sim.CurrentTime+10*time.Millisecond)
-			return
+	if !wp.owner.Options.PetPoolMana {
+		currentCost := &proto.APLValue{Value: &proto.APLValue_SpellCurrentCost{SpellCurrentCost: &proto.APLValueSpellCurrentCost{SpellId: spellID}}}
+		donePooling := []*proto.APLValue{
+			aplCompare(proto.APLValueCompare_OpGe,
+				&proto.APLValue{Value: &proto.APLValue_CurrentManaPercent{CurrentManaPercent: &proto.APLValueCurrentManaPercent{}}},
+				aplConst("94%")),
 		}
-
-		if wp.CurrentMana() >= wp.MaxMana()*0.94 {
-			wp.manaPooling = false
-			wp.WaitUntil(sim, sim.CurrentTime+10*time.Millisecond)
-			return
+		if castTime := wp.primaryAbility.DefaultCast.CastTime; castTime > 0 {
+			// Enough mana for 75% of the casts which fit in the remaining time.
+			remainingCasts := aplMath(proto.APLValueMath_OpDiv,
+				&proto.APLValue{Value: &proto.APLValue_CurrentMana{CurrentMana: &proto.APLValueCurrentMana{}}},
+				currentCost)
+			donePooling = append(donePooling, aplCompare(proto.APLValueCompare_OpLt,
+				&proto.APLValue{Value: &proto.APLValue_RemainingTime{RemainingTime: &proto.APLValueRemainingTime{}}},
+				aplMath(proto.APLValueMath_OpMul, remainingCasts, aplConst(strconv.FormatFloat(castTime.Seconds()/0.75, 'f', -1, 64)))))
 		}
 
-		if wp.manaPooling {
-			return
-		}
+		priorityList = append(priorityList, &proto.APLListItem{
+			Action: &proto.APLAction{
+				Condition: &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: []*proto.APLValue{
+					{Value: &proto.APLValue_SpellIsReady{SpellIsReady: &proto.APLValueSpellIsReady{SpellId: spellID}}},
+					aplCompare(proto.APLValueCompare_OpLt,
+						&proto.APLValue{Value: &proto.APLValue_CurrentMana{CurrentMana: &proto.APLValueCurrentMana{}}},
+						currentCost),
+				}}}},
+				Action: &proto.APLAction_WaitUntil{WaitUntil: &proto.APLActionWaitUntil{
+					Condition: &proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: donePooling}}},
+				}},
+			},
+		})
 	}
 
-	if !wp.primaryAbility.IsReady(sim) {
-		wp.WaitUntil(sim, wp.primaryAbility.CD.ReadyAt())
-		return
-	}
+	return &proto.APLRotation{PriorityList: priorityList}
+}
 
-	if wp.Unit.CurrentMana() >= wp.primaryAbility.CurCast.Cost {
-		wp.primaryAbility.Cast(sim, wp.CurrentTarget)
-	} else if !wp.owner.Options.PetPoolMana {
-		wp.manaPooling = true
-	}
+func aplConst(val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}}
+}
+
+func aplCompare(op proto.APLValueCompare_ComparisonOperator, lhs *proto.APLValue, rhs *proto.APLValue) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{Op: op, Lhs: lhs, Rhs: rhs}}}
+}
+
+func aplMath(op proto.APLValueMath_MathOperator, lhs *proto.APLValue, rhs *proto.APLValue) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{Op: op, Lhs: lhs, Rhs: rhs}}}
 }
 
 func (warlock *Warlock) makeStatInheritance() core.PetStatInheritance {
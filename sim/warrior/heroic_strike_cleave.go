@@ -90,6 +90,7 @@ func (warrior *Warrior) registerCleaveSpell(realismICD *core.Cooldown) {
 	flatDamageBonus *= []float64{1, 1.4, 1.8, 2.2}[warrior.Talents.ImprovedCleave]
 
 	results := make([]*core.SpellResult, min(int32(2), warrior.Env.GetNumTargets()))
+	cleaveTargets := make([]*core.Unit, 0, len(results))
 
 	warrior.Cleave = warrior.RegisterSpell(AnyStance, core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: spellID},
@@ -97,6 +98,9 @@ func (warrior *Warrior) registerCleaveSpell(realismICD *core.Cooldown) {
 		DefenseType: core.DefenseTypeMelee,
 		ProcMask:    core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeMHAuto,
 		Flags:       core.SpellFlagMeleeMetrics | SpellFlagOffensive,
+		AoeRadius:   core.MaxMeleeAttackDistance,
+		AoeArc:      180,
+		AoeOrigin:   core.AoeOriginCaster,
 
 		RageCost: core.RageCostOptions{
 			Cost: 20,
@@ -110,13 +114,24 @@ func (warrior *Warrior) registerCleaveSpell(realismICD *core.Cooldown) {
 		BonusCoefficient: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for idx := range results {
+			// With positions, the second enemy has to be in front of the warrior.
+			cleaveTargets = append(cleaveTargets[:0], target)
+			if sim.Environment.UsePositions {
+				for _, aoeTarget := range spell.AoeTargets(target) {
+					if aoeTarget != target && len(cleaveTargets) < len(results) {
+						cleaveTargets = append(cleaveTargets, aoeTarget)
+					}
+				}
+			} else if len(results) > 1 {
+				cleaveTargets = append(cleaveTargets, sim.Environment.NextTargetUnit(target))
+			}
+
+			for idx, cleaveTarget := range cleaveTargets {
 				baseDamage := flatDamageBonus + spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
-				results[idx] = spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
-				target = sim.Environment.NextTargetUnit(target)
+				results[idx] = spell.CalcDamage(sim, cleaveTarget, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:len(cleaveTargets)] {
 				spell.DealDamage(sim, result)
 			}
 
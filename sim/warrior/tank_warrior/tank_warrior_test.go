@@ -13,26 +13,32 @@ func init() {
 }
 
 func TestTankWarrior(t *testing.T) {
-	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-		{
-			Class:      proto.Class_ClassWarrior,
-			Phase:      4,
-			Level:      60,
-			Race:       proto.Race_RaceOrc,
-			OtherRaces: []proto.Race{proto.Race_RaceHuman},
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(tankWarriorConfigs))
+}
+
+func BenchmarkTankWarrior(b *testing.B) {
+	core.RunCharacterBenchmarks(b, tankWarriorConfigs)
+}
 
-			Talents:     P4Talents,
-			GearSet:     core.GetGearSet("../../../ui/tank_warrior/gear_sets", "phase_4_tanky"),
-			Rotation:    core.GetAplRotation("../../../ui/tank_warrior/apls", "phase_4"),
-			Buffs:       core.FullBuffsPhase4,
-			Consumes:    Phase4Consumes,
-			SpecOptions: core.SpecOptionsCombo{Label: "Arms", SpecOptions: PlayerOptionsBasic},
+var tankWarriorConfigs = []core.CharacterSuiteConfig{
+	{
+		Class:      proto.Class_ClassWarrior,
+		Phase:      4,
+		Level:      60,
+		Race:       proto.Race_RaceOrc,
+		OtherRaces: []proto.Race{proto.Race_RaceHuman},
 
-			ItemFilter:      ItemFilters,
-			EPReferenceStat: proto.Stat_StatAttackPower,
-			StatsToWeigh:    Stats,
-		},
-	}))
+		Talents:     P4Talents,
+		GearSet:     core.GetGearSet("../../../ui/tank_warrior/gear_sets", "phase_4_tanky"),
+		Rotation:    core.GetAplRotation("../../../ui/tank_warrior/apls", "phase_4"),
+		Buffs:       core.FullBuffsPhase4,
+		Consumes:    Phase4Consumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Arms", SpecOptions: PlayerOptionsBasic},
+
+		ItemFilter:      ItemFilters,
+		EPReferenceStat: proto.Stat_StatAttackPower,
+		StatsToWeigh:    Stats,
+	},
 }
 
 var P4Talents = "20304300302-03-55200110530201051"
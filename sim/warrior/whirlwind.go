@@ -27,6 +27,8 @@ func (warrior *Warrior) registerWhirlwindSpell() {
 		DefenseType: core.DefenseTypeMelee,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
 		Flags:       core.SpellFlagAPL | SpellFlagOffensive,
+		AoeRadius:   8,
+		AoeOrigin:   core.AoeOriginCaster,
 
 		RageCost: core.RageCostOptions{
 			Cost: 25,
@@ -42,8 +44,8 @@ func (warrior *Warrior) registerWhirlwindSpell() {
 			},
 		},
 
-		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				warrior.WhirlwindMH.Cast(sim, aoeTarget)
 				if canHitOffhand && warrior.IsEnraged() {
 					warrior.WhirlwindOH.Cast(sim, aoeTarget)
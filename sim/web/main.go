@@ -12,6 +12,7 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"runtime"
 	"runtime/pprof"
 	"strings"
 	"sync"
@@ -25,8 +26,10 @@ import (
 	"github.com/wowsims/sod/sim"
 	"github.com/wowsims/sod/sim/core"
 	proto "github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simcache"
 
 	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 func init() {
@@ -48,6 +51,9 @@ func main() {
 	var host = flag.String("host", "localhost:3333", "URL to host the interface on.")
 	var launch = flag.Bool("launch", true, "auto launch browser")
 	var skipVersionCheck = flag.Bool("nvc", false, "set true to skip version check")
+	var cacheEntries = flag.Int("cache_entries", 100, "Number of sim results with a fixed seed to cache in memory. Requests with a 'Cache-Control: no-cache' header bypass the cache.")
+	var cacheDir = flag.String("cache_dir", "", "Directory to also cache sim results in, so they are kept across restarts.")
+	var threads = flag.Int("threads", runtime.NumCPU(), "Number of parallel workers for each sim, unless the request sets its own. 0 runs iterations sequentially.")
 
 	flag.Parse()
 
@@ -85,6 +91,10 @@ func main() {
 	s := &server{
 		progMut:         sync.RWMutex{},
 		asyncProgresses: map[string]*asyncProgress{},
+		numThreads:      int32(*threads),
+	}
+	if *cacheEntries > 0 || *cacheDir != "" {
+		s.cache = simcache.New(Version, *cacheEntries, *cacheDir)
 	}
 	s.runServer(*useFS, *host, *launch, *simName, *wasm, bufio.NewReader(os.Stdin))
 }
@@ -97,6 +107,15 @@ var handlers = map[string]apiHandler{
 	"/statWeights": {msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.StatWeights(msg.(*proto.StatWeightsRequest))
 	}},
+	"/buffAttribution": {msg: func() googleProto.Message { return &proto.BuffAttributionRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.BuffAttribution(msg.(*proto.BuffAttributionRequest))
+	}},
+	"/compareSims": {msg: func() googleProto.Message { return &proto.CompareSimsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.CompareSims(msg.(*proto.CompareSimsRequest))
+	}},
+	"/validateRaidSim": {msg: func() googleProto.Message { return &proto.RaidSimRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.ValidateRaidSimRequest(msg.(*proto.RaidSimRequest))
+	}},
 	"/computeStats": {msg: func() googleProto.Message { return &proto.ComputeStatsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.ComputeStats(msg.(*proto.ComputeStatsRequest))
 	}},
@@ -109,6 +128,9 @@ var asyncAPIHandlers = map[string]asyncAPIHandler{
 	"/statWeightsAsync": {msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
 		core.StatWeightsAsync(msg.(*proto.StatWeightsRequest), reporter)
 	}},
+	"/buffAttributionAsync": {msg: func() googleProto.Message { return &proto.BuffAttributionRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.BuffAttributionAsync(msg.(*proto.BuffAttributionRequest), reporter)
+	}},
 	"/bulkSimAsync": {msg: func() googleProto.Message { return &proto.BulkSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
 		// TODO: we can use context's to cancel stuff.
 		// We should have all the async APIs take in context and let it be cancelled via its async ID.
@@ -119,6 +141,63 @@ var asyncAPIHandlers = map[string]asyncAPIHandler{
 type server struct {
 	progMut         sync.RWMutex
 	asyncProgresses map[string]*asyncProgress
+	cache           *simcache.Cache
+	numThreads      int32
+}
+
+// Returns the key to cache the result of this request under, or "" if it
+// shouldn't be cached.
+func (s *server) cacheKey(r *http.Request, msg googleProto.Message) string {
+	if s.cache == nil || r.Header.Get("Cache-Control") == "no-cache" {
+		return ""
+	}
+	hash := simcache.RequestHash(msg)
+	if hash == "" {
+		return ""
+	}
+	return r.URL.Path + ":" + hash
+}
+
+// Caches a result unless it contains an error. outbytes is the serialized
+// result, or nil if it hasn't been serialized yet.
+func (s *server) cacheResult(key string, result googleProto.Message, outbytes []byte) {
+	if key == "" || resultHasError(result.ProtoReflect()) {
+		return
+	}
+	if outbytes == nil {
+		var err error
+		if outbytes, err = googleProto.Marshal(result); err != nil {
+			return
+		}
+	}
+	if err := s.cache.Put(key, outbytes); err != nil {
+		log.Printf("[ERROR] Failed to cache result: %s", err.Error())
+	}
+}
+
+// Returns whether any error_result field in the result is set.
+func resultHasError(result protoreflect.Message) bool {
+	hasError := false
+	result.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Name() == "error_result" && v.String() != "" {
+			hasError = true
+		} else if fd.Kind() == protoreflect.MessageKind && fd.Cardinality() != protoreflect.Repeated && resultHasError(v.Message()) {
+			hasError = true
+		}
+		return !hasError
+	})
+	return hasError
+}
+
+func setCacheHeader(w http.ResponseWriter, key string, hit bool) {
+	if key == "" {
+		return
+	}
+	if hit {
+		w.Header().Set("X-Sim-Cache", "hit")
+	} else {
+		w.Header().Set("X-Sim-Cache", "miss")
+	}
 }
 
 type apiHandler struct {
@@ -168,6 +247,19 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	core.SetDefaultNumThreads(msg, s.numThreads)
+
+	cacheKey := s.cacheKey(r, msg)
+	if cached, ok := s.cache.Get(cacheKey); ok {
+		finalProgress := &proto.ProgressMetrics{}
+		if err := googleProto.Unmarshal(cached, finalProgress); err == nil {
+			simProgress := s.addNewSim()
+			simProgress.latestProgress.Store(finalProgress)
+			setCacheHeader(w, cacheKey, true)
+			s.writeAsyncAPIResult(w, simProgress)
+			return
+		}
+	}
 
 	// reporter channel is handed into the core simulation.
 	//  as the simulation advances it will push changes to the channel
@@ -194,13 +286,19 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 				simProgress.latestProgress.Store(progMetric)
-				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil {
+				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil || progMetric.FinalBuffAttributionResult != nil {
+					s.cacheResult(cacheKey, progMetric, nil)
 					return
 				}
 			}
 		}
 	}()
 
+	setCacheHeader(w, cacheKey, false)
+	s.writeAsyncAPIResult(w, simProgress)
+}
+
+func (s *server) writeAsyncAPIResult(w http.ResponseWriter, simProgress *asyncProgress) {
 	protoResult := &proto.AsyncAPIResult{
 		ProgressId: simProgress.id,
 	}
@@ -252,7 +350,7 @@ func (s *server) setupAsyncServer() {
 		}
 
 		// If this was the last result, delete the cache for this simulation.
-		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil {
+		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil || latest.FinalBuffAttributionResult != nil {
 			s.progMut.Lock()
 			delete(s.asyncProgresses, msg.ProgressId)
 			s.progMut.Unlock()
@@ -265,7 +363,8 @@ func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, Cache-Control")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Sim-Cache")
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
 			return
@@ -286,7 +385,7 @@ func (s *server) runServer(useFS bool, host string, launchBrowser bool, simName
 	}
 
 	for route := range handlers {
-		http.Handle(route, corsMiddleware(http.HandlerFunc(handleAPI)))
+		http.Handle(route, corsMiddleware(http.HandlerFunc(s.handleAPI)))
 	}
 
 	http.HandleFunc("/version", func(resp http.ResponseWriter, req *http.Request) {
@@ -398,7 +497,7 @@ func (s *server) runServer(useFS bool, host string, launchBrowser bool, simName
 }
 
 // handleAPI is generic handler for any api function using protos.
-func handleAPI(w http.ResponseWriter, r *http.Request) {
+func (s *server) handleAPI(w http.ResponseWriter, r *http.Request) {
 	endpoint := r.URL.Path
 
 	body, err := io.ReadAll(r.Body)
@@ -424,6 +523,15 @@ func handleAPI(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	core.SetDefaultNumThreads(msg, s.numThreads)
+
+	cacheKey := s.cacheKey(r, msg)
+	if cached, ok := s.cache.Get(cacheKey); ok {
+		setCacheHeader(w, cacheKey, true)
+		w.Header().Add("Content-Type", "application/x-protobuf")
+		w.Write(cached)
+		return
+	}
 
 	result := handler.handle(msg)
 
@@ -433,7 +541,9 @@ func handleAPI(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	s.cacheResult(cacheKey, result, outbytes)
 
+	setCacheHeader(w, cacheKey, false)
 	w.Header().Add("Content-Type", "application/x-protobuf")
 	w.Write(outbytes)
 }
@@ -0,0 +1,186 @@
+// Compares `go test -bench` output read from stdin against a baseline file, and
+// fails if the benchmarks got slower or allocate more than the baseline allows.
+//
+// go test --tags=with_db -run=^$ -bench=. -p=1 -benchtime=50x -benchmem ./sim/... | go run ./tools/benchgate -baseline=sim/bench_baseline.txt
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var baselineFile = flag.String("baseline", "sim/bench_baseline.txt", "Path to the baseline file.")
+var update = flag.Bool("update", false, "Overwrite the baseline with the new results instead of comparing against it.")
+var nsTolerance = flag.Float64("ns-tolerance", 0.25, "Allowed relative increase in the geometric mean of ns/op. Timings depend on the machine, so a baseline is only meaningful on the machine it was recorded on.")
+var allocsTolerance = flag.Float64("allocs-tolerance", 0.05, "Allowed relative increase in allocs/op.")
+
+type benchResult struct {
+	nsPerOp     float64
+	allocsPerOp float64
+}
+
+var benchLineRegex = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+\d+\s+(.*)$`)
+
+// Parses benchmark results, keyed by package and benchmark name.
+func parseBenchOutput(r io.Reader) (map[string]benchResult, error) {
+	results := make(map[string]benchResult)
+	pkg := ""
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if after, ok := strings.CutPrefix(line, "pkg: "); ok {
+			pkg = strings.TrimSpace(after)
+			continue
+		}
+		match := benchLineRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		var result benchResult
+		fields := strings.Fields(match[2])
+		for i := 0; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value in %q: %w", line, err)
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				result.nsPerOp = value
+			case "allocs/op":
+				result.allocsPerOp = value
+			}
+		}
+		results[pkg+"."+match[1]] = result
+	}
+	return results, scanner.Err()
+}
+
+func readBaseline(path string) (map[string]benchResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	baseline := make(map[string]benchResult)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid baseline line: %q", scanner.Text())
+		}
+		ns, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		allocs, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, err
+		}
+		baseline[fields[0]] = benchResult{nsPerOp: ns, allocsPerOp: allocs}
+	}
+	return baseline, scanner.Err()
+}
+
+func writeBaseline(path string, results map[string]benchResult) error {
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var sb strings.Builder
+	sb.WriteString("# Generated by tools/benchgate with -update. Columns: benchmark, ns/op, allocs/op.\n")
+	for _, name := range names {
+		fmt.Fprintf(&sb, "%s %.0f %.0f\n", name, results[name].nsPerOp, results[name].allocsPerOp)
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
+
+// Compares the results against the baseline. Allocations are deterministic, so
+// any benchmark allocating more than the tolerance allows is a failure. Timings
+// of single benchmarks are too noisy for that, so those are only warnings, and
+// the geometric mean of the ns/op ratios is what has to stay within tolerance.
+func compare(baseline map[string]benchResult, results map[string]benchResult) (failures []string, warnings []string) {
+	logRatioSum := 0.0
+	numCompared := 0
+	for name, result := range results {
+		base, ok := baseline[name]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("%s: not in the baseline", name))
+			continue
+		}
+		if result.allocsPerOp > base.allocsPerOp*(1+*allocsTolerance) {
+			failures = append(failures, fmt.Sprintf("%s: %.0f allocs/op, baseline %.0f", name, result.allocsPerOp, base.allocsPerOp))
+		}
+		if base.nsPerOp > 0 && result.nsPerOp > 0 {
+			if result.nsPerOp > base.nsPerOp*(1+*nsTolerance) {
+				warnings = append(warnings, fmt.Sprintf("%s: %.0f ns/op, baseline %.0f", name, result.nsPerOp, base.nsPerOp))
+			}
+			logRatioSum += math.Log(result.nsPerOp / base.nsPerOp)
+			numCompared++
+		}
+	}
+
+	if numCompared > 0 {
+		geomeanRatio := math.Exp(logRatioSum / float64(numCompared))
+		fmt.Printf("Geometric mean of ns/op relative to the baseline: %.3f\n", geomeanRatio)
+		if geomeanRatio > 1+*nsTolerance {
+			failures = append(failures, fmt.Sprintf("ns/op is %.1f%% higher than the baseline on average", (geomeanRatio-1)*100))
+		}
+	}
+
+	slices.Sort(failures)
+	slices.Sort(warnings)
+	return failures, warnings
+}
+
+func main() {
+	flag.Parse()
+
+	results, err := parseBenchOutput(io.TeeReader(os.Stdin, os.Stdout))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(results) == 0 {
+		log.Fatal("No benchmark results found in the input")
+	}
+
+	if *update {
+		if err := writeBaseline(*baselineFile, results); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Wrote %d benchmarks to %s\n", len(results), *baselineFile)
+		return
+	}
+
+	baseline, err := readBaseline(*baselineFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	failures, warnings := compare(baseline, results)
+	for _, warning := range warnings {
+		fmt.Println("Warning: " + warning)
+	}
+	if len(failures) > 0 {
+		fmt.Println("Benchmark regressions:")
+		for _, failure := range failures {
+			fmt.Println("  " + failure)
+		}
+		os.Exit(1)
+	}
+	fmt.Printf("No regressions in %d benchmarks\n", len(results))
+}
@@ -44,14 +44,6 @@ type WowDatabase struct {
 	Encounters []*proto.PresetEncounter
 }
 
-var runeOverrideNames = make(map[string]*proto.UIRune, len(RuneOverrides))
-
-func init() {
-	for _, v := range RuneOverrides {
-		runeOverrideNames[v.Name] = v
-	}
-}
-
 func NewWowDatabase() *WowDatabase {
 	return &WowDatabase{
 		Items:          make(map[int32]*proto.UIItem),
@@ -183,9 +175,6 @@ func (db *WowDatabase) AddRune(id int32, tooltip WowheadItemResponse) {
 	if tooltip.GetName() == "" || tooltip.GetIcon() == "" {
 		return
 	}
-	if runeOverrideNames[tooltip.GetName()] != nil {
-		return
-	}
 
 	db.Runes[id] = &proto.UIRune{
 		Id:             id,
@@ -310,6 +299,7 @@ func ReadDatabaseFromJson(jsonStr string) *WowDatabase {
 		Items:          sliceToMap(dbProto.Items),
 		RandomSuffixes: sliceToMap(dbProto.RandomSuffixes),
 		Enchants:       enchants,
+		Runes:          sliceToMap(dbProto.Runes),
 		Zones:          sliceToMap(dbProto.Zones),
 		Npcs:           sliceToMap(dbProto.Npcs),
 		Factions:       sliceToMap(dbProto.Factions),
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/sod/sim"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/tools/database"
+)
+
+type coverageEntry struct {
+	phase   int32
+	slot    string
+	id      int32
+	name    string
+	details []string
+}
+
+// Builds a report of the items, enchants and item sets in the database whose
+// effects aren't implemented, so the sim treats them as if they only had stats.
+//
+// Item effects are read from the tooltips where available, falling back to
+// core.UIItemHasEffect.
+func GenerateCoverageReport(db *database.WowDatabase, itemTooltips map[int32]database.WowheadItemResponse) string {
+	sim.RegisterAll()
+
+	var items []coverageEntry
+	numItemsWithEffects := 0
+	for _, item := range db.Items {
+		var effects []string
+		if tooltip, ok := itemTooltips[item.Id]; ok {
+			effects = tooltip.GetEffects()
+		} else if core.UIItemHasEffect(item) {
+			effects = []string{"(no tooltip available)"}
+		}
+		if len(effects) == 0 {
+			continue
+		}
+
+		numItemsWithEffects++
+		if !core.HasItemEffect(item.Id) {
+			items = append(items, coverageEntry{
+				phase:   item.Phase,
+				slot:    itemSlotName(item),
+				id:      item.Id,
+				name:    item.Name,
+				details: effects,
+			})
+		}
+	}
+
+	// Enchants without stats do nothing at all unless they have an effect.
+	var enchants []coverageEntry
+	for _, enchant := range db.Enchants {
+		if slices.ContainsFunc(enchant.Stats, func(stat float64) bool { return stat != 0 }) {
+			continue
+		}
+		if core.HasEnchantEffect(enchant.EffectId) || core.HasWeaponEffect(enchant.EffectId) {
+			continue
+		}
+		enchants = append(enchants, coverageEntry{
+			phase: enchant.Phase,
+			slot:  strings.TrimPrefix(enchant.Type.String(), "ItemType"),
+			id:    enchant.EffectId,
+			name:  enchant.Name,
+		})
+	}
+
+	setsByName := make(map[string]*coverageEntry)
+	for _, item := range db.Items {
+		if item.SetName == "" || core.HasItemSet(item.SetId, item.SetName) {
+			continue
+		}
+		set, ok := setsByName[item.SetName]
+		if !ok {
+			set = &coverageEntry{id: item.SetId, name: item.SetName, slot: "Set"}
+			setsByName[item.SetName] = set
+		}
+		set.phase = max(set.phase, item.Phase)
+		set.details = append(set.details, fmt.Sprintf("%d %s", item.Id, item.Name))
+	}
+	var sets []coverageEntry
+	for _, set := range setsByName {
+		slices.Sort(set.details)
+		sets = append(sets, *set)
+	}
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "Unimplemented item effects: %d of %d items with effects\n", len(items), numItemsWithEffects)
+	writeCoverageEntries(&report, items)
+	fmt.Fprintf(&report, "\nEnchants without stats or effects: %d\n", len(enchants))
+	writeCoverageEntries(&report, enchants)
+	fmt.Fprintf(&report, "\nUnimplemented item sets: %d\n", len(sets))
+	writeCoverageEntries(&report, sets)
+	return report.String()
+}
+
+func itemSlotName(item *proto.UIItem) string {
+	if item.Type == proto.ItemType_ItemTypeWeapon {
+		return strings.TrimPrefix(item.HandType.String(), "HandType")
+	}
+	return strings.TrimPrefix(item.Type.String(), "ItemType")
+}
+
+// Writes the entries grouped by phase and then slot.
+func writeCoverageEntries(report *strings.Builder, entries []coverageEntry) {
+	slices.SortFunc(entries, func(a, b coverageEntry) int {
+		if a.phase != b.phase {
+			return cmp.Compare(a.phase, b.phase)
+		}
+		if a.slot != b.slot {
+			return cmp.Compare(a.slot, b.slot)
+		}
+		if a.id != b.id {
+			return cmp.Compare(a.id, b.id)
+		}
+		return cmp.Compare(a.name, b.name)
+	})
+
+	for i, entry := range entries {
+		if i == 0 || entry.phase != entries[i-1].phase {
+			fmt.Fprintf(report, "Phase %d\n", entry.phase)
+		}
+		if i == 0 || entry.phase != entries[i-1].phase || entry.slot != entries[i-1].slot {
+			fmt.Fprintf(report, "  %s\n", entry.slot)
+		}
+		fmt.Fprintf(report, "    %d %s\n", entry.id, entry.name)
+		for _, detail := range entry.details {
+			fmt.Fprintf(report, "      %s\n", detail)
+		}
+	}
+}
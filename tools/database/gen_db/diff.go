@@ -0,0 +1,301 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/sod/sim"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+	"github.com/wowsims/sod/tools/database"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type DiffChangeType string
+
+const (
+	DiffAdded   DiffChangeType = "added"
+	DiffRemoved DiffChangeType = "removed"
+	DiffChanged DiffChangeType = "changed"
+)
+
+type DiffFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// A change to one entity of the database.
+type DiffChange struct {
+	Kind   string            `json:"kind"` // item, item_set, random_suffix, enchant or rune
+	ID     int32             `json:"id"`
+	Name   string            `json:"name"`
+	Phase  int32             `json:"phase,omitempty"`
+	Change DiffChangeType    `json:"change"`
+	Fields []DiffFieldChange `json:"fields,omitempty"`
+
+	// Set if the sim implements the entity's effects in code, so the change
+	// may need the implementation to be updated.
+	ReviewReason string `json:"reviewReason,omitempty"`
+}
+
+type DatabaseDiff struct {
+	Changes []DiffChange `json:"changes"`
+}
+
+// Compares two databases, typically the db.json before and after regenerating
+// it. Items, enchants and sets whose effects are registered in the sim are
+// flagged for review.
+func DiffDatabases(oldDB *database.WowDatabase, newDB *database.WowDatabase) *DatabaseDiff {
+	sim.RegisterAll()
+	diff := &DatabaseDiff{}
+
+	diffEntities(diff, "item", oldDB.Items, newDB.Items, diffProtos[*proto.UIItem], func(item *proto.UIItem) (int32, string, int32) {
+		return item.Id, item.Name, item.Phase
+	}, func(item *proto.UIItem) string {
+		if core.HasItemEffect(item.Id) {
+			return "has a registered item effect"
+		}
+		if item.SetName != "" && core.HasItemSet(item.SetId, item.SetName) {
+			return fmt.Sprintf("is part of the registered set %s", item.SetName)
+		}
+		return ""
+	})
+
+	diffEntities(diff, "item_set", itemSets(oldDB), itemSets(newDB), diffItemSets, func(set *itemSet) (int32, string, int32) {
+		return set.id, set.name, set.phase
+	}, func(set *itemSet) string {
+		if core.HasItemSet(set.id, set.name) {
+			return "is a registered set"
+		}
+		return ""
+	})
+
+	diffEntities(diff, "random_suffix", oldDB.RandomSuffixes, newDB.RandomSuffixes, diffProtos[*proto.ItemRandomSuffix], func(suffix *proto.ItemRandomSuffix) (int32, string, int32) {
+		return suffix.Id, suffix.Name, 0
+	}, nil)
+
+	diffEntities(diff, "enchant", oldDB.Enchants, newDB.Enchants, diffProtos[*proto.UIEnchant], func(enchant *proto.UIEnchant) (int32, string, int32) {
+		return enchant.EffectId, enchant.Name, enchant.Phase
+	}, func(enchant *proto.UIEnchant) string {
+		if core.HasEnchantEffect(enchant.EffectId) || core.HasWeaponEffect(enchant.EffectId) {
+			return "has a registered enchant effect"
+		}
+		return ""
+	})
+
+	diffEntities(diff, "rune", oldDB.Runes, newDB.Runes, diffProtos[*proto.UIRune], func(rune *proto.UIRune) (int32, string, int32) {
+		return rune.Id, rune.Name, 0
+	}, nil)
+
+	return diff
+}
+
+func diffEntities[K comparable, T any](diff *DatabaseDiff, kind string, oldEntities map[K]T, newEntities map[K]T, compare func(T, T) []DiffFieldChange, describe func(T) (int32, string, int32), reviewReason func(T) string) {
+	var changes []DiffChange
+	newChange := func(entity T, change DiffChangeType) DiffChange {
+		id, name, phase := describe(entity)
+		diffChange := DiffChange{Kind: kind, ID: id, Name: name, Phase: phase, Change: change}
+		if reviewReason != nil {
+			diffChange.ReviewReason = reviewReason(entity)
+		}
+		return diffChange
+	}
+
+	for key, newEntity := range newEntities {
+		oldEntity, ok := oldEntities[key]
+		if !ok {
+			changes = append(changes, newChange(newEntity, DiffAdded))
+			continue
+		}
+		if fields := compare(oldEntity, newEntity); len(fields) > 0 {
+			change := newChange(newEntity, DiffChanged)
+			change.Fields = fields
+			changes = append(changes, change)
+		}
+	}
+	for key, oldEntity := range oldEntities {
+		if _, ok := newEntities[key]; !ok {
+			changes = append(changes, newChange(oldEntity, DiffRemoved))
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b DiffChange) int {
+		if a.ID != b.ID {
+			return cmp.Compare(a.ID, b.ID)
+		}
+		if a.Change != b.Change {
+			return cmp.Compare(a.Change, b.Change)
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	diff.Changes = append(diff.Changes, changes...)
+}
+
+// Lists the fields which differ, with the stats split into one field per stat.
+func diffProtos[T googleProto.Message](oldEntity T, newEntity T) []DiffFieldChange {
+	oldMessage, newMessage := oldEntity.ProtoReflect(), newEntity.ProtoReflect()
+	var changes []DiffFieldChange
+	fields := oldMessage.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		oldValue, newValue := oldMessage.Get(field), newMessage.Get(field)
+		if field.Name() == "stats" && field.IsList() {
+			changes = append(changes, diffStats(oldValue.List(), newValue.List())...)
+			continue
+		}
+		oldString, newString := formatFieldValue(field, oldValue), formatFieldValue(field, newValue)
+		if oldString != newString {
+			changes = append(changes, DiffFieldChange{Field: string(field.Name()), Old: oldString, New: newString})
+		}
+	}
+	return changes
+}
+
+func diffStats(oldStats protoreflect.List, newStats protoreflect.List) []DiffFieldChange {
+	var changes []DiffFieldChange
+	statValue := func(list protoreflect.List, i int) float64 {
+		if i < list.Len() {
+			return list.Get(i).Float()
+		}
+		return 0
+	}
+	for i := 0; i < max(oldStats.Len(), newStats.Len()); i++ {
+		oldValue, newValue := statValue(oldStats, i), statValue(newStats, i)
+		if oldValue != newValue {
+			changes = append(changes, DiffFieldChange{
+				Field: "stats." + stats.Stat(i).StatName(),
+				Old:   strconv.FormatFloat(oldValue, 'f', -1, 64),
+				New:   strconv.FormatFloat(newValue, 'f', -1, 64),
+			})
+		}
+	}
+	return changes
+}
+
+func formatFieldValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	if field.IsList() {
+		list := value.List()
+		elements := make([]string, list.Len())
+		for i := range elements {
+			elements[i] = formatSingularValue(field, list.Get(i))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	}
+	return formatSingularValue(field, value)
+}
+
+func formatSingularValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return strconv.Itoa(int(value.Enum()))
+	case protoreflect.MessageKind:
+		data, err := protojson.MarshalOptions{}.Marshal(value.Message().Interface())
+		if err != nil {
+			return err.Error()
+		}
+		return string(data)
+	case protoreflect.DoubleKind, protoreflect.FloatKind:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	}
+	return value.String()
+}
+
+type itemSet struct {
+	id    int32
+	name  string
+	phase int32
+	items []int32
+}
+
+func diffItemSets(oldSet *itemSet, newSet *itemSet) []DiffFieldChange {
+	if slices.Equal(oldSet.items, newSet.items) {
+		return nil
+	}
+	format := func(items []int32) string {
+		ids := make([]string, len(items))
+		for i, id := range items {
+			ids[i] = strconv.Itoa(int(id))
+		}
+		return "[" + strings.Join(ids, ", ") + "]"
+	}
+	return []DiffFieldChange{{Field: "items", Old: format(oldSet.items), New: format(newSet.items)}}
+}
+
+// Groups the items by set, keyed by set name since not every set has an ID.
+func itemSets(db *database.WowDatabase) map[string]*itemSet {
+	sets := make(map[string]*itemSet)
+	for _, item := range db.Items {
+		if item.SetName == "" {
+			continue
+		}
+		set, ok := sets[item.SetName]
+		if !ok {
+			set = &itemSet{id: item.SetId, name: item.SetName}
+			sets[item.SetName] = set
+		}
+		set.phase = max(set.phase, item.Phase)
+		set.items = append(set.items, item.Id)
+	}
+	for _, set := range sets {
+		slices.Sort(set.items)
+	}
+	return sets
+}
+
+func (diff *DatabaseDiff) ToJson() string {
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return string(data) + "\n"
+}
+
+// A changelog grouped by kind, preceded by the changes which need review.
+func (diff *DatabaseDiff) ToText() string {
+	var report strings.Builder
+
+	var review []DiffChange
+	for _, change := range diff.Changes {
+		if change.ReviewReason != "" {
+			review = append(review, change)
+		}
+	}
+	fmt.Fprintf(&report, "Changes needing review: %d\n", len(review))
+	for _, change := range review {
+		fmt.Fprintf(&report, "  %s %s %d %s: %s\n", change.Change, change.Kind, change.ID, change.Name, change.ReviewReason)
+	}
+
+	for _, kind := range []string{"item", "item_set", "random_suffix", "enchant", "rune"} {
+		counts := make(map[DiffChangeType]int)
+		var lines strings.Builder
+		for _, change := range diff.Changes {
+			if change.Kind != kind {
+				continue
+			}
+			counts[change.Change]++
+			marker := map[DiffChangeType]string{DiffAdded: "+", DiffRemoved: "-", DiffChanged: "~"}[change.Change]
+			fmt.Fprintf(&lines, "  %s %d %s", marker, change.ID, change.Name)
+			if change.Phase != 0 {
+				fmt.Fprintf(&lines, " (phase %d)", change.Phase)
+			}
+			lines.WriteString("\n")
+			for _, field := range change.Fields {
+				fmt.Fprintf(&lines, "      %s: %s -> %s\n", field.Field, field.Old, field.New)
+			}
+		}
+		fmt.Fprintf(&report, "\n%s: %d added, %d removed, %d changed\n", kind, counts[DiffAdded], counts[DiffRemoved], counts[DiffChanged])
+		report.WriteString(lines.String())
+	}
+	return report.String()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+	"github.com/wowsims/sod/tools/database"
+)
+
+const felstriker = 228757
+
+func testDatabases() (*database.WowDatabase, *database.WowDatabase) {
+	oldDB, newDB := database.NewWowDatabase(), database.NewWowDatabase()
+	for _, db := range []*database.WowDatabase{oldDB, newDB} {
+		db.Items[felstriker] = &proto.UIItem{Id: felstriker, Name: "Felstriker", Ilvl: 63, Phase: 1}
+		db.Items[4] = &proto.UIItem{Id: 4, Name: "Set Helm", SetName: "Test Set", Phase: 1}
+	}
+
+	oldDB.Items[1] = &proto.UIItem{Id: 1, Name: "Removed Item"}
+	newDB.Items[2] = &proto.UIItem{Id: 2, Name: "Added Item", Phase: 3}
+
+	oldDB.Items[3] = &proto.UIItem{Id: 3, Name: "Changed Item", Phase: 1, Stats: stats.Stats{stats.SpellPower: 10}.ToFloatArray()}
+	newDB.Items[3] = &proto.UIItem{Id: 3, Name: "Changed Item", Phase: 2, Stats: stats.Stats{stats.SpellPower: 12}.ToFloatArray()}
+
+	newDB.Items[5] = &proto.UIItem{Id: 5, Name: "Set Boots", SetName: "Test Set", Phase: 2}
+	newDB.Items[felstriker].Ilvl = 65
+
+	return oldDB, newDB
+}
+
+func TestDiffDatabases(t *testing.T) {
+	diff := DiffDatabases(testDatabases())
+
+	expected := `Changes needing review: 1
+  changed item 228757 Felstriker: has a registered item effect
+
+item: 2 added, 1 removed, 2 changed
+  - 1 Removed Item
+  + 2 Added Item (phase 3)
+  ~ 3 Changed Item (phase 2)
+      stats.SpellPower: 10 -> 12
+      phase: 1 -> 2
+  + 5 Set Boots (phase 2)
+  ~ 228757 Felstriker (phase 1)
+      ilvl: 63 -> 65
+
+item_set: 0 added, 0 removed, 1 changed
+  ~ 0 Test Set (phase 2)
+      items: [4] -> [4, 5]
+
+random_suffix: 0 added, 0 removed, 0 changed
+
+enchant: 0 added, 0 removed, 0 changed
+
+rune: 0 added, 0 removed, 0 changed
+`
+	if text := diff.ToText(); text != expected {
+		t.Fatalf("Expected text:\n%s\nGot:\n%s", expected, text)
+	}
+
+	// The diff doesn't depend on the order in which the databases' maps are iterated.
+	for i := 0; i < 10; i++ {
+		if other := DiffDatabases(testDatabases()).ToJson(); other != diff.ToJson() {
+			t.Fatalf("Expected the same JSON for the same databases, got:\n%s\nand:\n%s", diff.ToJson(), other)
+		}
+	}
+
+	var decoded DatabaseDiff
+	if err := json.Unmarshal([]byte(diff.ToJson()), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Changes) != len(diff.Changes) || decoded.Changes[4].ReviewReason != "has a registered item effect" {
+		t.Fatalf("Expected the JSON to round-trip, got %+v", decoded.Changes)
+	}
+}
@@ -31,11 +31,19 @@ import (
 // Note: This does not make network requests, only regenerates core db binary and json files from existing inputs
 // go run ./tools/database/gen_db -outDir=assets -gen=db
 
+// To list the items, enchants and item sets in db.json whose effects aren't implemented in the sim:
+// go run ./tools/database/gen_db -outDir=assets -gen=coverage
+
+// To list what changed between two versions of db.json, flagging changes to items, enchants and sets with registered effects:
+// go run ./tools/database/gen_db -gen=diff old_db.json assets/database/db.json
+// go run ./tools/database/gen_db -gen=diff -format=json old_db.json assets/database/db.json
+
 var exactId = flag.Int("id", 0, "ID to scan for")
 var minId = flag.Int("minid", 1, "Minimum ID to scan for")
 var maxId = flag.Int("maxid", 31000, "Maximum ID to scan for")
 var outDir = flag.String("outDir", "assets", "Path to output directory for writing generated .go files.")
-var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'wotlk-items', and 'wago-db2-items'")
+var diffFormat = flag.String("format", "text", "Output format of -gen=diff, 'text' or 'json'")
+var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'coverage', 'diff', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'wotlk-items', and 'wago-db2-items'")
 
 func main() {
 	flag.Parse()
@@ -68,6 +76,27 @@ func main() {
 	} else if *genAsset == "wago-db2-items" {
 		tools.WriteFile(fmt.Sprintf("%s/wago_db2_items.csv", inputsDir), tools.ReadWebRequired("https://wago.tools/db2/ItemSparse/csv?build=1.15.3.55646"))
 		return
+	} else if *genAsset == "coverage" {
+		itemTooltips := database.NewWowheadItemTooltipManager(fmt.Sprintf("%s/wowhead_item_tooltips.csv", inputsDir)).Read()
+		db := database.ReadDatabaseFromJson(tools.ReadFile(fmt.Sprintf("%s/db.json", dbDir)))
+		fmt.Print(GenerateCoverageReport(db, itemTooltips))
+		return
+	} else if *genAsset == "diff" {
+		if flag.NArg() != 2 {
+			log.Fatalf("-gen=diff needs the old and new db.json files, got %d arguments", flag.NArg())
+		}
+		oldDB := database.ReadDatabaseFromJson(tools.ReadFile(flag.Arg(0)))
+		newDB := database.ReadDatabaseFromJson(tools.ReadFile(flag.Arg(1)))
+		diff := DiffDatabases(oldDB, newDB)
+		switch *diffFormat {
+		case "text":
+			fmt.Print(diff.ToText())
+		case "json":
+			fmt.Print(diff.ToJson())
+		default:
+			log.Fatalf("Invalid format %q, expected 'text' or 'json'", *diffFormat)
+		}
+		return
 	} else if *genAsset != "db" {
 		panic("Invalid gen value")
 	}
@@ -151,15 +180,22 @@ func main() {
 		}
 	}
 
+	overrides, err := database.ReadOverrideFiles(fmt.Sprintf("%s/overrides", inputsDir))
+	if err != nil {
+		log.Fatalf("Failed to read overrides: %s", err)
+	}
+
+	// Runes with an override of their name replace the scraped ones of that name.
+	overrideRuneNames := overrides.RuneNames()
 	for id, rune := range runeTooltips {
-		if !slices.Contains(database.UnimplementedRuneOverrides, id) {
+		if !slices.Contains(database.UnimplementedRuneOverrides, id) && !overrideRuneNames[rune.GetName()] {
 			db.AddRune(id, rune)
 		}
 	}
 
-	db.MergeItems(database.ItemOverrides)
-	db.MergeEnchants(database.EnchantOverrides)
-	db.MergeRunes(database.RuneOverrides)
+	for _, unused := range db.ApplyOverrides(overrides) {
+		log.Printf("Warning: override %s", unused)
+	}
 	ApplyGlobalFilters(db)
 	AttachFactionInformation(db, wagoItems)
 	AttachItemSetIDs(db, wagoItems)
@@ -219,6 +255,12 @@ func main() {
 	db.MergeNpcs(atlasDBProto.Npcs)
 	db.MergeFactions(atlasDBProto.Factions)
 
+	// Effects come from the item tooltips, and without them the sim can't warn
+	// about equipped items whose effects aren't implemented.
+	if itemsWithEffects := core.FilterMap(db.Items, func(_ int32, item *proto.UIItem) bool { return item.HasEffect }); len(itemsWithEffects) == 0 {
+		log.Fatalf("No item has an effect, so %s/wowhead_item_tooltips.csv is missing or outdated. Scrape it again with -gen=wowhead-items.", inputsDir)
+	}
+
 	db.WriteBinaryAndJson(fmt.Sprintf("%s/db.bin", dbDir), fmt.Sprintf("%s/db.json", dbDir))
 }
 
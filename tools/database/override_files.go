@@ -0,0 +1,185 @@
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core/hujsonproto"
+	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// An override, and where it was defined for reporting, e.g. "items.hujson: items[3]".
+type Override[T googleProto.Message] struct {
+	Value  T
+	Source string
+}
+
+type Overrides struct {
+	Items    []Override[*proto.UIItem]
+	Enchants []Override[*proto.UIEnchant]
+	Runes    []Override[*proto.UIRune]
+}
+
+// Returns the names of the runes which the overrides give a name.
+func (overrides *Overrides) RuneNames() map[string]bool {
+	names := make(map[string]bool)
+	for _, override := range overrides.Runes {
+		if override.Value.Name != "" {
+			names[override.Value.Name] = true
+		}
+	}
+	return names
+}
+
+// The sections an override file may have.
+var overrideFileSections = []string{"items", "enchants", "runes"}
+
+// Reads the .hujson and .json files of the directory, in name order. Each file
+// is a UIDatabase in the format of hujsonproto.Unmarshal with only items,
+// enchants and runes, so the field names are the same as in db.json.
+func ReadOverrideFiles(dir string) (*Overrides, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := &Overrides{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || (filepath.Ext(name) != ".hujson" && filepath.Ext(name) != ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if err := overrides.parseFile(name, data); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return overrides, nil
+}
+
+func (overrides *Overrides) parseFile(name string, data []byte) error {
+	db := &proto.UIDatabase{}
+	if err := hujsonproto.Unmarshal(data, db); err != nil {
+		return err
+	}
+
+	var sectionErr error
+	db.ProtoReflect().Range(func(field protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !slices.Contains(overrideFileSections, field.JSONName()) {
+			sectionErr = fmt.Errorf("unknown section %q, expected one of %s", field.JSONName(), strings.Join(overrideFileSections, ", "))
+		}
+		return sectionErr == nil
+	})
+	if sectionErr != nil {
+		return sectionErr
+	}
+
+	for i, item := range db.Items {
+		if item.Id == 0 {
+			return fmt.Errorf("items[%d]: missing id", i)
+		}
+		overrides.Items = append(overrides.Items, Override[*proto.UIItem]{Value: item, Source: fmt.Sprintf("%s: items[%d]", name, i)})
+	}
+	for i, enchant := range db.Enchants {
+		// Importers and exporters need both IDs.
+		if enchant.EffectId == 0 || enchant.SpellId == 0 {
+			return fmt.Errorf("enchants[%d]: missing effectId or spellId", i)
+		}
+		overrides.Enchants = append(overrides.Enchants, Override[*proto.UIEnchant]{Value: enchant, Source: fmt.Sprintf("%s: enchants[%d]", name, i)})
+	}
+	for i, rune := range db.Runes {
+		if rune.Id == 0 {
+			return fmt.Errorf("runes[%d]: missing id", i)
+		}
+		overrides.Runes = append(overrides.Runes, Override[*proto.UIRune]{Value: rune, Source: fmt.Sprintf("%s: runes[%d]", name, i)})
+	}
+	return nil
+}
+
+// Merges the overrides into the database, and returns the ones which no
+// longer change anything, because the data they correct has been fixed.
+func (db *WowDatabase) ApplyOverrides(overrides *Overrides) []string {
+	var unused []string
+	for _, override := range overrides.Items {
+		if existing, ok := db.Items[override.Value.Id]; ok && overrideIsRedundant(override.Value, existing) {
+			unused = append(unused, fmt.Sprintf("%s: item %d no longer changes anything", override.Source, override.Value.Id))
+		}
+		db.MergeItem(googleProto.Clone(override.Value).(*proto.UIItem))
+	}
+	for _, override := range overrides.Enchants {
+		if existing, ok := db.Enchants[EnchantToDBKey(override.Value)]; ok && overrideIsRedundant(override.Value, existing) {
+			unused = append(unused, fmt.Sprintf("%s: enchant %d no longer changes anything", override.Source, override.Value.EffectId))
+		}
+		db.MergeEnchant(googleProto.Clone(override.Value).(*proto.UIEnchant))
+	}
+	for _, override := range overrides.Runes {
+		if existing, ok := db.Runes[override.Value.Id]; ok && overrideIsRedundant(override.Value, existing) {
+			unused = append(unused, fmt.Sprintf("%s: rune %d no longer changes anything", override.Source, override.Value.Id))
+		}
+		db.MergeRune(googleProto.Clone(override.Value).(*proto.UIRune))
+	}
+	return unused
+}
+
+// Whether every field the override sets already has its value. Lists other
+// than stats are appended to when merging, so those only need to contain the
+// override's elements already.
+func overrideIsRedundant(override googleProto.Message, existing googleProto.Message) bool {
+	existingMessage := existing.ProtoReflect()
+	redundant := true
+	override.ProtoReflect().Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		existingValue := existingMessage.Get(field)
+		if field.IsList() && field.Name() != "stats" {
+			for i := 0; i < value.List().Len() && redundant; i++ {
+				redundant = listContains(field, existingValue.List(), value.List().Get(i))
+			}
+		} else {
+			redundant = valuesEqual(field, value, existingValue)
+		}
+		return redundant
+	})
+	return redundant
+}
+
+func listContains(field protoreflect.FieldDescriptor, list protoreflect.List, value protoreflect.Value) bool {
+	for i := 0; i < list.Len(); i++ {
+		if singularValuesEqual(field, list.Get(i), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func valuesEqual(field protoreflect.FieldDescriptor, a protoreflect.Value, b protoreflect.Value) bool {
+	if !field.IsList() {
+		return singularValuesEqual(field, a, b)
+	}
+	if a.List().Len() != b.List().Len() {
+		return false
+	}
+	for i := 0; i < a.List().Len(); i++ {
+		if !singularValuesEqual(field, a.List().Get(i), b.List().Get(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func singularValuesEqual(field protoreflect.FieldDescriptor, a protoreflect.Value, b protoreflect.Value) bool {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return googleProto.Equal(a.Message().Interface(), b.Message().Interface())
+	case protoreflect.BytesKind:
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	}
+	return a.Interface() == b.Interface()
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func writeOverrideFile(t *testing.T, contents string) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "overrides.hujson"), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestReadOverrideFiles(t *testing.T) {
+	dir := writeOverrideFile(t, `
+// Comments and trailing commas are allowed.
+{
+	"items": [
+		{"id": 1, "name": "Item", "stats": {"SpellPower": 12, "Stamina": 5}, "phase": 2},
+	],
+	"enchants": [
+		{"effectId": 2, "spellId": 3, "type": "ItemTypeHead"},
+	],
+	"runes": [
+		{"id": 4, "classAllowlist": ["ClassMage"]},
+	],
+}`)
+	// Files with other extensions are ignored.
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not an override"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	overrides, err := ReadOverrideFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overrides.Items) != 1 || len(overrides.Enchants) != 1 || len(overrides.Runes) != 1 {
+		t.Fatalf("Expected one override of each kind, got %d items, %d enchants and %d runes", len(overrides.Items), len(overrides.Enchants), len(overrides.Runes))
+	}
+
+	item := overrides.Items[0]
+	expectedStats := stats.Stats{stats.SpellPower: 12, stats.Stamina: 5}.ToFloatArray()
+	if !googleProto.Equal(item.Value, &proto.UIItem{Id: 1, Name: "Item", Stats: expectedStats, Phase: 2}) {
+		t.Fatalf("Unexpected item override %v", item.Value)
+	}
+	if item.Source != "overrides.hujson: items[0]" {
+		t.Fatalf("Unexpected source %q", item.Source)
+	}
+	if overrides.Enchants[0].Value.Type != proto.ItemType_ItemTypeHead {
+		t.Fatalf("Expected the enchant type to be parsed from its name, got %v", overrides.Enchants[0].Value.Type)
+	}
+	if classes := overrides.Runes[0].Value.ClassAllowlist; len(classes) != 1 || classes[0] != proto.Class_ClassMage {
+		t.Fatalf("Unexpected rune classes %v", classes)
+	}
+}
+
+func TestReadOverrideFilesErrors(t *testing.T) {
+	for _, test := range []struct {
+		contents string
+		err      string
+	}{
+		{`{"items": [{"id": 1, "nmae": "Typo"}]}`, "nmae"},
+		{`{"items": [{"id": 1, "type": "ItemTypeNope"}]}`, "ItemTypeNope"},
+		{`{"gems": []}`, "gems"},
+		{`{"zones": [{"id": 1}]}`, `unknown section "zones"`},
+		{`{"items": [{"id": 1, "stats": {"SpellPowr": 12}}]}`, `items[0]: unknown stat "SpellPowr"`},
+		{`{"items": [{"name": "No ID"}]}`, "items[0]: missing id"},
+		{`{"enchants": [{"effectId": 2}]}`, "enchants[0]: missing effectId or spellId"},
+	} {
+		_, err := ReadOverrideFiles(writeOverrideFile(t, test.contents))
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("Expected an error containing %q for %s, got %v", test.err, test.contents, err)
+		}
+		if !strings.HasPrefix(err.Error(), "overrides.hujson: ") {
+			t.Fatalf("Expected the error to name the file, got %v", err)
+		}
+	}
+}
+
+func TestOverrideIsRedundant(t *testing.T) {
+	existing := &proto.UIItem{
+		Id:             1,
+		Name:           "Item",
+		Phase:          2,
+		Stats:          stats.Stats{stats.SpellPower: 12}.ToFloatArray(),
+		ClassAllowlist: []proto.Class{proto.Class_ClassMage, proto.Class_ClassWarlock},
+	}
+
+	for _, test := range []struct {
+		override  *proto.UIItem
+		redundant bool
+	}{
+		// Scalars must be equal.
+		{&proto.UIItem{Id: 1, Phase: 2}, true},
+		{&proto.UIItem{Id: 1, Phase: 3}, false},
+		{&proto.UIItem{Id: 1, Name: "Other"}, false},
+		// Lists are appended to, so they only need to be contained.
+		{&proto.UIItem{Id: 1, ClassAllowlist: []proto.Class{proto.Class_ClassWarlock}}, true},
+		{&proto.UIItem{Id: 1, ClassAllowlist: []proto.Class{proto.Class_ClassPriest}}, false},
+		// Stats are replaced, so they must be equal.
+		{&proto.UIItem{Id: 1, Stats: stats.Stats{stats.SpellPower: 12}.ToFloatArray()}, true},
+		{&proto.UIItem{Id: 1, Stats: stats.Stats{stats.SpellPower: 14}.ToFloatArray()}, false},
+	} {
+		if redundant := overrideIsRedundant(test.override, existing); redundant != test.redundant {
+			t.Fatalf("Expected override %v to be redundant: %t, got %t", test.override, test.redundant, redundant)
+		}
+	}
+}
+
+func TestApplyOverridesReportsUnused(t *testing.T) {
+	db := NewWowDatabase()
+	db.Items[1] = &proto.UIItem{Id: 1, Name: "Item", Phase: 2}
+	db.Runes[4] = &proto.UIRune{Id: 4, Name: "Rune"}
+
+	unused := db.ApplyOverrides(&Overrides{
+		Items: []Override[*proto.UIItem]{
+			{Value: &proto.UIItem{Id: 1, Phase: 2}, Source: "items.hujson: items[0]"},
+			{Value: &proto.UIItem{Id: 1, Ilvl: 60}, Source: "items.hujson: items[1]"},
+			// New items aren't reported, even though there is nothing to change.
+			{Value: &proto.UIItem{Id: 2, Name: "New Item"}, Source: "items.hujson: items[2]"},
+		},
+		Runes: []Override[*proto.UIRune]{
+			{Value: &proto.UIRune{Id: 4, Name: "Rune"}, Source: "runes.hujson: runes[0]"},
+		},
+	})
+
+	expected := []string{
+		"items.hujson: items[0]: item 1 no longer changes anything",
+		"runes.hujson: runes[0]: rune 4 no longer changes anything",
+	}
+	if strings.Join(unused, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Expected unused overrides %q, got %q", expected, unused)
+	}
+	if db.Items[1].Ilvl != 60 || db.Items[2] == nil {
+		t.Fatalf("Expected the overrides to be applied, got %v", db.Items)
+	}
+}
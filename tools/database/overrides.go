@@ -8,107 +8,7 @@ import (
 
 var OtherItemIdsToFetch = []string{}
 
-var ItemOverrides = []*proto.UIItem{
-	// Valentine's day event rewards
-	// {Id: 51804, Phase: 2},
-
-	// SOD Items
-	{Id: 10019, Sources: []*proto.UIItemSource{{
-		Source: &proto.UIItemSource_Crafted{
-			Crafted: &proto.CraftedSource{
-				Profession: proto.Profession_Tailoring, SpellId: 3759,
-			},
-		},
-	}}},
-
-	// Updated profession items not updated in the AtlasLoot DB
-	// Crimson Silk Robe
-	{Id: 217245, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Tailoring, SpellId: 439085}}}}},
-	// Black Mageweave Vest
-	{Id: 217246, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Tailoring, SpellId: 439086}}}}},
-	// Long Silken Cloak
-	{Id: 217252, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Tailoring, SpellId: 439094}}}}},
-	// Enchanter's Cowl
-	{Id: 217257, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Tailoring, SpellId: 439102}}}}},
-	// Big Voodoo Mask
-	{Id: 217259, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Leatherworking, SpellId: 439105}}}}},
-	// Big Voodoo Robe
-	{Id: 217261, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Leatherworking, SpellId: 439108}}}}},
-	// Turtle Scale Breastplate
-	{Id: 217268, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Leatherworking, SpellId: 439116}}}}},
-	// Turtle Scale Gloves
-	{Id: 217270, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Leatherworking, SpellId: 439118}}}}},
-	// Golden Scale Cuirass
-	{Id: 217277, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Blacksmithing, SpellId: 439124}}}}},
-	// Golden Scale Coif
-	{Id: 217279, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Blacksmithing, SpellId: 439126}}}}},
-	// Golden Scale Leggings
-	{Id: 217285, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: proto.Profession_Blacksmithing, SpellId: 439132}}}}},
-
-	// The item tooltip is missing the usual Libram tag
-	{Id: 221457, RangedWeaponType: proto.RangedWeaponType_RangedWeaponTypeLibram},
-
-	// The item tooltip is missing the usual Totem tag
-	{Id: 221464, RangedWeaponType: proto.RangedWeaponType_RangedWeaponTypeTotem},
-
-	// SoD Gnomeregan Quest Necklaces are missing quest info from the gear planner DB
-	{Id: 213343, Sources: []*proto.UIItemSource{
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 80324, Name: "The Mad King"}}},
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 80325, Name: "The Mad King"}}},
-	}},
-	{Id: 213344, Sources: []*proto.UIItemSource{
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 80324, Name: "The Mad King"}}},
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 80325, Name: "The Mad King"}}},
-	}},
-	{Id: 213345, Sources: []*proto.UIItemSource{
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 80324, Name: "The Mad King"}}},
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 80325, Name: "The Mad King"}}},
-	}},
-	{Id: 213346, Sources: []*proto.UIItemSource{
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 80324, Name: "The Mad King"}}},
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 80325, Name: "The Mad King"}}},
-	}},
-
-	// SoD Sunken Temple Drakeclaw Bands are missing quest info from the gear planner DB
-	{Id: 220626, Sources: []*proto.UIItemSource{
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 82081, Name: "A Broken Ritual"}}},
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 82083, Name: "A Broken Ritual"}}},
-	}},
-	{Id: 220627, Sources: []*proto.UIItemSource{
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 82081, Name: "A Broken Ritual"}}},
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 82083, Name: "A Broken Ritual"}}},
-	}},
-	{Id: 220628, Sources: []*proto.UIItemSource{
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 82081, Name: "A Broken Ritual"}}},
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 82083, Name: "A Broken Ritual"}}},
-	}},
-	{Id: 220629, Sources: []*proto.UIItemSource{
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 82081, Name: "A Broken Ritual"}}},
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 82083, Name: "A Broken Ritual"}}},
-	}},
-	{Id: 220630, Sources: []*proto.UIItemSource{
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 82081, Name: "A Broken Ritual"}}},
-		{Source: &proto.UIItemSource_Quest{Quest: &proto.QuestSource{Id: 82083, Name: "A Broken Ritual"}}},
-	}},
-
-	// 2024-08-30 This item randomly vanished from Wowhead after Phase 5 datamining
-	{
-		Id:                 215161,
-		Name:               "Tempered Interference-Negating Helmet",
-		Icon:               "inv_helmet_49",
-		Type:               1,
-		ArmorType:          4,
-		RequiresLevel:      40,
-		Stats:              []float64{20, 0, 14, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 426, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
-		WeaponSkills:       []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
-		Ilvl:               45,
-		Phase:              2,
-		Quality:            4,
-		RequiredProfession: 2,
-		Sources: []*proto.UIItemSource{
-			{Source: &proto.UIItemSource_Crafted{Crafted: &proto.CraftedSource{Profession: 2}}}},
-	},
-}
+// Item overrides are in assets/db_inputs/overrides, see override_files.go.
 
 // Keep these sorted by item ID.
 var ItemAllowList = map[int32]struct{}{
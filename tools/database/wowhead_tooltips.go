@@ -422,6 +422,81 @@ func (item WowheadItemResponse) IsRandomEnchant() bool {
 	return randomEnchantRegex.MatchString(item.Tooltip)
 }
 
+var effectRegex = regexp.MustCompile(`(Use|Equip|Chance on hit): (.+?)</span>`)
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// Equip lines which are fully described by the item's stats.
+var statEffectRegexes = []*regexp.Regexp{
+	spellHealingRegex,
+	spellPowerRegex,
+	spellPowerRegex3,
+	arcaneSpellPowerRegex,
+	fireSpellPowerRegex,
+	frostSpellPowerRegex,
+	holySpellPowerRegex,
+	natureSpellPowerRegex,
+	shadowSpellPowerRegex,
+	hitRegex,
+	hitRegex2,
+	physicalHitRegex,
+	spellHitRegex,
+	critRegex,
+	critRegex2,
+	spellCritRegex,
+	meleeCritRegex,
+	hasteRegex,
+	spellPenetrationRegex,
+	mp5Regex,
+	attackPowerRegex,
+	rangedAttackPowerRegex,
+	rangedAttackPowerRegex2,
+	feralAttackPowerRegex,
+	armorPenetrationRegex,
+	defenseRegex,
+	blockRegex,
+	blockValueRegex,
+	dodgeRegex,
+	parryRegex,
+	axesSkill,
+	swordsSkill,
+	daggersSkill,
+	unarmedSkill,
+	macesSkill,
+	twoHandedAxesSkill,
+	twoHandedSwordsSkill,
+	twoHandedMacesSkill,
+	stavesSkill,
+	polearmsSkill,
+	thrownSkill,
+	bowsSkill,
+	crossbowsSkill,
+	gunsSkill,
+	feralCombatSkill,
+}
+
+// Returns the text of each Use, Equip and Chance on hit line in the tooltip,
+// except for Equip lines which are plain stat bonuses. Set bonuses are not included.
+func (item WowheadItemResponse) GetEffects() []string {
+	var effects []string
+	for _, match := range effectRegex.FindAllStringSubmatch(item.TooltipWithoutSetBonus(), -1) {
+		text := strings.TrimSpace(htmlTagRegex.ReplaceAllString(match[2], ""))
+		if match[1] == "Equip" && isStatEffect(text) {
+			continue
+		}
+		effects = append(effects, match[1]+": "+text)
+	}
+	return effects
+}
+
+func isStatEffect(text string) bool {
+	for _, pattern := range statEffectRegexes {
+		if loc := pattern.FindStringIndex(text); loc != nil && loc[0] == 0 && loc[1] == len(text) {
+			return true
+		}
+	}
+	return false
+}
+
 func (item WowheadItemResponse) IsEquippable() bool {
 	return item.GetItemType() != proto.ItemType_ItemTypeUnknown &&
 		!item.IsPattern()
@@ -690,6 +765,7 @@ func (item WowheadItemResponse) ToItemProto() *proto.UIItem {
 
 		RequiredProfession: item.GetRequiredProfession(),
 		SetName:            item.GetItemSetName(),
+		HasEffect:          len(item.GetEffects()) > 0,
 	}
 
 	if item.GetRequiredProfession() != proto.Profession_ProfessionUnknown {
@@ -1,5 +1,5 @@
-import { EquipmentSpec, ItemSlot, ItemSpec, ItemSwap, Profession, SimDatabase, SimEnchant, SimItem } from '../proto/common.js';
-import { UIEnchant as Enchant, UIItem as Item } from '../proto/ui.js';
+import { EquipmentSpec, ItemSlot, ItemSpec, ItemSwap, Profession, SimDatabase, SimEnchant, SimItem, SimRune } from '../proto/common.js';
+import { UIEnchant as Enchant, UIItem as Item, UIRune as Rune } from '../proto/ui.js';
 import { isBluntWeaponType, isSharpWeaponType } from '../proto_utils/utils.js';
 import { distinct, equalsOrBothNull, getEnumValues } from '../utils.js';
 import { EquippedItem } from './equipped_item.js';
@@ -92,6 +92,7 @@ abstract class BaseGear {
 			items: distinct(equippedItems.map(ei => BaseGear.itemToDB(ei.item))),
 			randomSuffixes: distinct(equippedItems.filter(ei => ei.randomSuffix).map(ei => ei.randomSuffix!)),
 			enchants: distinct(equippedItems.filter(ei => ei.enchant).map(ei => BaseGear.enchantToDB(ei.enchant!))),
+			runes: distinct(equippedItems.filter(ei => ei.rune).map(ei => BaseGear.runeToDB(ei.rune!))),
 		});
 	}
 
@@ -103,7 +104,9 @@ abstract class BaseGear {
 		return SimEnchant.fromJson(Enchant.toJson(enchant), { ignoreUnknownFields: true });
 	}
 
-	// TODO: Add rune
+	private static runeToDB(rune: Rune): SimRune {
+		return SimRune.fromJson(Rune.toJson(rune), { ignoreUnknownFields: true });
+	}
 }
 
 /**